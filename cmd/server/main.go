@@ -3,12 +3,16 @@ package main
 import (
 	"log"
 	"net/http"
+	"strings"
+	"time"
+	_ "time/tzdata" // タイムゾーンの検証のため、タイムゾーンのデータベースを含める（実行イメージにはない）
 
 	"simple-kanban/config"
 	"simple-kanban/internal/handler"
 	"simple-kanban/internal/repository"
 	"simple-kanban/internal/service"
 	"simple-kanban/pkg/logger"
+	"simple-kanban/pkg/mailer"
 	"simple-kanban/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -56,42 +60,116 @@ func main() {
 	calendarSettingsRepo := repository.NewCalendarSettingsRepository(db)
 	calendarEventRepo := repository.NewCalendarEventRepository(db)
 	timerSessionRepo := repository.NewTimerSessionRepository(db)
+	customFieldRepo := repository.NewCustomFieldRepository(db)
+	labelRepo := repository.NewLabelRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	laneRepo := repository.NewLaneRepository(db)
+	taskActivityRepo := repository.NewTaskActivityRepository(db)
+	boardTemplateRepo := repository.NewBoardTemplateRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	automationRepo := repository.NewAutomationRepository(db)
+	tokenRepo := repository.NewPersonalAccessTokenRepository(db)
+	oidcRepo := repository.NewOIDCRepository(db)
+	accountTokenRepo := repository.NewAccountTokenRepository(db)
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	accountRepo := repository.NewAccountRepository(db)
+
+	// メール送信（SMTPが設定されていない場合は、開発時のみファイルに保存）
+	mail, err := mailer.New(cfg.Mail, cfg.Server.Mode)
+	if err != nil {
+		appLogger.Error("メール送信の設定エラー: %v", err)
+		log.Fatalf("メール送信の設定エラー: %v", err)
+	}
 
 	// サービスレイヤーを初期化
-	userService := service.NewUserService(userRepo, cfg)
-	boardService := service.NewBoardService(boardRepo, db)
-	taskService := service.NewTaskService(taskRepo, boardRepo, columnRepo)
+	webhookService := service.NewWebhookService(webhookRepo, boardRepo, nil)
+	automationService := service.NewAutomationService(automationRepo, taskRepo, boardRepo, columnRepo, labelRepo, calendarEventRepo, webhookService)
+	userService := service.NewUserService(userRepo, accountTokenRepo, mail, cfg)
+	tokenService := service.NewPersonalAccessTokenService(tokenRepo, userRepo, boardRepo)
+	oidcService := service.NewOIDCService(cfg, userRepo, oidcRepo, accountTokenRepo, nil)
+	boardService := service.NewBoardService(boardRepo, columnRepo, boardTemplateRepo, workspaceRepo, db, webhookService)
+	taskService := service.NewTaskService(taskRepo, boardRepo, columnRepo, customFieldRepo, laneRepo, webhookService, automationService)
 	calendarService := service.NewCalendarService(calendarSettingsRepo, calendarEventRepo, taskRepo)
-	timerService := service.NewTimerService(timerSessionRepo, taskRepo)
+	timerService := service.NewTimerService(timerSessionRepo, taskRepo, automationService)
+	customFieldService := service.NewCustomFieldService(customFieldRepo, taskRepo, boardService, webhookService)
+	labelService := service.NewLabelService(labelRepo, taskRepo, boardService, webhookService)
+	myWorkService := service.NewMyWorkService(taskRepo, boardRepo, calendarEventRepo, timerSessionRepo)
+	taskBulkService := service.NewTaskBulkService(db, webhookService, automationService)
+	taskTransferService := service.NewTaskTransferService(db, webhookService)
+	taskActivityService := service.NewTaskActivityService(taskActivityRepo, taskRepo, boardRepo)
+	taskRankService := service.NewTaskRankService(taskRepo)
+	laneService := service.NewLaneService(laneRepo, boardRepo, boardService)
+	boardTemplateService := service.NewBoardTemplateService(boardTemplateRepo, boardRepo, labelRepo, customFieldRepo, boardService)
+	boardExportService := service.NewBoardExportService(db, boardService, laneRepo, labelRepo, customFieldRepo)
+	trelloImportService := service.NewTrelloImportService(db)
+	taskCSVService := service.NewTaskCSVService(db, boardService, boardRepo, taskRepo, userRepo, webhookService, automationService)
+	trashService := service.NewTrashService(trashRepo, boardRepo, workspaceRepo, columnRepo, taskRepo, webhookService, cfg.Trash.RetentionDays)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, userRepo)
+	accountService := service.NewAccountService(db, accountRepo, userRepo, boardRepo, calendarSettingsRepo, calendarEventRepo, timerSessionRepo, boardExportService)
 
 	// ハンドラーレイヤーを初期化
 	authHandler := handler.NewAuthHandler(userService)
+	tokenHandler := handler.NewPersonalAccessTokenHandler(tokenService)
+	oidcHandler := handler.NewOIDCHandler(oidcService)
+	workspaceHandler := handler.NewWorkspaceHandler(workspaceService)
 	boardHandler := handler.NewBoardHandler(boardService)
 	taskHandler := handler.NewTaskHandler(taskService)
 	calendarHandler := handler.NewCalendarHandler(calendarService, taskService, appLogger)
 	timerHandler := handler.NewTimerHandler(timerService)
 	analyticsHandler := handler.NewAnalyticsHandler()
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+	labelHandler := handler.NewLabelHandler(labelService)
+	myWorkHandler := handler.NewMyWorkHandler(myWorkService, userService)
+	taskBulkHandler := handler.NewTaskBulkHandler(taskBulkService)
+	taskTransferHandler := handler.NewTaskTransferHandler(taskTransferService, taskActivityService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	laneHandler := handler.NewLaneHandler(laneService)
+	boardTemplateHandler := handler.NewBoardTemplateHandler(boardTemplateService)
+	boardExportHandler := handler.NewBoardExportHandler(boardExportService, trelloImportService)
+	taskCSVHandler := handler.NewTaskCSVHandler(taskCSVService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	automationHandler := handler.NewAutomationHandler(automationService)
+	accountHandler := handler.NewAccountHandler(accountService)
+
+	// 保持期間を過ぎたゴミ箱のデータを定期的に完全削除
+	stopTrashRetention := trashService.StartRetentionJob(time.Duration(cfg.Trash.PurgeIntervalMinutes) * time.Minute)
+	defer stopTrashRetention()
+
+	// 同じ位置への移動の繰り返しで長くなったタスクのランクキーを定期的に振り直す
+	stopRankRebalance := taskRankService.StartRebalanceJob(time.Duration(cfg.Task.RankRebalanceIntervalMinutes) * time.Minute)
+	defer stopRankRebalance()
+
+	// Webhookの配信キューを定期的に処理（失敗した配信は指数バックオフで再試行）
+	stopWebhookDelivery := webhookService.StartDeliveryJob(time.Duration(cfg.Webhook.DeliveryIntervalSeconds) * time.Second)
+	defer stopWebhookDelivery()
+
+	// 期限を過ぎた未完了のタスクに期限切れの自動化ルールを定期的に実行
+	stopAutomationDueDate := automationService.StartDueDateJob(time.Duration(cfg.Automation.DueDateIntervalMinutes) * time.Minute)
+	defer stopAutomationDueDate()
 
 	// Ginルーターを作成
 	router := gin.New()
 
+	// X-Forwarded-Forを信頼するプロキシ（IPアドレスごとのリクエスト数の制限に使用）
+	var trustedProxies []string
+	if cfg.Server.TrustedProxies != "" {
+		trustedProxies = strings.Split(cfg.Server.TrustedProxies, ",")
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("信頼するプロキシの設定エラー: %v", err)
+	}
+
+	// リクエスト数の制限（認証APIはIPアドレスごと、認証が必要なAPIはIPアドレスごととユーザーごと）
+	authRateLimiter := middleware.NewRateLimiter(cfg.RateLimit.AuthPerMinute)
+	apiIPRateLimiter := middleware.NewRateLimiter(cfg.RateLimit.APIIPPerMinute)
+	apiUserRateLimiter := middleware.NewRateLimiter(cfg.RateLimit.APIPerMinute)
+
 	// ミドルウェアを設定
 	router.Use(gin.Logger())   // リクエストログを出力
 	router.Use(gin.Recovery()) // パニック時の復旧
 
 	// CORS設定（開発用）
-	router.Use(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusNoContent)
-			return
-		}
-
-		c.Next()
-	})
+	router.Use(middleware.CORSMiddleware())
 
 	// ヘルスチェックエンドポイント
 	router.GET("/health", func(c *gin.Context) {
@@ -106,43 +184,180 @@ func main() {
 	{
 		// 認証エンドポイント（認証不要）
 		auth := v1.Group("/auth")
+		auth.Use(middleware.RateLimitMiddleware(authRateLimiter, middleware.RateLimitKeyByIP)) // 総当たり対策
 		{
-			auth.POST("/register", authHandler.Register) // ユーザー登録
-			auth.POST("/login", authHandler.Login)       // ログイン
+			auth.POST("/register", authHandler.Register)                    // ユーザー登録
+			auth.POST("/login", authHandler.Login)                          // ログイン
+			auth.POST("/password-reset", authHandler.RequestPasswordReset)  // パスワード再設定の申請（メール送信）
+			auth.POST("/password-reset/confirm", authHandler.ResetPassword) // パスワード再設定
+			auth.POST("/verify-email", authHandler.VerifyEmail)             // メールアドレス確認
+			auth.POST("/2fa/verify", authHandler.VerifyTwoFactor)           // ログイン時の二要素認証
+			auth.GET("/oidc/login", oidcHandler.Login)                      // IDプロバイダーでのログイン開始（OpenID Connect）
+			auth.GET("/oidc/callback", oidcHandler.Callback)                // IDプロバイダーからのコールバック
+			auth.POST("/oidc/exchange", oidcHandler.Exchange)               // コールバックで渡したコードをJWTトークンと交換
 		}
 
 		// 認証が必要なエンドポイント
 		protected := v1.Group("/")
-		protected.Use(middleware.AuthMiddleware(cfg)) // JWT認証ミドルウェア
+		protected.Use(middleware.RateLimitMiddleware(apiIPRateLimiter, middleware.RateLimitKeyByIP))     // 認証前にIPアドレスごとに制限
+		protected.Use(middleware.AuthMiddleware(cfg, tokenService, userService))                         // JWT・パーソナルアクセストークン認証ミドルウェア
+		protected.Use(middleware.RateLimitMiddleware(apiUserRateLimiter, middleware.RateLimitKeyByUser)) // ユーザーごとに制限
 		{
 			// 認証関連（認証後）
-			protected.GET("/auth/profile", authHandler.Profile) // プロフィール取得
+			protected.GET("/auth/profile", authHandler.Profile)                              // プロフィール取得
+			protected.PUT("/auth/profile", authHandler.UpdateProfile)                        // プロフィール更新
+			protected.PUT("/auth/password", authHandler.ChangePassword)                      // パスワード変更
+			protected.PUT("/auth/email", authHandler.ChangeEmail)                            // メールアドレス変更（確認メール送信）
+			protected.POST("/auth/verify-email/resend", authHandler.ResendVerificationEmail) // 確認メールの再送
+
+			// 二要素認証（TOTP）関連（トークンでの操作は不可）
+			twoFactor := protected.Group("/auth/2fa")
+			{
+				twoFactor.POST("/setup", authHandler.SetupTwoFactor)                   // シークレットの発行
+				twoFactor.POST("/enable", authHandler.EnableTwoFactor)                 // 有効化（リカバリーコードの発行）
+				twoFactor.POST("/disable", authHandler.DisableTwoFactor)               // 無効化
+				twoFactor.POST("/recovery-codes", authHandler.RegenerateRecoveryCodes) // リカバリーコードの再発行
+			}
+
+			// アカウントのエクスポート・削除関連（トークンでの操作は不可）
+			account := protected.Group("/account")
+			{
+				account.GET("/export", accountHandler.ExportAccount) // 所有するデータのエクスポート（ZIP）
+				account.DELETE("", accountHandler.DeleteAccount)     // アカウント削除
+			}
+
+			// パーソナルアクセストークン関連（トークンでの操作は不可）
+			tokens := protected.Group("/tokens")
+			{
+				tokens.GET("", tokenHandler.ListTokens)         // トークン一覧取得
+				tokens.POST("", tokenHandler.CreateToken)       // トークン発行
+				tokens.DELETE("/:id", tokenHandler.RevokeToken) // トークン失効
+			}
+
+			// ワークスペース関連
+			workspaces := protected.Group("/workspaces")
+			{
+				workspaces.GET("", workspaceHandler.GetWorkspaces)                        // ワークスペース一覧取得
+				workspaces.POST("", workspaceHandler.CreateWorkspace)                     // ワークスペース作成
+				workspaces.PUT("/:id", workspaceHandler.UpdateWorkspace)                  // ワークスペース更新（管理者）
+				workspaces.DELETE("/:id", workspaceHandler.DeleteWorkspace)               // ワークスペース削除（管理者）
+				workspaces.GET("/:id/members", workspaceHandler.GetMembers)               // メンバー一覧取得
+				workspaces.POST("/:id/members", workspaceHandler.AddMember)               // メンバー追加（管理者）
+				workspaces.PUT("/:id/members/:user_id", workspaceHandler.UpdateMember)    // メンバーの役割変更（管理者）
+				workspaces.DELETE("/:id/members/:user_id", workspaceHandler.RemoveMember) // メンバー削除・退出
+			}
 
 			// ボード関連
 			boards := protected.Group("/boards")
 			{
-				boards.GET("", boardHandler.GetUserBoards)                         // ボード一覧取得
-				boards.GET("/with-columns", boardHandler.GetUserBoardsWithColumns) // ボード一覧取得（カラム・タスク付き）
-				boards.POST("", boardHandler.CreateBoard)                          // ボード作成
-				boards.GET("/:id/columns", boardHandler.GetBoardWithColumns)       // ボード詳細（カラム付き）
-				boards.PUT("/:id", boardHandler.UpdateBoard)                       // ボード更新
-				boards.DELETE("/:id", boardHandler.DeleteBoard)                    // ボード削除
+				boards.GET("", boardHandler.GetUserBoards)                                     // ボード一覧取得
+				boards.GET("/with-columns", boardHandler.GetUserBoardsWithColumns)             // ボード一覧取得（カラム・タスク付き）
+				boards.POST("", boardHandler.CreateBoard)                                      // ボード作成
+				boards.POST("/import", boardExportHandler.ImportBoard)                         // ボードのインポート（JSON）
+				boards.POST("/import/trello", boardExportHandler.ImportTrelloBoard)            // Trelloのボードのインポート（JSON）
+				boards.GET("/:id/columns", boardHandler.GetBoardWithColumns)                   // ボード詳細（カラム付き）
+				boards.PUT("/:id", boardHandler.UpdateBoard)                                   // ボード更新
+				boards.DELETE("/:id", boardHandler.DeleteBoard)                                // ボード削除
+				boards.GET("/:id/tasks", taskHandler.ListBoardTasks)                           // ボード内タスクの絞り込み・並び替え
+				boards.GET("/:id/custom-fields", customFieldHandler.GetBoardFields)            // カスタムフィールド一覧取得
+				boards.POST("/:id/custom-fields", customFieldHandler.CreateField)              // カスタムフィールド作成
+				boards.GET("/:id/labels", labelHandler.GetBoardLabels)                         // ラベル一覧取得
+				boards.POST("/:id/labels", labelHandler.CreateLabel)                           // ラベル作成
+				boards.GET("/:id/trash", trashHandler.GetBoardTrash)                           // ボードのゴミ箱
+				boards.GET("/:id/lanes", laneHandler.GetBoardLanes)                            // レーン一覧取得
+				boards.POST("/:id/lanes", laneHandler.CreateLane)                              // レーン作成
+				boards.GET("/:id/swimlanes", laneHandler.GetSwimlanes)                         // スイムレーン表示
+				boards.POST("/:id/save-as-template", boardTemplateHandler.SaveBoardAsTemplate) // テンプレートとして保存
+				boards.POST("/:id/clone", boardHandler.CloneBoard)                             // ボード複製
+				boards.GET("/:id/export", boardExportHandler.ExportBoard)                      // ボードのエクスポート（JSON）
+				boards.GET("/:id/export/csv", taskCSVHandler.ExportTasksCSV)                   // タスクのエクスポート（CSV）
+				boards.POST("/:id/import/csv", taskCSVHandler.ImportTasksCSV)                  // タスクのインポート（CSV）
+				boards.GET("/:id/webhooks", webhookHandler.GetBoardWebhooks)                   // Webhook一覧取得
+				boards.POST("/:id/webhooks", webhookHandler.CreateWebhook)                     // Webhook作成
+				boards.GET("/:id/automation-rules", automationHandler.GetBoardRules)           // 自動化ルール一覧取得
+				boards.POST("/:id/automation-rules", automationHandler.CreateRule)             // 自動化ルール作成
+				boards.GET("/:id/automation-executions", automationHandler.GetExecutions)      // 自動化ルールの実行ログ
+			}
+
+			// ボードテンプレート関連
+			boardTemplates := protected.Group("/board-templates")
+			{
+				boardTemplates.GET("", boardTemplateHandler.ListTemplates)         // テンプレート一覧取得
+				boardTemplates.POST("", boardTemplateHandler.CreateTemplate)       // テンプレート作成
+				boardTemplates.GET("/:id", boardTemplateHandler.GetTemplate)       // テンプレート取得
+				boardTemplates.DELETE("/:id", boardTemplateHandler.DeleteTemplate) // テンプレート削除
 			}
 
 			// タスク関連
 			tasks := protected.Group("/tasks")
 			{
-				tasks.POST("", taskHandler.CreateTask)       // タスク作成
-				tasks.GET("/:id", taskHandler.GetTask)       // タスク取得
-				tasks.PUT("/:id", taskHandler.UpdateTask)    // タスク更新
-				tasks.DELETE("/:id", taskHandler.DeleteTask) // タスク削除
-				tasks.PUT("/:id/move", taskHandler.MoveTask) // タスク移動
+				tasks.POST("", taskHandler.CreateTask)                                         // タスク作成
+				tasks.GET("/search", taskHandler.SearchTasks)                                  // 全ボード横断のタスク検索
+				tasks.GET("/my-work", myWorkHandler.GetMyWork)                                 // 自分の担当タスク（期限別）
+				tasks.POST("/bulk", taskBulkHandler.BulkUpdate)                                // タスク一括操作
+				tasks.GET("/:id", taskHandler.GetTask)                                         // タスク取得
+				tasks.PUT("/:id", taskHandler.UpdateTask)                                      // タスク更新
+				tasks.DELETE("/:id", taskHandler.DeleteTask)                                   // タスク削除
+				tasks.PUT("/:id/move", taskHandler.MoveTask)                                   // タスク移動
+				tasks.POST("/:id/move-to-board", taskTransferHandler.MoveTaskToBoard)          // 別のボードへ移動
+				tasks.GET("/:id/history", taskTransferHandler.GetTaskHistory)                  // タスクの履歴
+				tasks.PUT("/:id/custom-fields/:fieldId", customFieldHandler.SetTaskValue)      // カスタムフィールド値設定
+				tasks.DELETE("/:id/custom-fields/:fieldId", customFieldHandler.ClearTaskValue) // カスタムフィールド値削除
+				tasks.POST("/:id/labels/:labelId", labelHandler.AddLabelToTask)                // ラベル付与
+				tasks.DELETE("/:id/labels/:labelId", labelHandler.RemoveLabelFromTask)         // ラベル解除
+			}
+
+			// ラベル関連
+			labels := protected.Group("/labels")
+			{
+				labels.PUT("/:id", labelHandler.UpdateLabel)    // ラベル更新
+				labels.DELETE("/:id", labelHandler.DeleteLabel) // ラベル削除
+			}
+
+			// レーン関連
+			lanes := protected.Group("/lanes")
+			{
+				lanes.PUT("/:id", laneHandler.UpdateLane)    // レーン更新
+				lanes.DELETE("/:id", laneHandler.DeleteLane) // レーン削除
+			}
+
+			// Webhook関連
+			webhooks := protected.Group("/webhooks")
+			{
+				webhooks.PUT("/:id", webhookHandler.UpdateWebhook)            // Webhook更新
+				webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)         // Webhook削除
+				webhooks.POST("/:id/ping", webhookHandler.PingWebhook)        // 疎通確認
+				webhooks.GET("/:id/deliveries", webhookHandler.GetDeliveries) // 配信ログ
+			}
+
+			// 自動化ルール関連
+			automationRules := protected.Group("/automation-rules")
+			{
+				automationRules.PUT("/:id", automationHandler.UpdateRule)    // 自動化ルール更新
+				automationRules.DELETE("/:id", automationHandler.DeleteRule) // 自動化ルール削除
+			}
+
+			// ゴミ箱関連
+			trash := protected.Group("/trash")
+			{
+				trash.GET("", trashHandler.GetUserTrash)                       // ゴミ箱一覧
+				trash.POST("/boards/:id/restore", trashHandler.RestoreBoard)   // ボード復元
+				trash.POST("/columns/:id/restore", trashHandler.RestoreColumn) // カラム復元
+				trash.POST("/tasks/:id/restore", trashHandler.RestoreTask)     // タスク復元
+			}
+
+			// カスタムフィールド関連
+			customFields := protected.Group("/custom-fields")
+			{
+				customFields.PUT("/:id", customFieldHandler.UpdateField)    // カスタムフィールド更新
+				customFields.DELETE("/:id", customFieldHandler.DeleteField) // カスタムフィールド削除
 			}
 
-			// カラム関連（タスクの順序変更）
+			// カラム関連
 			columns := protected.Group("/columns")
 			{
-				columns.PUT("/:columnId/tasks/reorder", taskHandler.ReorderTasks) // タスク順序変更
+				columns.PUT("/:columnId/tasks/reorder", taskHandler.ReorderTasks)      // タスク順序変更
+				columns.PUT("/:columnId/wip-limit", boardHandler.UpdateColumnWIPLimit) // WIP制限設定
 			}
 
 			// カレンダー関連
@@ -7,15 +7,23 @@ import (
 
 // Config アプリケーション全体の設定を管理する構造体
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	JWT      JWTConfig      `json:"jwt"`
+	Server     ServerConfig     `json:"server"`
+	Database   DatabaseConfig   `json:"database"`
+	JWT        JWTConfig        `json:"jwt"`
+	Trash      TrashConfig      `json:"trash"`
+	Task       TaskConfig       `json:"task"`
+	Webhook    WebhookConfig    `json:"webhook"`
+	Automation AutomationConfig `json:"automation"`
+	OIDC       OIDCConfig       `json:"oidc"`
+	Mail       MailConfig       `json:"mail"`
+	RateLimit  RateLimitConfig  `json:"rate_limit"`
 }
 
 // ServerConfig サーバー関連の設定
 type ServerConfig struct {
-	Port string `json:"port"`
-	Mode string `json:"mode"` // gin.DebugMode, gin.ReleaseMode, gin.TestMode
+	Port           string `json:"port"`
+	Mode           string `json:"mode"`            // gin.DebugMode, gin.ReleaseMode, gin.TestMode
+	TrustedProxies string `json:"trusted_proxies"` // X-Forwarded-Forを信頼するプロキシ（カンマ区切り、空の場合は信頼しない）
 }
 
 // DatabaseConfig データベース接続設定
@@ -39,12 +47,69 @@ type JWTConfig struct {
 	CookieSameSite string `json:"cookie_same_site"`
 }
 
+// TrashConfig ゴミ箱（削除済みデータ）の保持設定
+type TrashConfig struct {
+	RetentionDays        int `json:"retention_days"`         // 削除後に完全削除するまでの日数（0以下で無効）
+	PurgeIntervalMinutes int `json:"purge_interval_minutes"` // 完全削除ジョブの実行間隔（分）
+}
+
+// TaskConfig タスクの並び順（ランクキー）の設定
+type TaskConfig struct {
+	RankRebalanceIntervalMinutes int `json:"rank_rebalance_interval_minutes"` // ランク再配置ジョブの実行間隔（分、0以下で無効）
+}
+
+// OIDCConfig OpenID Connectによるログインの設定（IssuerURLが空の場合は無効）
+type OIDCConfig struct {
+	IssuerURL    string `json:"issuer_url"`    // IDプロバイダーのIssuer（/.well-known/openid-configuration の取得に使用）
+	ClientID     string `json:"client_id"`     // IDプロバイダーに登録したクライアントID
+	ClientSecret string `json:"client_secret"` // クライアントシークレット（パブリッククライアントの場合は空）
+	RedirectURL  string `json:"redirect_url"`  // コールバックのURL（/api/v1/auth/oidc/callback）
+	Scopes       string `json:"scopes"`        // 要求するスコープ（空白区切り）
+}
+
+// Enabled OpenID Connectによるログインが設定されているかどうかを判定します
+func (c OIDCConfig) Enabled() bool {
+	return c.IssuerURL != "" && c.ClientID != ""
+}
+
+// MailConfig メール送信の設定（SMTPHostが空の場合は送信せずにOutboxDirへ保存）
+type MailConfig struct {
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     int    `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	SMTPPassword string `json:"smtp_password"`
+	From         string `json:"from"`         // 送信元のメールアドレス
+	OutboxDir    string `json:"outbox_dir"`   // SMTPを使用しない場合にメールを保存するディレクトリ
+	AppBaseURL   string `json:"app_base_url"` // メール内のリンクに使用するフロントエンドのURL
+}
+
+// RateLimitConfig リクエスト数の制限とログインのロックアウトの設定
+type RateLimitConfig struct {
+	AuthPerMinute      int `json:"auth_per_minute"`      // 認証API（ログイン・登録など）のIPアドレスごとの1分あたりの上限（0以下で無効）
+	APIPerMinute       int `json:"api_per_minute"`       // 認証が必要なAPIのユーザーごとの1分あたりの上限（0以下で無効）
+	APIIPPerMinute     int `json:"api_ip_per_minute"`    // 認証が必要なAPIのIPアドレスごとの1分あたりの上限（0以下で無効）
+	LockoutThreshold   int `json:"lockout_threshold"`    // アカウントをロックするまでの連続したログイン失敗の回数（0以下で無効）
+	LockoutBaseSeconds int `json:"lockout_base_seconds"` // 最初のロックの時間（秒、以降は失敗のたびに倍増）
+	LockoutMaxMinutes  int `json:"lockout_max_minutes"`  // ロックの時間の上限（分）
+}
+
+// AutomationConfig 自動化ルールの設定
+type AutomationConfig struct {
+	DueDateIntervalMinutes int `json:"due_date_interval_minutes"` // 期限切れのルールを実行する間隔（分、0以下で無効）
+}
+
+// WebhookConfig Webhookの配信設定
+type WebhookConfig struct {
+	DeliveryIntervalSeconds int `json:"delivery_interval_seconds"` // 配信キューを処理する間隔（秒、0以下で無効）
+}
+
 // Load 環境変数から設定を読み込みます
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", "8080"),
-			Mode: getEnv("GIN_MODE", "debug"),
+			Port:           getEnv("PORT", "8080"),
+			Mode:           getEnv("GIN_MODE", "debug"),
+			TrustedProxies: getEnv("TRUSTED_PROXIES", ""),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -63,6 +128,43 @@ func Load() *Config {
 			CookieHTTPOnly: getEnvAsBool("JWT_COOKIE_HTTP_ONLY", true),
 			CookieSameSite: getEnv("JWT_COOKIE_SAME_SITE", "Lax"),
 		},
+		Trash: TrashConfig{
+			RetentionDays:        getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+			PurgeIntervalMinutes: getEnvAsInt("TRASH_PURGE_INTERVAL_MINUTES", 60),
+		},
+		Task: TaskConfig{
+			RankRebalanceIntervalMinutes: getEnvAsInt("TASK_RANK_REBALANCE_INTERVAL_MINUTES", 60),
+		},
+		Webhook: WebhookConfig{
+			DeliveryIntervalSeconds: getEnvAsInt("WEBHOOK_DELIVERY_INTERVAL_SECONDS", 10),
+		},
+		Automation: AutomationConfig{
+			DueDateIntervalMinutes: getEnvAsInt("AUTOMATION_DUE_DATE_INTERVAL_MINUTES", 5),
+		},
+		OIDC: OIDCConfig{
+			IssuerURL:    getEnv("OIDC_ISSUER_URL", ""),
+			ClientID:     getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/v1/auth/oidc/callback"),
+			Scopes:       getEnv("OIDC_SCOPES", "openid email profile"),
+		},
+		Mail: MailConfig{
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			From:         getEnv("MAIL_FROM", "no-reply@simple-kanban.local"),
+			OutboxDir:    getEnv("MAIL_OUTBOX_DIR", "mail"),
+			AppBaseURL:   getEnv("APP_BASE_URL", "http://localhost:5173"),
+		},
+		RateLimit: RateLimitConfig{
+			AuthPerMinute:      getEnvAsInt("RATE_LIMIT_AUTH_PER_MINUTE", 10),
+			APIPerMinute:       getEnvAsInt("RATE_LIMIT_API_PER_MINUTE", 300),
+			APIIPPerMinute:     getEnvAsInt("RATE_LIMIT_API_IP_PER_MINUTE", 600),
+			LockoutThreshold:   getEnvAsInt("LOGIN_LOCKOUT_THRESHOLD", 5),
+			LockoutBaseSeconds: getEnvAsInt("LOGIN_LOCKOUT_BASE_SECONDS", 60),
+			LockoutMaxMinutes:  getEnvAsInt("LOGIN_LOCKOUT_MAX_MINUTES", 60),
+		},
 	}
 }
 
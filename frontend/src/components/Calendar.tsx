@@ -222,7 +222,7 @@ const Calendar: React.FC<CalendarProps> = ({ onTaskSelect, refreshKey, onTimerSt
     if (!event.task_id) return;
     try {
       const current = await taskApi.getTask(event.task_id);
-      await taskApi.updateTask(event.task_id, { is_completed: !current.is_completed });
+      await taskApi.updateTask(event.task_id, { is_completed: !current.is_completed }, current.version);
       // 再取得して反映（完了タスクも表示は継続）
       await fetchEvents();
     } catch {
@@ -230,10 +230,9 @@ const Calendar: React.FC<CalendarProps> = ({ onTaskSelect, refreshKey, onTimerSt
     }
   };
 
-  const deleteEvent = async (eventId?: number) => {
-    if (!eventId) return;
+  const deleteEvent = async (event: Types.CalendarEvent) => {
     try {
-      await calendarApi.deleteEvent(eventId);
+      await calendarApi.deleteEvent(event.id, event.version);
       await fetchEvents();
     } catch {
       // no-op
@@ -501,7 +500,7 @@ const Calendar: React.FC<CalendarProps> = ({ onTaskSelect, refreshKey, onTimerSt
                                     <button
                                       onClick={(e) => {
                                         e.stopPropagation();
-                                        deleteEvent(Number(event.id));
+                                        deleteEvent(event);
                                       }}
                                       className="text-red-700 hover:text-red-900"
                                       title="イベント削除"
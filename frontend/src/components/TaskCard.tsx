@@ -44,7 +44,7 @@ const TaskCard: React.FC<TaskCardProps> = ({ task, index, onUpdate, onDelete })
         ...data,
         due_date: data.due_date || undefined,
         estimated_time: data.estimated_time || undefined,
-      });
+      }, task.version);
       const taskWithColumnId = {
         ...updatedTask,
         column_id: updatedTask.column_id ?? task.column_id,
@@ -64,7 +64,7 @@ const TaskCard: React.FC<TaskCardProps> = ({ task, index, onUpdate, onDelete })
       setLoading(true);
       const updatedTask = await taskApi.updateTask(task.id, {
         is_completed: !task.is_completed,
-      });
+      }, task.version);
       // サーバーからcolumn_idが返らない場合に備え補完
       const taskWithColumnId = {
         ...updatedTask,
@@ -86,7 +86,7 @@ const TaskCard: React.FC<TaskCardProps> = ({ task, index, onUpdate, onDelete })
     
     try {
       setLoading(true);
-      await taskApi.deleteTask(task.id);
+      await taskApi.deleteTask(task.id, task.version);
       onDelete(task.id);
     } catch (error) {
       
@@ -60,6 +60,11 @@ export const authApi = {
 };
 
 // ボード関連のAPI
+// 楽観的排他制御用のIf-Matchヘッダー（取得時のバージョンを必ず指定する）
+const ifMatch = (version: number) => ({
+  headers: { 'If-Match': `"${version}"` },
+});
+
 export const boardApi = {
   // ボード一覧取得
   getBoards: async (): Promise<Types.Board[]> => {
@@ -104,14 +109,14 @@ export const boardApi = {
   },
 
   // ボード更新
-  updateBoard: async (id: number, data: Types.UpdateBoardRequest): Promise<Types.Board> => {
-    const response = await api.put(`/boards/${id}`, data);
+  updateBoard: async (id: number, data: Types.UpdateBoardRequest, version: number): Promise<Types.Board> => {
+    const response = await api.put(`/boards/${id}`, data, ifMatch(version));
     return response.data.board;
   },
 
   // ボード削除
-  deleteBoard: async (id: number): Promise<void> => {
-    await api.delete(`/boards/${id}`);
+  deleteBoard: async (id: number, version: number): Promise<void> => {
+    await api.delete(`/boards/${id}`, ifMatch(version));
   },
 };
 
@@ -139,8 +144,8 @@ export const taskApi = {
   },
 
   // タスク更新
-  updateTask: async (id: number, data: Types.UpdateTaskRequest): Promise<Types.Task> => {
-    const response = await api.put(`/tasks/${id}`, data);
+  updateTask: async (id: number, data: Types.UpdateTaskRequest, version: number): Promise<Types.Task> => {
+    const response = await api.put(`/tasks/${id}`, data, ifMatch(version));
     // サーバー実装によってはラップなしで返る場合がある
     const task = (response.data.task ?? response.data) as unknown as Types.Task;
     // サーバーのTaskResponseにはcolumn_idが含まれないため、必要最小限のフィールドを補完
@@ -149,8 +154,8 @@ export const taskApi = {
   },
 
   // タスク削除
-  deleteTask: async (id: number): Promise<void> => {
-    await api.delete(`/tasks/${id}`);
+  deleteTask: async (id: number, version: number): Promise<void> => {
+    await api.delete(`/tasks/${id}`, ifMatch(version));
   },
 
   // タスク移動（ドラッグ&ドロップ）
@@ -180,20 +185,20 @@ export const calendarApi = {
   },
 
   // カレンダーイベント作成
-  createEvent: async (data: Omit<Types.CalendarEvent, 'id' | 'user_id' | 'created_at' | 'updated_at'>): Promise<Types.CalendarEvent> => {
+  createEvent: async (data: Omit<Types.CalendarEvent, 'id' | 'user_id' | 'created_at' | 'updated_at' | 'version'>): Promise<Types.CalendarEvent> => {
     const response = await api.post('/calendar/events', data);
     return response.data;
   },
 
   // カレンダーイベント更新
-  updateEvent: async (id: number, data: Partial<Types.CalendarEvent>): Promise<Types.CalendarEvent> => {
-    const response = await api.put(`/calendar/events/${id}`, data);
+  updateEvent: async (id: number, data: Partial<Types.CalendarEvent>, version: number): Promise<Types.CalendarEvent> => {
+    const response = await api.put(`/calendar/events/${id}`, data, ifMatch(version));
     return response.data;
   },
 
   // カレンダーイベント削除
-  deleteEvent: async (id: number): Promise<void> => {
-    await api.delete(`/calendar/events/${id}`);
+  deleteEvent: async (id: number, version: number): Promise<void> => {
+    await api.delete(`/calendar/events/${id}`, ifMatch(version));
   },
 
   // タスクからカレンダーイベント作成
@@ -86,11 +86,11 @@ const Dashboard: React.FC = () => {
   };
 
   // ボード削除
-  const deleteBoard = async (boardId: number) => {
+  const deleteBoard = async (boardId: number, version: number) => {
     if (!window.confirm('このボードを削除しますか？')) return;
 
     try {
-      await boardApi.deleteBoard(boardId);
+      await boardApi.deleteBoard(boardId, version);
       setBoards(prevBoards => (prevBoards || []).filter(board => board.id !== boardId));
     } catch (error: any) {
       setError(error.response?.data?.error || 'ボードの削除に失敗しました');
@@ -197,7 +197,7 @@ const Dashboard: React.FC = () => {
                   <div className="flex justify-between items-start mb-4">
                     <h3 className="text-lg font-medium text-gray-900">{board.name}</h3>
                     <button
-                      onClick={() => deleteBoard(board.id)}
+                      onClick={() => deleteBoard(board.id, board.version)}
                       className="text-gray-400 hover:text-red-500"
                     >
                       <svg className="w-5 h-5" fill="none" stroke="currentColor" viewBox="0 0 24 24">
@@ -30,6 +30,7 @@ export interface Task {
   order: number;
   assignee_id?: string;
   due_date?: string;
+  version: number; // 楽観的排他制御用のバージョン
   created_at: string;
   updated_at: string;
   // 新機能用フィールド
@@ -81,6 +82,7 @@ export interface Column {
   board_id: number;
   title: string;
   order: number;
+  version?: number;
   created_at: string;
   updated_at: string;
   tasks?: Task[];
@@ -101,6 +103,7 @@ export interface Board {
   id: number;
   name: string;
   owner_id: string;
+  version: number;
   created_at: string;
   updated_at: string;
   columns?: Column[];
@@ -108,6 +111,7 @@ export interface Board {
 
 export interface CreateBoardRequest {
   name: string;
+  template_id?: string; // ボードテンプレート（省略時は To Do / In Progress / Done）
 }
 
 export interface UpdateBoardRequest {
@@ -163,6 +167,7 @@ export interface CalendarEvent {
   end: string;
   color?: string;
   is_task_based: boolean;
+  version: number;
   task?: Task; // サーバーから付与される関連タスク（任意）
 }
 
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// AccountExportFormat アカウントのデータのエクスポートであることを表す識別子
+const AccountExportFormat = "simple-kanban/account"
+
+// AccountExportVersion アカウントのデータのエクスポートの形式のバージョン
+const AccountExportVersion = 1
+
+// AccountExport ユーザーが所有するすべてのデータのエクスポート
+// アーカイブ（ZIP）にはaccount.jsonと、ボードごとのエクスポートファイル（boards/*.json）を含みます
+type AccountExport struct {
+	Format           string                    `json:"format"`
+	Version          int                       `json:"version"`
+	ExportedAt       time.Time                 `json:"exported_at"`
+	Profile          ExportedProfile           `json:"profile"`
+	CalendarSettings *ExportedCalendarSettings `json:"calendar_settings,omitempty"`
+	TimerSessions    []ExportedTimerSession    `json:"timer_sessions"`
+	CalendarEvents   []ExportedCalendarEvent   `json:"calendar_events"`
+	Boards           []BoardExport             `json:"-"` // ボードのインポートで読み込めるよう、ボードごとのファイルに出力
+}
+
+// ExportedProfile エクスポートしたプロフィール
+type ExportedProfile struct {
+	Email            string     `json:"email"`
+	DisplayName      string     `json:"display_name,omitempty"`
+	AvatarURL        string     `json:"avatar_url,omitempty"`
+	TimeZone         string     `json:"time_zone"`
+	Locale           string     `json:"locale"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at,omitempty"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// ExportedCalendarSettings エクスポートしたカレンダーの表示設定
+type ExportedCalendarSettings struct {
+	WeekdayStartTime string `json:"weekday_start_time"`
+	WeekdayEndTime   string `json:"weekday_end_time"`
+	WeekendStartTime string `json:"weekend_start_time"`
+	WeekendEndTime   string `json:"weekend_end_time"`
+	TimeSlotDuration int    `json:"time_slot_duration"`
+}
+
+// ExportedTimerSession エクスポートしたタイマーセッション
+type ExportedTimerSession struct {
+	TaskID    uint       `json:"task_id"`
+	TaskTitle string     `json:"task_title,omitempty"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Duration  int        `json:"duration"` // 継続時間（秒）
+}
+
+// ExportedCalendarEvent エクスポートしたカレンダーの予定
+type ExportedCalendarEvent struct {
+	Title       string    `json:"title"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Color       string    `json:"color,omitempty"`
+	TaskID      *uint     `json:"task_id,omitempty"`
+	IsTaskBased bool      `json:"is_task_based"`
+}
+
+// NewExportedProfile ユーザーからエクスポートするプロフィールを作成します
+func NewExportedProfile(user *User) ExportedProfile {
+	return ExportedProfile{
+		Email:            user.Email,
+		DisplayName:      user.DisplayName,
+		AvatarURL:        user.AvatarURL,
+		TimeZone:         user.TimeZone,
+		Locale:           user.Locale,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		TwoFactorEnabled: user.TwoFactorEnabled(),
+		CreatedAt:        user.CreatedAt,
+	}
+}
+
+// WriteArchive エクスポートをZIPアーカイブとして書き込みます
+func (e *AccountExport) WriteArchive(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	if err := writeArchiveJSON(zw, "account.json", e, e.ExportedAt); err != nil {
+		return err
+	}
+	for i := range e.Boards {
+		// ボード名は重複やファイル名に使えない文字を含むことがあるため、連番で名前を付ける
+		name := fmt.Sprintf("boards/board-%03d.json", i+1)
+		if err := writeArchiveJSON(zw, name, &e.Boards[i], e.ExportedAt); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeArchiveJSON アーカイブにJSONファイルを追加します
+func writeArchiveJSON(zw *zip.Writer, name string, v interface{}, modified time.Time) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountExport_WriteArchive(t *testing.T) {
+	board := BoardExport{
+		Format:  BoardExportFormat,
+		Version: BoardExportVersion,
+		Board: ExportedBoard{
+			Name:    "個人/タスク",
+			Columns: []ExportedColumn{{ID: 1, Title: "To Do"}},
+			Tasks:   []ExportedTask{{ID: 1, ColumnID: 1, Title: "レポート"}},
+		},
+	}
+	export := &AccountExport{
+		Format:         AccountExportFormat,
+		Version:        AccountExportVersion,
+		ExportedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Profile:        ExportedProfile{Email: "alice@example.com", TimeZone: "Asia/Tokyo", Locale: "ja"},
+		TimerSessions:  []ExportedTimerSession{{TaskID: 1, Duration: 60}},
+		CalendarEvents: []ExportedCalendarEvent{},
+		Boards:         []BoardExport{board, board},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteArchive(&buf))
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(archive.File))
+	files := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+		files[f.Name] = f
+	}
+	// ボード名ではなく連番でファイル名を付ける
+	assert.Equal(t, []string{"account.json", "boards/board-001.json", "boards/board-002.json"}, names)
+
+	var account map[string]interface{}
+	readArchiveJSON(t, files["account.json"], &account)
+	assert.Equal(t, AccountExportFormat, account["format"])
+	assert.NotContains(t, account, "boards")
+	assert.Equal(t, "alice@example.com", account["profile"].(map[string]interface{})["email"])
+
+	// ボードのファイルはボードのインポートで読み込める
+	var imported BoardExport
+	readArchiveJSON(t, files["boards/board-002.json"], &imported)
+	assert.NoError(t, imported.Validate())
+	assert.Equal(t, "個人/タスク", imported.Board.Name)
+}
+
+func TestUser_Anonymize(t *testing.T) {
+	now := time.Now()
+	user := &User{
+		ID:              uuid.New(),
+		Email:           "alice@example.com",
+		PasswordHash:    "hash",
+		DisplayName:     "Alice",
+		AvatarURL:       "https://example.com/alice.png",
+		TimeZone:        "Asia/Tokyo",
+		PendingEmail:    "alice@new.example.com",
+		EmailVerifiedAt: &now,
+		TOTPSecret:      "secret",
+		TOTPEnabledAt:   &now,
+		RecoveryCodes:   StringList{"code"},
+		CreatedAt:       now,
+	}
+	id := user.ID
+
+	user.Anonymize()
+	assert.Equal(t, id, user.ID)
+	assert.Equal(t, now, user.CreatedAt)
+	assert.Equal(t, "deleted-"+id.String()+"@deleted.invalid", user.Email)
+	assert.Equal(t, DeletedUserDisplayName, user.Name())
+	assert.Empty(t, user.PasswordHash)
+	assert.Empty(t, user.AvatarURL)
+	assert.Empty(t, user.PendingEmail)
+	assert.Nil(t, user.EmailVerifiedAt)
+	assert.False(t, user.TwoFactorEnabled())
+	assert.Empty(t, user.TOTPSecret)
+	assert.Empty(t, user.RecoveryCodes)
+}
+
+func readArchiveJSON(t *testing.T, f *zip.File, v interface{}) {
+	t.Helper()
+	require.NotNil(t, f)
+	r, err := f.Open()
+	require.NoError(t, err)
+	defer r.Close()
+	require.NoError(t, json.NewDecoder(r).Decode(v))
+}
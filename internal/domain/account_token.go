@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountTokenPurpose メールで送信するトークン（OpenID Connectのログインでは、フロントエンドに渡す1回限りのコード）の用途
+type AccountTokenPurpose string
+
+const (
+	AccountTokenPasswordReset     AccountTokenPurpose = "password_reset"     // パスワードの再設定
+	AccountTokenEmailVerification AccountTokenPurpose = "email_verification" // メールアドレスの確認
+	AccountTokenEmailChange       AccountTokenPurpose = "email_change"       // 変更後のメールアドレスの確認
+	AccountTokenOIDCLogin         AccountTokenPurpose = "oidc_login"         // IDプロバイダーでのログイン後にJWTと交換するコード
+)
+
+// トークンの有効期限
+const (
+	PasswordResetTokenTTL     = time.Hour
+	EmailVerificationTokenTTL = 24 * time.Hour
+)
+
+// AccountToken パスワードの再設定・メールアドレスの確認のためにメールで送信するトークンを表すエンティティ
+// トークンそのものは保存せず、SHA-256のハッシュのみを保存します（1回のみ使用できる）
+type AccountToken struct {
+	ID        uint                `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uuid.UUID           `json:"user_id" gorm:"type:uuid;not null;index"`
+	Purpose   AccountTokenPurpose `json:"purpose" gorm:"type:varchar(32);not null"`
+	TokenHash string              `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	Email     string              `json:"email" gorm:"not null"` // 送信先のメールアドレス（確認の対象）
+	ExpiresAt time.Time           `json:"expires_at" gorm:"not null;index"`
+	UsedAt    *time.Time          `json:"used_at,omitempty"`
+	CreatedAt time.Time           `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName テーブル名を明示的に指定
+func (AccountToken) TableName() string {
+	return "account_tokens"
+}
+
+// HashAccountToken トークンを保存・照合するためのハッシュ（SHA-256の16進数）を返します
+func HashAccountToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,315 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AutomationTrigger 自動化ルールを実行するきっかけ
+type AutomationTrigger string
+
+const (
+	AutomationTriggerTaskCreated   AutomationTrigger = "task_created"    // タスク作成
+	AutomationTriggerTaskMoved     AutomationTrigger = "task_moved"      // 別のカラムへの移動（TriggerColumnIDで移動先を限定できる）
+	AutomationTriggerDueDatePassed AutomationTrigger = "due_date_passed" // 未完了のまま期限を過ぎた
+	AutomationTriggerTimerStopped  AutomationTrigger = "timer_stopped"   // タイマーの停止
+)
+
+// IsValid 有効なトリガーかどうかを判定します
+func (t AutomationTrigger) IsValid() bool {
+	switch t {
+	case AutomationTriggerTaskCreated, AutomationTriggerTaskMoved, AutomationTriggerDueDatePassed, AutomationTriggerTimerStopped:
+		return true
+	}
+	return false
+}
+
+// AutomationConditionType 自動化ルールの条件の種類
+type AutomationConditionType string
+
+const (
+	AutomationConditionHasLabel AutomationConditionType = "has_label" // ラベルが付与されている
+	AutomationConditionAssignee AutomationConditionType = "assignee"  // 担当者が一致する（AssigneeIDがnilの場合は担当者なし）
+	AutomationConditionInColumn AutomationConditionType = "in_column" // カラムにある
+)
+
+// AutomationCondition 自動化ルールの条件（ルールのすべての条件を満たすタスクに実行する）
+type AutomationCondition struct {
+	Type       AutomationConditionType `json:"type"`
+	LabelID    uint                    `json:"label_id,omitempty"`
+	AssigneeID *uuid.UUID              `json:"assignee_id,omitempty"`
+	ColumnID   uint                    `json:"column_id,omitempty"`
+}
+
+// Matches タスクが条件を満たすかどうかを判定します
+func (c *AutomationCondition) Matches(task *Task) bool {
+	switch c.Type {
+	case AutomationConditionHasLabel:
+		for _, label := range task.Labels {
+			if label.ID == c.LabelID {
+				return true
+			}
+		}
+		return false
+	case AutomationConditionAssignee:
+		if c.AssigneeID == nil || task.AssigneeID == nil {
+			return c.AssigneeID == nil && task.AssigneeID == nil
+		}
+		return *c.AssigneeID == *task.AssigneeID
+	case AutomationConditionInColumn:
+		return task.ColumnID == c.ColumnID
+	}
+	return false
+}
+
+// AutomationActionType 自動化ルールのアクションの種類
+type AutomationActionType string
+
+const (
+	AutomationActionSetField            AutomationActionType = "set_field"             // 項目の値を設定
+	AutomationActionMove                AutomationActionType = "move"                  // カラムの末尾に移動
+	AutomationActionAssign              AutomationActionType = "assign"                // 担当者を設定（AssigneeIDがnilの場合は外す）
+	AutomationActionAddLabel            AutomationActionType = "add_label"             // ラベルを付与
+	AutomationActionCreateCalendarEvent AutomationActionType = "create_calendar_event" // カレンダーにイベントを作成
+)
+
+// set_fieldで設定できる項目
+const (
+	AutomationFieldIsCompleted = "is_completed" // 完了（true / false）
+	AutomationFieldPriority    = "priority"     // 優先度
+	AutomationFieldDueDate     = "due_date"     // 期限（実行日からの日数、nullで解除）
+)
+
+// 既定のカレンダーイベントの長さ（分）
+const DefaultAutomationEventMinutes = 60
+
+// AutomationAction 自動化ルールのアクション（定義した順に実行する）
+type AutomationAction struct {
+	Type            AutomationActionType `json:"type"`
+	Field           string               `json:"field,omitempty"`            // set_field: 項目
+	Value           json.RawMessage      `json:"value,omitempty"`            // set_field: 設定する値
+	ColumnID        uint                 `json:"column_id,omitempty"`        // move: 移動先のカラム
+	AssigneeID      *uuid.UUID           `json:"assignee_id,omitempty"`      // assign: 担当者
+	LabelID         uint                 `json:"label_id,omitempty"`         // add_label: 付与するラベル
+	DurationMinutes int                  `json:"duration_minutes,omitempty"` // create_calendar_event: イベントの長さ（分）
+}
+
+// ApplyField set_fieldアクションの値をタスクに設定します
+func (a *AutomationAction) ApplyField(task *Task, now time.Time) error {
+	switch a.Field {
+	case AutomationFieldIsCompleted:
+		var completed bool
+		if err := json.Unmarshal(a.Value, &completed); err != nil {
+			return errors.New("is_completed には true または false を指定してください")
+		}
+		task.IsCompleted = completed
+	case AutomationFieldPriority:
+		var priority TaskPriority
+		if err := json.Unmarshal(a.Value, &priority); err != nil || !priority.IsValid() {
+			return fmt.Errorf("不正な優先度です: %s", string(a.Value))
+		}
+		task.Priority = priority
+	case AutomationFieldDueDate:
+		var days *int
+		if err := json.Unmarshal(a.Value, &days); err != nil {
+			return errors.New("due_date には実行日からの日数か null を指定してください")
+		}
+		if days == nil {
+			task.DueDate = nil
+		} else {
+			dueDate := now.AddDate(0, 0, *days)
+			task.DueDate = &dueDate
+		}
+	default:
+		return fmt.Errorf("設定できない項目です: %s", a.Field)
+	}
+	return nil
+}
+
+// AutomationConditions 条件の配列をJSONBカラムとして保存するための型
+type AutomationConditions []AutomationCondition
+
+// Value データベースへ保存する値に変換します
+func (c AutomationConditions) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]AutomationCondition(c))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan データベースの値から復元します
+func (c *AutomationConditions) Scan(value interface{}) error {
+	if value == nil {
+		*c = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("AutomationConditionsに変換できない型です")
+	}
+
+	return json.Unmarshal(data, (*[]AutomationCondition)(c))
+}
+
+// AutomationActions アクションの配列をJSONBカラムとして保存するための型
+type AutomationActions []AutomationAction
+
+// Value データベースへ保存する値に変換します
+func (a AutomationActions) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]AutomationAction(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan データベースの値から復元します
+func (a *AutomationActions) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("AutomationActionsに変換できない型です")
+	}
+
+	return json.Unmarshal(data, (*[]AutomationAction)(a))
+}
+
+// AutomationRule ボードの自動化ルール（「トリガー」が発生し「条件」を満たすタスクに「アクション」を実行する）を表すエンティティ
+type AutomationRule struct {
+	ID              uint                 `json:"id" gorm:"primaryKey;autoIncrement"`
+	BoardID         uint                 `json:"board_id" gorm:"not null;index"`
+	Name            string               `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	Enabled         bool                 `json:"enabled" gorm:"not null"`
+	Trigger         AutomationTrigger    `json:"trigger" gorm:"type:varchar(32);not null;index"`
+	TriggerColumnID *uint                `json:"trigger_column_id,omitempty"` // task_moved: 移動先のカラム（nilの場合はすべてのカラム）
+	Conditions      AutomationConditions `json:"conditions" gorm:"type:jsonb;not null"`
+	Actions         AutomationActions    `json:"actions" gorm:"type:jsonb;not null"`
+	CreatedAt       time.Time            `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time            `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName テーブル名を明示的に指定
+func (AutomationRule) TableName() string {
+	return "automation_rules"
+}
+
+// Validate トリガー・条件・アクションの形式をチェックします
+// カラム・ラベルがルールのボードのものかどうかは呼び出し側でチェックします
+func (r *AutomationRule) Validate() error {
+	if !r.Trigger.IsValid() {
+		return fmt.Errorf("不正なトリガーです: %s", r.Trigger)
+	}
+	if r.TriggerColumnID != nil && r.Trigger != AutomationTriggerTaskMoved {
+		return errors.New("trigger_column_id は task_moved のトリガーでのみ指定できます")
+	}
+
+	for _, condition := range r.Conditions {
+		switch condition.Type {
+		case AutomationConditionHasLabel:
+			if condition.LabelID == 0 {
+				return errors.New("has_label の条件には label_id を指定してください")
+			}
+		case AutomationConditionInColumn:
+			if condition.ColumnID == 0 {
+				return errors.New("in_column の条件には column_id を指定してください")
+			}
+		case AutomationConditionAssignee:
+		default:
+			return fmt.Errorf("不正な条件です: %s", condition.Type)
+		}
+	}
+
+	if len(r.Actions) == 0 {
+		return errors.New("アクションを1つ以上指定してください")
+	}
+	for _, action := range r.Actions {
+		switch action.Type {
+		case AutomationActionSetField:
+			// 値の形式は実際に設定してチェックする
+			if err := action.ApplyField(&Task{}, time.Now()); err != nil {
+				return err
+			}
+		case AutomationActionMove:
+			if action.ColumnID == 0 {
+				return errors.New("move のアクションには column_id を指定してください")
+			}
+		case AutomationActionAddLabel:
+			if action.LabelID == 0 {
+				return errors.New("add_label のアクションには label_id を指定してください")
+			}
+		case AutomationActionCreateCalendarEvent:
+			if action.DurationMinutes < 0 {
+				return errors.New("duration_minutes は0以上で指定してください")
+			}
+		case AutomationActionAssign:
+		default:
+			return fmt.Errorf("不正なアクションです: %s", action.Type)
+		}
+	}
+	return nil
+}
+
+// Matches タスクがルールのすべての条件を満たすかどうかを判定します
+func (r *AutomationRule) Matches(task *Task) bool {
+	for i := range r.Conditions {
+		if !r.Conditions[i].Matches(task) {
+			return false
+		}
+	}
+	return true
+}
+
+// AutomationExecutionStatus 自動化ルールの実行結果
+type AutomationExecutionStatus string
+
+const (
+	AutomationExecutionSucceeded AutomationExecutionStatus = "succeeded" // すべてのアクションを実行した
+	AutomationExecutionFailed    AutomationExecutionStatus = "failed"    // アクションの途中で失敗した
+	AutomationExecutionSkipped   AutomationExecutionStatus = "skipped"   // ループ防止のため実行しなかった
+)
+
+// AutomationExecution 自動化ルールの実行ログを表すエンティティ
+type AutomationExecution struct {
+	ID        uint                      `json:"id" gorm:"primaryKey;autoIncrement"`
+	RuleID    uint                      `json:"rule_id" gorm:"not null;index:idx_automation_executions_rule_task,priority:1"`
+	BoardID   uint                      `json:"board_id" gorm:"not null;index"`
+	TaskID    uint                      `json:"task_id" gorm:"not null;index:idx_automation_executions_rule_task,priority:2"`
+	Trigger   AutomationTrigger         `json:"trigger" gorm:"type:varchar(32);not null"`
+	Status    AutomationExecutionStatus `json:"status" gorm:"type:varchar(16);not null"`
+	Actions   int                       `json:"actions"` // 実行したアクションの数
+	Depth     int                       `json:"depth"`   // ほかのルールのアクションから連鎖した段数（0は利用者の操作）
+	Error     string                    `json:"error,omitempty" gorm:"type:text"`
+	CreatedAt time.Time                 `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+// TableName テーブル名を明示的に指定
+func (AutomationExecution) TableName() string {
+	return "automation_executions"
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationRule_Matches(t *testing.T) {
+	assignee := uuid.New()
+	rule := AutomationRule{
+		Trigger: AutomationTriggerTaskMoved,
+		Conditions: AutomationConditions{
+			{Type: AutomationConditionHasLabel, LabelID: 3},
+			{Type: AutomationConditionAssignee, AssigneeID: &assignee},
+			{Type: AutomationConditionInColumn, ColumnID: 2},
+		},
+	}
+
+	task := &Task{ColumnID: 2, AssigneeID: &assignee, Labels: []Label{{ID: 1}, {ID: 3}}}
+	assert.True(t, rule.Matches(task))
+
+	other := uuid.New()
+	assert.False(t, rule.Matches(&Task{ColumnID: 2, AssigneeID: &other, Labels: []Label{{ID: 3}}}), "担当者が異なる")
+	assert.False(t, rule.Matches(&Task{ColumnID: 2, AssigneeID: &assignee}), "ラベルがない")
+	assert.False(t, rule.Matches(&Task{ColumnID: 1, AssigneeID: &assignee, Labels: []Label{{ID: 3}}}), "カラムが異なる")
+
+	// 担当者なしの条件
+	unassigned := AutomationCondition{Type: AutomationConditionAssignee}
+	assert.True(t, unassigned.Matches(&Task{}))
+	assert.False(t, unassigned.Matches(task))
+
+	// 条件がない場合はすべてのタスクが対象
+	assert.True(t, (&AutomationRule{}).Matches(&Task{}))
+}
+
+func TestAutomationRule_Validate(t *testing.T) {
+	columnID := uint(2)
+	valid := AutomationRule{
+		Trigger:         AutomationTriggerTaskMoved,
+		TriggerColumnID: &columnID,
+		Actions: AutomationActions{
+			{Type: AutomationActionSetField, Field: AutomationFieldPriority, Value: json.RawMessage(`"high"`)},
+			{Type: AutomationActionCreateCalendarEvent},
+		},
+	}
+	require.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(r *AutomationRule)
+	}{
+		{"不正なトリガー", func(r *AutomationRule) { r.Trigger = "task_archived" }},
+		{"移動以外のトリガーでのカラム指定", func(r *AutomationRule) { r.Trigger = AutomationTriggerTaskCreated }},
+		{"アクションなし", func(r *AutomationRule) { r.Actions = nil }},
+		{"不正な優先度", func(r *AutomationRule) { r.Actions[0].Value = json.RawMessage(`"critical"`) }},
+		{"設定できない項目", func(r *AutomationRule) { r.Actions[0].Field = "title" }},
+		{"移動先のカラムなし", func(r *AutomationRule) { r.Actions[1] = AutomationAction{Type: AutomationActionMove} }},
+		{"ラベルなしの条件", func(r *AutomationRule) {
+			r.Conditions = AutomationConditions{{Type: AutomationConditionHasLabel}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := valid
+			rule.Actions = append(AutomationActions(nil), valid.Actions...)
+			tt.modify(&rule)
+			assert.Error(t, rule.Validate())
+		})
+	}
+}
+
+func TestAutomationAction_ApplyField(t *testing.T) {
+	now := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	task := &Task{}
+
+	require.NoError(t, (&AutomationAction{Field: AutomationFieldIsCompleted, Value: json.RawMessage(`true`)}).ApplyField(task, now))
+	assert.True(t, task.IsCompleted)
+
+	require.NoError(t, (&AutomationAction{Field: AutomationFieldDueDate, Value: json.RawMessage(`3`)}).ApplyField(task, now))
+	require.NotNil(t, task.DueDate)
+	assert.Equal(t, now.AddDate(0, 0, 3), *task.DueDate)
+
+	require.NoError(t, (&AutomationAction{Field: AutomationFieldDueDate, Value: json.RawMessage(`null`)}).ApplyField(task, now))
+	assert.Nil(t, task.DueDate)
+}
@@ -8,16 +8,18 @@ import (
 )
 
 // Board Kanbanボードを表すエンティティ
-// ユーザーが所有し、複数のカラムを持ちます
+// ワークスペースが所有し、ワークスペースのメンバーが利用できます。複数のカラムを持ちます
 type Board struct {
-	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name      string         `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
-	OwnerID   uuid.UUID      `json:"owner_id" gorm:"type:uuid;not null;index"`
-	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
+	ID          uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string         `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	WorkspaceID uint           `json:"workspace_id" gorm:"not null;default:0;index"` // 所有するワークスペース（0はワークスペース導入前のボードで、マイグレーションで設定する）
+	OwnerID     uuid.UUID      `json:"owner_id" gorm:"type:uuid;not null;index"`     // 作成したユーザー
+	Version     int            `json:"version" gorm:"not null;default:1"`            // 楽観的排他制御用のバージョン
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
 
-	// リレーション：このボードの所有者
+	// リレーション：このボードを作成したユーザー
 	Owner User `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
 
 	// リレーション：このボードが持つカラム一覧（order順でソート）
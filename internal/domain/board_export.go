@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BoardExportFormat ボードのエクスポートファイルであることを表す識別子
+const BoardExportFormat = "simple-kanban/board"
+
+// BoardExportVersion エクスポートファイルの形式のバージョン
+// 形式を変更した場合は1つ進め、インポート時に古いバージョンを変換します
+const BoardExportVersion = 1
+
+// BoardExport ボードのエクスポートファイル（JSON）の内容
+// 各要素のIDはエクスポート元のIDで、ファイル内の参照にのみ使用します
+type BoardExport struct {
+	Format     string        `json:"format"`
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Board      ExportedBoard `json:"board"`
+}
+
+// ExportedBoard エクスポートしたボード
+type ExportedBoard struct {
+	Name         string                `json:"name"`
+	Columns      []ExportedColumn      `json:"columns"`
+	Lanes        []ExportedLane        `json:"lanes"`
+	Labels       []ExportedLabel       `json:"labels"`
+	CustomFields []ExportedCustomField `json:"custom_fields"`
+	Tasks        []ExportedTask        `json:"tasks"`
+}
+
+// ExportedColumn エクスポートしたカラム
+type ExportedColumn struct {
+	ID           uint         `json:"id"`
+	Title        string       `json:"title"`
+	Order        int          `json:"order"`
+	WIPLimit     *int         `json:"wip_limit,omitempty"`
+	WIPLimitMode WIPLimitMode `json:"wip_limit_mode,omitempty"`
+}
+
+// ExportedLane エクスポートしたスイムレーン
+type ExportedLane struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Order int    `json:"order"`
+}
+
+// ExportedLabel エクスポートしたラベル
+type ExportedLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// ExportedCustomField エクスポートしたカスタムフィールドの定義
+type ExportedCustomField struct {
+	ID      uint            `json:"id"`
+	Name    string          `json:"name"`
+	Type    CustomFieldType `json:"type"`
+	Options StringList      `json:"options,omitempty"`
+	Order   int             `json:"order"`
+}
+
+// ExportedTask エクスポートしたタスク
+// セル（カラムとレーンの組）内の順序はファイル内の並び順で表し、担当者はインスタンス間で共通のメールアドレスで表します
+type ExportedTask struct {
+	ID                uint                       `json:"id"`
+	ColumnID          uint                       `json:"column_id"`
+	LaneID            *uint                      `json:"lane_id,omitempty"`
+	Title             string                     `json:"title"`
+	Description       string                     `json:"description,omitempty"`
+	AssigneeEmail     *string                    `json:"assignee_email,omitempty"`
+	DueDate           *time.Time                 `json:"due_date,omitempty"`
+	EstimatedTime     *int                       `json:"estimated_time,omitempty"`
+	ActualTime        *int                       `json:"actual_time,omitempty"`
+	IsCompleted       bool                       `json:"is_completed"`
+	Priority          TaskPriority               `json:"priority,omitempty"`
+	LabelIDs          []uint                     `json:"label_ids,omitempty"`
+	CustomFieldValues []ExportedCustomFieldValue `json:"custom_field_values,omitempty"`
+}
+
+// ExportedCustomFieldValue エクスポートしたタスクのカスタムフィールド値
+type ExportedCustomFieldValue struct {
+	FieldID      uint       `json:"field_id"`
+	TextValue    *string    `json:"text_value,omitempty"`
+	NumberValue  *float64   `json:"number_value,omitempty"`
+	DateValue    *time.Time `json:"date_value,omitempty"`
+	OptionValues StringList `json:"option_values,omitempty"`
+}
+
+// Raw 値をフィールド型に応じてAPIで指定する形式（ParseCustomFieldValueのraw）に戻します
+// フィールド型の値がない場合はnilを返します
+func (v ExportedCustomFieldValue) Raw(fieldType CustomFieldType) interface{} {
+	switch fieldType {
+	case CustomFieldTypeText:
+		if v.TextValue != nil {
+			return *v.TextValue
+		}
+	case CustomFieldTypeNumber:
+		if v.NumberValue != nil {
+			return *v.NumberValue
+		}
+	case CustomFieldTypeDate:
+		if v.DateValue != nil {
+			return v.DateValue.Format("2006-01-02")
+		}
+	case CustomFieldTypeSingleSelect:
+		if len(v.OptionValues) == 1 {
+			return v.OptionValues[0]
+		}
+	case CustomFieldTypeMultiSelect:
+		if v.OptionValues != nil {
+			options := make([]interface{}, 0, len(v.OptionValues))
+			for _, option := range v.OptionValues {
+				options = append(options, option)
+			}
+			return options
+		}
+	}
+	return nil
+}
+
+// Validate エクスポートファイルの形式と、ファイル内の参照が正しいかをチェックします
+func (e *BoardExport) Validate() error {
+	if e.Format != BoardExportFormat {
+		return fmt.Errorf("エクスポートファイルの形式が不正です（format は %s を指定してください）", BoardExportFormat)
+	}
+	if e.Version < 1 || e.Version > BoardExportVersion {
+		return fmt.Errorf("対応していないエクスポートファイルのバージョンです: %d", e.Version)
+	}
+
+	board := &e.Board
+	if board.Name == "" {
+		return errors.New("ボード名を指定してください")
+	}
+	if len(board.Columns) == 0 {
+		return errors.New("ボードには1つ以上のカラムが必要です")
+	}
+
+	columnIDs := make(map[uint]bool, len(board.Columns))
+	for _, column := range board.Columns {
+		if column.Title == "" {
+			return errors.New("カラム名を指定してください")
+		}
+		if column.WIPLimitMode != "" && !column.WIPLimitMode.IsValid() {
+			return fmt.Errorf("カラム「%s」のWIP制限モードが不正です", column.Title)
+		}
+		if columnIDs[column.ID] {
+			return fmt.Errorf("カラムのIDが重複しています: %d", column.ID)
+		}
+		columnIDs[column.ID] = true
+	}
+
+	laneIDs := make(map[uint]bool, len(board.Lanes))
+	for _, lane := range board.Lanes {
+		if lane.Name == "" {
+			return errors.New("レーン名を指定してください")
+		}
+		if laneIDs[lane.ID] {
+			return fmt.Errorf("レーンのIDが重複しています: %d", lane.ID)
+		}
+		laneIDs[lane.ID] = true
+	}
+
+	labelIDs := make(map[uint]bool, len(board.Labels))
+	for _, label := range board.Labels {
+		if label.Name == "" {
+			return errors.New("ラベル名を指定してください")
+		}
+		if label.Color != "" {
+			if err := ValidateLabelColor(label.Color); err != nil {
+				return fmt.Errorf("ラベル「%s」: %w", label.Name, err)
+			}
+		}
+		if labelIDs[label.ID] {
+			return fmt.Errorf("ラベルのIDが重複しています: %d", label.ID)
+		}
+		labelIDs[label.ID] = true
+	}
+
+	// 値のチェックには、画面から作成した場合と同じく選択肢を整えた定義を使う
+	fields := make(map[uint]*CustomFieldDefinition, len(board.CustomFields))
+	for _, field := range board.CustomFields {
+		if field.Name == "" {
+			return errors.New("カスタムフィールド名を指定してください")
+		}
+		if !field.Type.IsValid() {
+			return fmt.Errorf("カスタムフィールド「%s」の型が不正です", field.Name)
+		}
+		if _, ok := fields[field.ID]; ok {
+			return fmt.Errorf("カスタムフィールドのIDが重複しています: %d", field.ID)
+		}
+		options, err := NormalizeCustomFieldOptions(field.Type, field.Options)
+		if err != nil {
+			return fmt.Errorf("カスタムフィールド「%s」: %w", field.Name, err)
+		}
+		fields[field.ID] = &CustomFieldDefinition{Name: field.Name, Type: field.Type, Options: options}
+	}
+
+	for _, task := range board.Tasks {
+		if task.Title == "" {
+			return errors.New("タスクのタイトルを指定してください")
+		}
+		if !columnIDs[task.ColumnID] {
+			return fmt.Errorf("タスク「%s」のカラムが見つかりません: %d", task.Title, task.ColumnID)
+		}
+		if task.LaneID != nil && !laneIDs[*task.LaneID] {
+			return fmt.Errorf("タスク「%s」のレーンが見つかりません: %d", task.Title, *task.LaneID)
+		}
+		if task.Priority != "" && !task.Priority.IsValid() {
+			return fmt.Errorf("タスク「%s」の優先度が不正です", task.Title)
+		}
+		for _, labelID := range task.LabelIDs {
+			if !labelIDs[labelID] {
+				return fmt.Errorf("タスク「%s」のラベルが見つかりません: %d", task.Title, labelID)
+			}
+		}
+		for _, value := range task.CustomFieldValues {
+			field, ok := fields[value.FieldID]
+			if !ok {
+				return fmt.Errorf("タスク「%s」のカスタムフィールドが見つかりません: %d", task.Title, value.FieldID)
+			}
+			if _, err := ParseCustomFieldValue(field, value.Raw(field.Type)); err != nil {
+				return fmt.Errorf("タスク「%s」: %w", task.Title, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BoardTemplate ボードの構成を保存したテンプレートを表すエンティティ
+// ユーザーが保存したテンプレートのほかに、組み込みテンプレート（BuiltinKeyあり・データベースには保存しない）があります
+type BoardTemplate struct {
+	ID          uint                    `json:"id" gorm:"primaryKey;autoIncrement"`
+	OwnerID     uuid.UUID               `json:"owner_id" gorm:"type:uuid;not null;index"`
+	Name        string                  `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	Description string                  `json:"description" gorm:"type:text"`
+	Definition  BoardTemplateDefinition `json:"definition" gorm:"type:jsonb;not null"`
+	BuiltinKey  string                  `json:"-" gorm:"-"` // 組み込みテンプレートのキー（ユーザーのテンプレートは空）
+	CreatedAt   time.Time               `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time               `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt          `json:"-" gorm:"index"` // ソフトデリート対応
+}
+
+// TableName テーブル名を明示的に指定
+func (BoardTemplate) TableName() string {
+	return "board_templates"
+}
+
+// IsBuiltin 組み込みテンプレートかどうかを判定します
+func (t *BoardTemplate) IsBuiltin() bool {
+	return t.BuiltinKey != ""
+}
+
+// TemplateID APIで使用するテンプレートIDを返します
+// 組み込みテンプレートはキー、ユーザーのテンプレートは数値のIDです
+func (t *BoardTemplate) TemplateID() string {
+	if t.IsBuiltin() {
+		return t.BuiltinKey
+	}
+	return strconv.FormatUint(uint64(t.ID), 10)
+}
+
+// BoardTemplateDefinition テンプレートに保存するボードの構成
+type BoardTemplateDefinition struct {
+	Columns      []TemplateColumn      `json:"columns"`
+	Labels       []TemplateLabel       `json:"labels"`
+	CustomFields []TemplateCustomField `json:"custom_fields"`
+	Tasks        []TemplateTask        `json:"tasks"` // 初期タスク（任意）
+}
+
+// TemplateColumn テンプレートのカラム
+type TemplateColumn struct {
+	Title        string       `json:"title"`
+	WIPLimit     *int         `json:"wip_limit,omitempty"`
+	WIPLimitMode WIPLimitMode `json:"wip_limit_mode,omitempty"`
+}
+
+// TemplateLabel テンプレートのラベル
+type TemplateLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// TemplateCustomField テンプレートのカスタムフィールド
+type TemplateCustomField struct {
+	Name    string          `json:"name"`
+	Type    CustomFieldType `json:"type"`
+	Options StringList      `json:"options,omitempty"`
+}
+
+// TemplateTask テンプレートの初期タスク
+// Columnは配置先のカラムのインデックス（Columns内の位置、0始まり）、Labelsはラベル名です
+type TemplateTask struct {
+	Column      int          `json:"column"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Priority    TaskPriority `json:"priority,omitempty"`
+	Labels      []string     `json:"labels,omitempty"`
+}
+
+// Validate テンプレートの構成が有効かをチェックします
+func (d *BoardTemplateDefinition) Validate() error {
+	if len(d.Columns) == 0 {
+		return errors.New("テンプレートには1つ以上のカラムが必要です")
+	}
+	for _, column := range d.Columns {
+		if column.Title == "" {
+			return errors.New("カラム名を指定してください")
+		}
+		if column.WIPLimitMode != "" && !column.WIPLimitMode.IsValid() {
+			return errors.New("不正なWIP制限モードです")
+		}
+		if column.WIPLimit != nil && *column.WIPLimit < 1 {
+			return errors.New("WIP制限は1以上で指定してください")
+		}
+	}
+	for _, label := range d.Labels {
+		if label.Name == "" {
+			return errors.New("ラベル名を指定してください")
+		}
+	}
+	for _, field := range d.CustomFields {
+		if field.Name == "" {
+			return errors.New("カスタムフィールド名を指定してください")
+		}
+		if !field.Type.IsValid() {
+			return errors.New("不正なフィールド型です")
+		}
+	}
+	for _, task := range d.Tasks {
+		if task.Title == "" {
+			return errors.New("初期タスクのタイトルを指定してください")
+		}
+		if task.Column < 0 || task.Column >= len(d.Columns) {
+			return errors.New("初期タスクの配置先カラムが不正です")
+		}
+		if task.Priority != "" && !task.Priority.IsValid() {
+			return errors.New("初期タスクの優先度が不正です")
+		}
+	}
+	return nil
+}
+
+// Value データベースへ保存する値に変換します
+func (d BoardTemplateDefinition) Value() (driver.Value, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan データベースの値から復元します
+func (d *BoardTemplateDefinition) Scan(value interface{}) error {
+	if value == nil {
+		*d = BoardTemplateDefinition{}
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("BoardTemplateDefinitionに変換できない型です")
+	}
+
+	return json.Unmarshal(data, d)
+}
+
+// DefaultBoardTemplateKey テンプレートを指定せずにボードを作成した場合に使用する組み込みテンプレートのキー
+const DefaultBoardTemplateKey = "basic"
+
+// BuiltinBoardTemplates 組み込みテンプレートの一覧を返します
+func BuiltinBoardTemplates() []BoardTemplate {
+	limit := func(n int) *int { return &n }
+
+	return []BoardTemplate{
+		{
+			BuiltinKey:  DefaultBoardTemplateKey,
+			Name:        "ベーシック",
+			Description: "To Do / In Progress / Done のシンプルなボード",
+			Definition: BoardTemplateDefinition{
+				Columns: []TemplateColumn{
+					{Title: "To Do"},
+					{Title: "In Progress"},
+					{Title: "Done"},
+				},
+			},
+		},
+		{
+			BuiltinKey:  "scrum",
+			Name:        "スクラム",
+			Description: "プロダクトバックログからスプリントを回すためのボード",
+			Definition: BoardTemplateDefinition{
+				Columns: []TemplateColumn{
+					{Title: "Product Backlog"},
+					{Title: "Sprint Backlog"},
+					{Title: "In Progress", WIPLimit: limit(5), WIPLimitMode: WIPLimitModeSoft},
+					{Title: "Review", WIPLimit: limit(3), WIPLimitMode: WIPLimitModeSoft},
+					{Title: "Done"},
+				},
+				Labels: []TemplateLabel{
+					{Name: "Story", Color: "#3B82F6"},
+					{Name: "Bug", Color: "#EF4444"},
+					{Name: "Chore", Color: "#6B7280"},
+				},
+				CustomFields: []TemplateCustomField{
+					{Name: "Story Points", Type: CustomFieldTypeNumber},
+					{Name: "Sprint", Type: CustomFieldTypeText},
+				},
+				Tasks: []TemplateTask{
+					{Column: 0, Title: "最初のユーザーストーリーを書く", Description: "〇〇として、△△したい。なぜなら□□だから。", Labels: []string{"Story"}},
+					{Column: 1, Title: "スプリントゴールを決める", Labels: []string{"Chore"}},
+				},
+			},
+		},
+		{
+			BuiltinKey:  "bug-triage",
+			Name:        "バグトリアージ",
+			Description: "報告されたバグを分類・修正・検証するためのボード",
+			Definition: BoardTemplateDefinition{
+				Columns: []TemplateColumn{
+					{Title: "New"},
+					{Title: "Triaged"},
+					{Title: "Fixing", WIPLimit: limit(3), WIPLimitMode: WIPLimitModeHard},
+					{Title: "Verifying"},
+					{Title: "Closed"},
+				},
+				Labels: []TemplateLabel{
+					{Name: "Critical", Color: "#DC2626"},
+					{Name: "Regression", Color: "#F59E0B"},
+					{Name: "Needs Info", Color: "#8B5CF6"},
+				},
+				CustomFields: []TemplateCustomField{
+					{Name: "Severity", Type: CustomFieldTypeSingleSelect, Options: StringList{"S1", "S2", "S3", "S4"}},
+					{Name: "Affected Version", Type: CustomFieldTypeText},
+					{Name: "Environment", Type: CustomFieldTypeMultiSelect, Options: StringList{"Web", "iOS", "Android"}},
+				},
+			},
+		},
+		{
+			BuiltinKey:  "personal",
+			Name:        "パーソナル",
+			Description: "個人のタスク管理のためのボード",
+			Definition: BoardTemplateDefinition{
+				Columns: []TemplateColumn{
+					{Title: "Inbox"},
+					{Title: "Today", WIPLimit: limit(3), WIPLimitMode: WIPLimitModeSoft},
+					{Title: "Waiting"},
+					{Title: "Done"},
+				},
+				Labels: []TemplateLabel{
+					{Name: "仕事", Color: "#2563EB"},
+					{Name: "プライベート", Color: "#10B981"},
+				},
+				Tasks: []TemplateTask{
+					{Column: 0, Title: "思いついたことはまず Inbox に入れる"},
+					{Column: 1, Title: "今日やることを 3 つまで選ぶ", Priority: TaskPriorityHigh},
+				},
+			},
+		},
+	}
+}
+
+// FindBuiltinBoardTemplate キーで組み込みテンプレートを取得します（見つからない場合はnil）
+func FindBuiltinBoardTemplate(key string) *BoardTemplate {
+	for _, template := range BuiltinBoardTemplates() {
+		if template.BuiltinKey == key {
+			return &template
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinBoardTemplates_AreValid(t *testing.T) {
+	keys := make(map[string]bool)
+	for _, template := range BuiltinBoardTemplates() {
+		assert.NoError(t, template.Definition.Validate(), template.BuiltinKey)
+		assert.False(t, keys[template.BuiltinKey], "キーが重複しています: %s", template.BuiltinKey)
+		keys[template.BuiltinKey] = true
+	}
+
+	for _, key := range []string{DefaultBoardTemplateKey, "scrum", "bug-triage", "personal"} {
+		template := FindBuiltinBoardTemplate(key)
+		require.NotNil(t, template, key)
+		assert.Equal(t, key, template.TemplateID())
+		assert.True(t, template.IsBuiltin())
+	}
+	assert.Nil(t, FindBuiltinBoardTemplate("unknown"))
+}
+
+func TestBoardTemplateDefinition_Validate(t *testing.T) {
+	definition := BoardTemplateDefinition{
+		Columns: []TemplateColumn{{Title: "To Do"}, {Title: "Done"}},
+		Tasks:   []TemplateTask{{Column: 1, Title: "完了済み"}},
+	}
+	assert.NoError(t, definition.Validate())
+
+	definition.Tasks[0].Column = 2
+	assert.Error(t, definition.Validate(), "存在しないカラムへの初期タスク")
+
+	assert.Error(t, (&BoardTemplateDefinition{}).Validate(), "カラムなし")
+	assert.Error(t, (&BoardTemplateDefinition{
+		Columns:      []TemplateColumn{{Title: "To Do"}},
+		CustomFields: []TemplateCustomField{{Name: "Points", Type: "unknown"}},
+	}).Validate(), "不正なフィールド型")
+}
+
+func TestBoardTemplateDefinition_ValueScan(t *testing.T) {
+	original := FindBuiltinBoardTemplate("bug-triage").Definition
+
+	value, err := original.Value()
+	require.NoError(t, err)
+
+	var restored BoardTemplateDefinition
+	require.NoError(t, restored.Scan([]byte(value.(string))))
+	assert.Equal(t, original, restored)
+}
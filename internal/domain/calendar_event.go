@@ -17,6 +17,7 @@ type CalendarEvent struct {
 	End         time.Time      `json:"end" gorm:"not null"`
 	Color       string         `json:"color" gorm:"default:'#3B82F6'"`              // イベントの色
 	IsTaskBased bool           `json:"is_task_based" gorm:"not null;default:false"` // タスクベースのイベントかどうか
+	Version     int            `json:"version" gorm:"not null;default:1"`           // 楽観的排他制御用のバージョン
 	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,13 +10,16 @@ import (
 // Column Kanbanボードのカラム（列）を表すエンティティ
 // ボードに属し、複数のタスクを持ちます
 type Column struct {
-	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
-	BoardID   uint           `json:"board_id" gorm:"not null;index"`
-	Title     string         `json:"title" gorm:"not null" validate:"required,min=1,max=50"`
-	Order     int            `json:"order" gorm:"not null;default:0"` // カラムの表示順序
-	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
+	ID           uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	BoardID      uint           `json:"board_id" gorm:"not null;index"`
+	Title        string         `json:"title" gorm:"not null" validate:"required,min=1,max=50"`
+	Order        int            `json:"order" gorm:"not null;default:0"`                               // カラムの表示順序
+	WIPLimit     *int           `json:"wip_limit"`                                                     // 仕掛り中タスク数の上限（nilは無制限）
+	WIPLimitMode WIPLimitMode   `json:"wip_limit_mode" gorm:"type:varchar(8);not null;default:'soft'"` // 上限を超えた場合の扱い
+	Version      int            `json:"version" gorm:"not null;default:1"`                             // 楽観的排他制御用のバージョン
+	CreatedAt    time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
 
 	// リレーション：このカラムが属するボード
 	Board Board `json:"board,omitempty" gorm:"foreignKey:BoardID"`
@@ -28,3 +32,56 @@ type Column struct {
 func (Column) TableName() string {
 	return "columns"
 }
+
+// WIPLimitMode WIP制限の適用方法
+type WIPLimitMode string
+
+const (
+	WIPLimitModeSoft WIPLimitMode = "soft" // 上限を超えても警告のみ
+	WIPLimitModeHard WIPLimitMode = "hard" // 上限を超える追加・移動を拒否
+)
+
+// IsValid WIP制限の適用方法が有効な値かを判定します
+func (m WIPLimitMode) IsValid() bool {
+	return m == WIPLimitModeSoft || m == WIPLimitModeHard
+}
+
+// CheckWIPLimit タスク数がcountになった場合にWIP制限を超えるかを判定します
+// 超える場合はWIPLimitErrorを返し、超えない・制限がない場合はnilを返します
+func (c *Column) CheckWIPLimit(count int) *WIPLimitError {
+	if c.WIPLimit == nil || count <= *c.WIPLimit {
+		return nil
+	}
+	mode := c.WIPLimitMode
+	if mode == "" {
+		mode = WIPLimitModeSoft
+	}
+	return &WIPLimitError{
+		ColumnID: c.ID,
+		Limit:    *c.WIPLimit,
+		Count:    count,
+		Mode:     mode,
+	}
+}
+
+// ErrCodeWIPLimitExceeded WIP制限超過を表すエラーコード
+const ErrCodeWIPLimitExceeded = "WIP_LIMIT_EXCEEDED"
+
+// WIPLimitError カラムのWIP制限を超えたことを表すエラー
+// Modeがsoftの場合は警告として扱われます
+type WIPLimitError struct {
+	ColumnID uint         `json:"column_id"`
+	Limit    int          `json:"limit"`
+	Count    int          `json:"count"` // 追加・移動後のタスク数
+	Mode     WIPLimitMode `json:"mode"`
+}
+
+// Error エラーメッセージを返します
+func (e *WIPLimitError) Error() string {
+	return fmt.Sprintf("カラムのWIP制限（%d件）を超えています", e.Limit)
+}
+
+// Code エラーコードを返します
+func (e *WIPLimitError) Code() string {
+	return ErrCodeWIPLimitExceeded
+}
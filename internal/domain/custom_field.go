@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// カスタムフィールドの入力制限
+const (
+	MaxCustomFieldTextLength = 1000
+	MaxCustomFieldOptions    = 50
+)
+
+// CustomFieldType カスタムフィールドの型
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText         CustomFieldType = "text"
+	CustomFieldTypeNumber       CustomFieldType = "number"
+	CustomFieldTypeDate         CustomFieldType = "date"
+	CustomFieldTypeSingleSelect CustomFieldType = "single_select"
+	CustomFieldTypeMultiSelect  CustomFieldType = "multi_select"
+)
+
+// IsValid 定義済みのフィールド型かどうかを判定します
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeDate,
+		CustomFieldTypeSingleSelect, CustomFieldTypeMultiSelect:
+		return true
+	}
+	return false
+}
+
+// IsSelect 選択肢を持つフィールド型かどうかを判定します
+func (t CustomFieldType) IsSelect() bool {
+	return t == CustomFieldTypeSingleSelect || t == CustomFieldTypeMultiSelect
+}
+
+// CustomFieldDefinition ボードごとに定義されるカスタムフィールドを表すエンティティ
+type CustomFieldDefinition struct {
+	ID        uint            `json:"id" gorm:"primaryKey;autoIncrement"`
+	BoardID   uint            `json:"board_id" gorm:"not null;index"`
+	Name      string          `json:"name" gorm:"not null" validate:"required,min=1,max=50"`
+	Type      CustomFieldType `json:"type" gorm:"type:varchar(20);not null"`
+	Options   StringList      `json:"options,omitempty" gorm:"type:jsonb"` // 選択肢（single_select / multi_select のみ）
+	Order     int             `json:"order" gorm:"not null;default:0"`     // 表示順序
+	CreatedAt time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt  `json:"-" gorm:"index"` // ソフトデリート対応
+
+	// リレーション：このフィールドが属するボード
+	Board Board `json:"-" gorm:"foreignKey:BoardID"`
+}
+
+// TableName テーブル名を明示的に指定
+func (CustomFieldDefinition) TableName() string {
+	return "custom_field_definitions"
+}
+
+// TaskCustomFieldValue タスクごとのカスタムフィールド値を表すエンティティ
+// フィールド型に応じていずれか1つの値カラムが使用されます
+type TaskCustomFieldValue struct {
+	ID           uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	TaskID       uint       `json:"task_id" gorm:"not null;uniqueIndex:idx_task_custom_field"`
+	FieldID      uint       `json:"field_id" gorm:"not null;uniqueIndex:idx_task_custom_field;index"`
+	TextValue    *string    `json:"text_value,omitempty" gorm:"type:text"`
+	NumberValue  *float64   `json:"number_value,omitempty"`
+	DateValue    *time.Time `json:"date_value,omitempty" gorm:"type:date"`
+	OptionValues StringList `json:"option_values,omitempty" gorm:"type:jsonb"` // 選択された選択肢
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// リレーション：この値のフィールド定義
+	Field CustomFieldDefinition `json:"-" gorm:"foreignKey:FieldID"`
+}
+
+// TableName テーブル名を明示的に指定
+func (TaskCustomFieldValue) TableName() string {
+	return "task_custom_field_values"
+}
+
+// NormalizeCustomFieldOptions 選択肢を検証し、空白除去・重複排除した一覧を返します
+func NormalizeCustomFieldOptions(fieldType CustomFieldType, options []string) (StringList, error) {
+	if !fieldType.IsSelect() {
+		if len(options) > 0 {
+			return nil, errors.New("選択肢は選択型のフィールドにのみ指定できます")
+		}
+		return nil, nil
+	}
+
+	normalized := StringList{}
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" || normalized.Contains(option) {
+			continue
+		}
+		normalized = append(normalized, option)
+	}
+
+	if len(normalized) == 0 {
+		return nil, errors.New("選択型のフィールドには選択肢が必要です")
+	}
+	if len(normalized) > MaxCustomFieldOptions {
+		return nil, fmt.Errorf("選択肢は%d個以内で指定してください", MaxCustomFieldOptions)
+	}
+	return normalized, nil
+}
+
+// ParseCustomFieldValue フィールド型に従って値を検証し、保存用の値に変換します
+// rawはJSONからデコードされた値です
+func ParseCustomFieldValue(field *CustomFieldDefinition, raw interface{}) (*TaskCustomFieldValue, error) {
+	value := &TaskCustomFieldValue{}
+
+	switch field.Type {
+	case CustomFieldTypeText:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%sには文字列を指定してください", field.Name)
+		}
+		if len([]rune(text)) > MaxCustomFieldTextLength {
+			return nil, fmt.Errorf("%sは%d文字以内で指定してください", field.Name, MaxCustomFieldTextLength)
+		}
+		value.TextValue = &text
+
+	case CustomFieldTypeNumber:
+		var number float64
+		switch v := raw.(type) {
+		case float64:
+			number = v
+		case int:
+			number = float64(v)
+		case string:
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%sには数値を指定してください", field.Name)
+			}
+			number = parsed
+		default:
+			return nil, fmt.Errorf("%sには数値を指定してください", field.Name)
+		}
+		value.NumberValue = &number
+
+	case CustomFieldTypeDate:
+		dateStr, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%sには日付を指定してください（YYYY-MM-DD形式）", field.Name)
+		}
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return nil, fmt.Errorf("%sには日付を指定してください（YYYY-MM-DD形式）", field.Name)
+		}
+		value.DateValue = &date
+
+	case CustomFieldTypeSingleSelect:
+		option, ok := raw.(string)
+		if !ok || !field.Options.Contains(option) {
+			return nil, fmt.Errorf("%sには定義済みの選択肢を指定してください", field.Name)
+		}
+		value.OptionValues = StringList{option}
+
+	case CustomFieldTypeMultiSelect:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%sには選択肢の配列を指定してください", field.Name)
+		}
+		selected := StringList{}
+		for _, item := range items {
+			option, ok := item.(string)
+			if !ok || !field.Options.Contains(option) {
+				return nil, fmt.Errorf("%sには定義済みの選択肢を指定してください", field.Name)
+			}
+			if !selected.Contains(option) {
+				selected = append(selected, option)
+			}
+		}
+		value.OptionValues = selected
+
+	default:
+		return nil, fmt.Errorf("不正なフィールド型です: %s", field.Type)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ParseCustomFieldValueの型ごとの検証テスト
+func TestParseCustomFieldValue(t *testing.T) {
+	selectOptions := StringList{"A", "B"}
+
+	tests := []struct {
+		name    string
+		field   CustomFieldDefinition
+		raw     interface{}
+		wantErr bool
+	}{
+		{"テキスト", CustomFieldDefinition{Type: CustomFieldTypeText}, "顧客A", false},
+		{"テキストに数値", CustomFieldDefinition{Type: CustomFieldTypeText}, 1.0, true},
+		{"数値", CustomFieldDefinition{Type: CustomFieldTypeNumber}, 3.0, false},
+		{"数値に文字列", CustomFieldDefinition{Type: CustomFieldTypeNumber}, "abc", true},
+		{"日付", CustomFieldDefinition{Type: CustomFieldTypeDate}, "2025-01-15", false},
+		{"日付の形式不正", CustomFieldDefinition{Type: CustomFieldTypeDate}, "15/01/2025", true},
+		{"単一選択", CustomFieldDefinition{Type: CustomFieldTypeSingleSelect, Options: selectOptions}, "A", false},
+		{"単一選択の未定義値", CustomFieldDefinition{Type: CustomFieldTypeSingleSelect, Options: selectOptions}, "C", true},
+		{"複数選択", CustomFieldDefinition{Type: CustomFieldTypeMultiSelect, Options: selectOptions}, []interface{}{"A", "B", "A"}, false},
+		{"複数選択の未定義値", CustomFieldDefinition{Type: CustomFieldTypeMultiSelect, Options: selectOptions}, []interface{}{"A", "C"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ParseCustomFieldValue(&tt.field, tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, value)
+		})
+	}
+
+	// 複数選択は重複が除去される
+	value, err := ParseCustomFieldValue(&CustomFieldDefinition{Type: CustomFieldTypeMultiSelect, Options: selectOptions}, []interface{}{"A", "B", "A"})
+	assert.NoError(t, err)
+	assert.Equal(t, StringList{"A", "B"}, value.OptionValues)
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// JSONMap キーと値の組をJSONBカラムとして保存するための型
+type JSONMap map[string]interface{}
+
+// Value データベースへ保存する値に変換します
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(map[string]interface{}(m))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan データベースの値から復元します
+func (m *JSONMap) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("JSONMapに変換できない型です")
+	}
+
+	return json.Unmarshal(data, (*map[string]interface{})(m))
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultLabelColor 色を指定しない場合のラベルの色
+const DefaultLabelColor = "#6B7280"
+
+// labelColorPattern ラベルの色の形式（ハンドラのhexcolorの検証と同じ16進数のカラーコード）
+var labelColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// ValidateLabelColor ラベルの色が16進数のカラーコード（#RRGGBB など）かどうかをチェックします
+func ValidateLabelColor(color string) error {
+	if !labelColorPattern.MatchString(color) {
+		return errors.New("ラベルの色は #RRGGBB 形式の16進数のカラーコードで指定してください")
+	}
+	return nil
+}
+
+// Label ボードごとに定義されるタスクのラベルを表すエンティティ
+type Label struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	BoardID   uint           `json:"board_id" gorm:"not null;index"`
+	Name      string         `json:"name" gorm:"not null" validate:"required,min=1,max=30"`
+	Color     string         `json:"color" gorm:"not null;default:'#6B7280'"` // ラベルの色
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
+}
+
+// TableName テーブル名を明示的に指定
+func (Label) TableName() string {
+	return "labels"
+}
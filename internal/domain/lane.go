@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Lane ボードのスイムレーン（行）を表すエンティティ
+// タスクはカラムとレーンの組（セル）の中で順序付けされます
+type Lane struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	BoardID   uint           `json:"board_id" gorm:"not null;index"`
+	Name      string         `json:"name" gorm:"not null" validate:"required,min=1,max=50"`
+	Order     int            `json:"order" gorm:"not null;default:0"` // レーンの表示順序
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
+}
+
+// TableName テーブル名を明示的に指定
+func (Lane) TableName() string {
+	return "lanes"
+}
+
+// SwimlaneGroupBy スイムレーンの分け方
+type SwimlaneGroupBy string
+
+const (
+	SwimlaneByLane     SwimlaneGroupBy = "lane"     // ボードに定義したレーン
+	SwimlaneByAssignee SwimlaneGroupBy = "assignee" // 担当者
+	SwimlaneByPriority SwimlaneGroupBy = "priority" // 優先度
+)
+
+// IsValid スイムレーンの分け方が有効な値かを判定します
+func (g SwimlaneGroupBy) IsValid() bool {
+	switch g {
+	case SwimlaneByLane, SwimlaneByAssignee, SwimlaneByPriority:
+		return true
+	}
+	return false
+}
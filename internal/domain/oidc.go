@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OIDCLoginStateTTL ログインを開始してからコールバックまでの有効期限
+const OIDCLoginStateTTL = 10 * time.Minute
+
+// OIDCLoginCodeTTL コールバックでフロントエンドに渡したコードをJWTと交換できる期限
+const OIDCLoginCodeTTL = time.Minute
+
+// UserIdentity 外部のIDプロバイダー（OpenID Connect）のアカウントとユーザーの紐付けを表すエンティティ
+type UserIdentity struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	Issuer    string    `json:"issuer" gorm:"not null;uniqueIndex:idx_user_identities_issuer_subject,priority:1"`
+	Subject   string    `json:"subject" gorm:"not null;uniqueIndex:idx_user_identities_issuer_subject,priority:2"` // IDプロバイダーでのユーザーID（sub）
+	Email     string    `json:"email"`                                                                             // 紐付け時のメールアドレス
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName テーブル名を明示的に指定
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// OIDCLoginState ログインの開始からコールバックまでの間に保持する値（stateごとに1回のみ使用）
+type OIDCLoginState struct {
+	State        string    `gorm:"primaryKey"`
+	CodeVerifier string    `gorm:"not null"` // PKCEのコード検証値
+	Nonce        string    `gorm:"not null"` // IDトークンの再利用を防ぐための値
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName テーブル名を明示的に指定
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
+
+// IsExpired 有効期限を過ぎているかどうかを判定します
+func (s *OIDCLoginState) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// PKCEChallenge PKCEのコード検証値からS256方式のコードチャレンジを返します
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenAccessLevel パーソナルアクセストークンの権限
+type TokenAccessLevel string
+
+const (
+	TokenAccessRead  TokenAccessLevel = "read"  // 参照のみ（GETリクエスト）
+	TokenAccessWrite TokenAccessLevel = "write" // 参照と変更
+)
+
+// IsValid 有効な権限かどうかを判定します
+func (l TokenAccessLevel) IsValid() bool {
+	return l == TokenAccessRead || l == TokenAccessWrite
+}
+
+// TokenScope パーソナルアクセストークンのスコープ（対象のボードと権限）
+type TokenScope struct {
+	BoardID *uint            `json:"board_id,omitempty"` // nilの場合はすべてのボードとボードに属さないAPI
+	Access  TokenAccessLevel `json:"access"`
+}
+
+// TokenScopes スコープの配列をJSONBカラムとして保存するための型
+type TokenScopes []TokenScope
+
+// Value データベースへ保存する値に変換します
+func (s TokenScopes) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]TokenScope(s))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan データベースの値から復元します
+func (s *TokenScopes) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("TokenScopesに変換できない型です")
+	}
+
+	return json.Unmarshal(data, (*[]TokenScope)(s))
+}
+
+// Validate スコープの形式をチェックします
+func (s TokenScopes) Validate() error {
+	if len(s) == 0 {
+		return errors.New("スコープを1つ以上指定してください")
+	}
+	for _, scope := range s {
+		if !scope.Access.IsValid() {
+			return fmt.Errorf("不正な権限です: %s", scope.Access)
+		}
+	}
+	return nil
+}
+
+// Allows スコープでリクエストが許可されるかどうかを判定します
+// boardIDがnilの場合はボードに属さないAPIとして、すべてのボードを対象とするスコープのみ許可します
+func (s TokenScopes) Allows(boardID *uint, write bool) bool {
+	for _, scope := range s {
+		if write && scope.Access != TokenAccessWrite {
+			continue
+		}
+		if scope.BoardID == nil || (boardID != nil && *scope.BoardID == *boardID) {
+			return true
+		}
+	}
+	return false
+}
+
+// PersonalAccessToken スクリプトやCLIからAPIを利用するためのトークンを表すエンティティ
+// トークンそのものは保存せず、SHA-256のハッシュのみを保存します
+type PersonalAccessToken struct {
+	ID          uint        `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID      uuid.UUID   `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name        string      `json:"name" gorm:"not null"`
+	TokenHash   string      `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	TokenPrefix string      `json:"token_prefix" gorm:"not null"` // 一覧でトークンを見分けるための先頭部分
+	Scopes      TokenScopes `json:"scopes" gorm:"type:jsonb;not null"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"` // nilの場合は無期限
+	LastUsedAt  *time.Time  `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time   `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName テーブル名を明示的に指定
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// IsExpired 有効期限を過ぎているかどうかを判定します
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// HashPersonalAccessToken トークンを保存・照合するためのハッシュ（SHA-256の16進数）を返します
+// トークンは十分な長さの乱数のため、パスワードのような低速なハッシュは使用しません
+func HashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
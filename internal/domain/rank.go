@@ -0,0 +1,90 @@
+package domain
+
+import "strings"
+
+// タスクの並び順を表すランクキーに使用する文字（バイト順で昇順）
+// ランクキーは辞書順で比較し、2つのキーの間には常に新しいキーを作成できます
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const rankBase = len(rankDigits)
+
+// RankBetween prevとnextの間に並ぶランクキーを返します
+// prevが空の場合は先頭、nextが空の場合は末尾を意味します
+// prev < next であり、どちらも末尾が'0'でないキーである必要があります
+func RankBetween(prev, next string) string {
+	if next != "" {
+		// 共通の接頭辞はそのまま残し、残りの部分の間を求める
+		i := 0
+		for i < len(next) && rankDigitAt(prev, i) == rankIndex(next[i]) {
+			i++
+		}
+		if i > 0 {
+			rest := ""
+			if i < len(prev) {
+				rest = prev[i:]
+			}
+			return next[:i] + RankBetween(rest, next[i:])
+		}
+	}
+
+	lo := rankDigitAt(prev, 0)
+	hi := rankBase
+	if next != "" {
+		hi = rankIndex(next[0])
+	}
+
+	// 先頭の文字の間に余裕があれば1文字で表せる
+	if hi-lo > 1 {
+		return string(rankDigits[(lo+hi)/2])
+	}
+
+	// 先頭の文字が隣り合う場合
+	if next != "" && len(next) > 1 {
+		return next[:1]
+	}
+	rest := ""
+	if len(prev) > 1 {
+		rest = prev[1:]
+	}
+	return string(rankDigits[lo]) + RankBetween(rest, "")
+}
+
+// RankSequence 等間隔に並ぶn個のランクキーを返します（ランクの再配置用）
+func RankSequence(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	// n個のキーを等間隔に配置できる桁数を求める
+	width, capacity := 1, rankBase
+	for capacity <= n {
+		width++
+		capacity *= rankBase
+	}
+	step := capacity / (n + 1)
+
+	keys := make([]string, n)
+	for i := range keys {
+		value := (i + 1) * step
+		key := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			key[j] = rankDigits[value%rankBase]
+			value /= rankBase
+		}
+		keys[i] = strings.TrimRight(string(key), "0")
+	}
+	return keys
+}
+
+// rankIndex ランクキーの文字の値を返します
+func rankIndex(c byte) int {
+	return strings.IndexByte(rankDigits, c)
+}
+
+// rankDigitAt キーのi文字目の値を返します（キーより後ろは0とみなします）
+func rankDigitAt(key string, i int) int {
+	if i >= len(key) {
+		return 0
+	}
+	return rankIndex(key[i])
+}
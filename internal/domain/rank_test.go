@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RankBetweenで作成したキーが前後のキーの間に並ぶことのテスト
+func TestRankBetween(t *testing.T) {
+	cases := []struct {
+		prev, next string
+	}{
+		{"", ""},
+		{"", "1"},
+		{"i", ""},
+		{"z", ""},
+		{"a", "b"},
+		{"a", "a1"},
+		{"az", "b"},
+		{"a", "az"},
+		{"a5", "a6"},
+		{"zz", ""},
+		{"", "01"},
+	}
+	for _, c := range cases {
+		key := RankBetween(c.prev, c.next)
+		assert.True(t, key > c.prev, "%q > %q", key, c.prev)
+		if c.next != "" {
+			assert.True(t, key < c.next, "%q < %q", key, c.next)
+		}
+		assert.NotEqual(t, byte('0'), key[len(key)-1], "%q", key)
+	}
+}
+
+// 同じ位置への挿入を繰り返しても順序が保たれることのテスト
+func TestRankBetween_RepeatedInsert(t *testing.T) {
+	keys := []string{RankBetween("", "")}
+	for i := 0; i < 200; i++ {
+		// 常に先頭の2つの間に挿入する
+		next := ""
+		if len(keys) > 1 {
+			next = keys[1]
+		}
+		key := RankBetween(keys[0], next)
+		keys = append([]string{keys[0], key}, keys[1:]...)
+	}
+	assert.True(t, sort.StringsAreSorted(keys))
+
+	for i := 0; i < 200; i++ {
+		keys = append([]string{RankBetween("", keys[0])}, keys...)
+		keys = append(keys, RankBetween(keys[len(keys)-1], ""))
+	}
+	assert.True(t, sort.StringsAreSorted(keys))
+}
+
+// RankSequenceが昇順で重複のないキーを返すことのテスト
+func TestRankSequence(t *testing.T) {
+	for _, n := range []int{1, 5, 35, 36, 1000} {
+		keys := RankSequence(n)
+		assert.Len(t, keys, n)
+		assert.True(t, sort.SliceIsSorted(keys, func(i, j int) bool { return keys[i] < keys[j] }))
+		for i := 1; i < n; i++ {
+			assert.NotEqual(t, keys[i-1], keys[i])
+		}
+		for _, key := range keys {
+			assert.NotEmpty(t, key)
+			assert.NotEqual(t, byte('0'), key[len(key)-1])
+		}
+	}
+}
@@ -9,14 +9,17 @@ import (
 
 // Task Kanbanボードのタスクを表すエンティティ
 // カラムに属し、ユーザーが担当できます
+// 順序はカラムとレーンの組（セル）ごとにランクキーで管理されます
 type Task struct {
 	ID          uint       `json:"id" gorm:"primaryKey;autoIncrement"`
 	ColumnID    uint       `json:"column_id" gorm:"not null;index"`
+	LaneID      *uint      `json:"lane_id,omitempty" gorm:"index"` // スイムレーン（nilはレーンなし）
 	Title       string     `json:"title" gorm:"not null" validate:"required,min=1,max=100"`
 	Description string     `json:"description" gorm:"type:text"`
-	Order       int        `json:"order" gorm:"not null;default:0"`              // タスクの表示順序
-	AssigneeID  *uuid.UUID `json:"assignee_id,omitempty" gorm:"type:uuid;index"` // 担当者（任意）
-	DueDate     *time.Time `json:"due_date,omitempty"`                           // 期限（任意）
+	Rank        string     `json:"rank" gorm:"type:varchar(255);not null;default:''"` // セル内の並び順を表すランクキー（辞書順）
+	Order       int        `json:"order" gorm:"-"`                                    // セル内の表示順序（1始まり、ランクから算出）
+	AssigneeID  *uuid.UUID `json:"assignee_id,omitempty" gorm:"type:uuid;index"`      // 担当者（任意）
+	DueDate     *time.Time `json:"due_date,omitempty"`                                // 期限（任意）
 
 	// 新機能用フィールド
 	EstimatedTime  *int       `json:"estimated_time,omitempty" gorm:"default:null"`  // 目標時間（分）
@@ -26,6 +29,9 @@ type Task struct {
 	ScheduledEnd   *time.Time `json:"scheduled_end,omitempty" gorm:"default:null"`   // スケジュール終了時刻
 	CalendarDate   *time.Time `json:"calendar_date,omitempty" gorm:"default:null"`   // カレンダー配置日
 
+	Priority TaskPriority `json:"priority" gorm:"type:varchar(16);not null;default:'none';index"` // 優先度
+
+	Version   int            `json:"version" gorm:"not null;default:1"` // 楽観的排他制御用のバージョン
 	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
@@ -35,9 +41,72 @@ type Task struct {
 
 	// リレーション：このタスクの担当者（任意）
 	Assignee *User `json:"assignee,omitempty" gorm:"foreignKey:AssigneeID"`
+
+	// リレーション：このタスクに付与されたラベル一覧
+	Labels []Label `json:"labels,omitempty" gorm:"many2many:task_labels;"`
+
+	// リレーション：このタスクのカスタムフィールド値一覧
+	CustomFieldValues []TaskCustomFieldValue `json:"custom_field_values,omitempty" gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE"`
 }
 
 // TableName テーブル名を明示的に指定
 func (Task) TableName() string {
 	return "tasks"
 }
+
+// AssignCellOrders セル内でランク順に並んだタスクに、セルごとの表示順序（1始まり）を設定します
+func AssignCellOrders(tasks []Task) {
+	type cell struct {
+		columnID uint
+		laneID   uint
+	}
+	positions := make(map[cell]int)
+	for i := range tasks {
+		key := cell{columnID: tasks[i].ColumnID}
+		if tasks[i].LaneID != nil {
+			key.laneID = *tasks[i].LaneID
+		}
+		positions[key]++
+		tasks[i].Order = positions[key]
+	}
+}
+
+// TaskPriority タスクの優先度
+type TaskPriority string
+
+const (
+	TaskPriorityNone   TaskPriority = "none"
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+// TaskPriorities 優先度の一覧（低い順）
+var TaskPriorities = []TaskPriority{
+	TaskPriorityNone,
+	TaskPriorityLow,
+	TaskPriorityMedium,
+	TaskPriorityHigh,
+	TaskPriorityUrgent,
+}
+
+// IsValid 定義済みの優先度かどうかを判定します
+func (p TaskPriority) IsValid() bool {
+	for _, v := range TaskPriorities {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank 優先度の並び替え用の数値を返します（高いほど優先）
+func (p TaskPriority) Rank() int {
+	for i, v := range TaskPriorities {
+		if p == v {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskActivityAction タスクの履歴の種類
+type TaskActivityAction string
+
+const (
+	TaskActivityMovedBoard TaskActivityAction = "moved_board" // 別のボードへ移動
+)
+
+// TaskActivity タスクに対して行われた操作の履歴を表すエンティティ
+// 操作ごとの詳細（移動元・移動先など）はDetailsに保存されます
+type TaskActivity struct {
+	ID        uint               `json:"id" gorm:"primaryKey;autoIncrement"`
+	TaskID    uint               `json:"task_id" gorm:"not null;index"`
+	UserID    uuid.UUID          `json:"user_id" gorm:"type:uuid;not null;index"` // 操作したユーザー
+	Action    TaskActivityAction `json:"action" gorm:"type:varchar(32);not null"`
+	Details   JSONMap            `json:"details" gorm:"type:jsonb"`
+	CreatedAt time.Time          `json:"created_at" gorm:"autoCreateTime;index"`
+
+	// リレーション：操作したユーザー（表示名の表示に使用）
+	User *User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TableName テーブル名を明示的に指定
+func (TaskActivity) TableName() string {
+	return "task_activities"
+}
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TaskCSVHeader タスクのCSVの列（エクスポート時の並び順）
+var TaskCSVHeader = []string{
+	"column",         // カラム名
+	"title",          // タイトル
+	"description",    // 説明
+	"assignee_email", // 担当者のメールアドレス
+	"due_date",       // 期限（YYYY-MM-DD または RFC3339）
+	"estimated_time", // 目標時間（分）
+	"actual_time",    // 実際にかかった時間（分）
+	"is_completed",   // 完了（true / false）
+}
+
+// utf8BOM Excelで文字化けせずに開けるよう、エクスポート時に先頭に付けるBOM
+const utf8BOM = "\uFEFF"
+
+// TaskCSVRow タスクのCSVの1行
+type TaskCSVRow struct {
+	Line          int // CSV内の行番号（ヘッダーを1行目とする）
+	Column        string
+	Title         string
+	Description   string
+	AssigneeEmail string
+	DueDate       *time.Time
+	EstimatedTime *int
+	ActualTime    *int
+	IsCompleted   bool
+}
+
+// TaskCSVRowError CSVの行ごとのエラー
+type TaskCSVRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ParseTaskCSV タスクのCSVを読み込みます
+// 列はヘッダーの名前で判断するため順不同で、未知の列は無視します。column と title の列は必須です
+// ヘッダーが不正な場合はerrorを返し、値や引用符が不正な行はrowErrorsに記録して読み込みを続けます
+func ParseTaskCSV(r io.Reader) (rows []TaskCSVRow, rowErrors []TaskCSVRowError, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("CSVが空です")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("CSVの読み込みに失敗しました: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, utf8BOM)
+		}
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"column", "title"} {
+		if _, ok := index[required]; !ok {
+			return nil, nil, fmt.Errorf("CSVのヘッダーに %s の列がありません", required)
+		}
+	}
+
+	rows = []TaskCSVRow{}
+	rowErrors = []TaskCSVRowError{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// 引用符の誤りなど行の形式が不正な場合は、その行のエラーとして続きを読み込む
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, nil, fmt.Errorf("CSVの読み込みに失敗しました: %w", err)
+			}
+			rowErrors = append(rowErrors, TaskCSVRowError{Line: parseErr.StartLine, Message: taskCSVParseErrorMessage(parseErr)})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		value := func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		// 空行は読み飛ばす
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		row, problems := parseTaskCSVRow(value)
+		row.Line = line
+		for _, problem := range problems {
+			rowErrors = append(rowErrors, TaskCSVRowError{Line: line, Message: problem})
+		}
+		if len(problems) == 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, rowErrors, nil
+}
+
+// taskCSVParseErrorMessage CSVの形式のエラーのメッセージを返します
+func taskCSVParseErrorMessage(parseErr *csv.ParseError) string {
+	if errors.Is(parseErr.Err, csv.ErrQuote) || errors.Is(parseErr.Err, csv.ErrBareQuote) {
+		return fmt.Sprintf("%d行目のダブルクォート（\"）の使い方が不正です", parseErr.Line)
+	}
+	return fmt.Sprintf("CSVの形式が不正です: %v", parseErr.Err)
+}
+
+// parseTaskCSVRow CSVの1行の値をチェックしてTaskCSVRowに変換します
+func parseTaskCSVRow(value func(name string) string) (TaskCSVRow, []string) {
+	var problems []string
+	row := TaskCSVRow{
+		Column:        value("column"),
+		Title:         value("title"),
+		Description:   value("description"),
+		AssigneeEmail: value("assignee_email"),
+	}
+
+	if row.Column == "" {
+		problems = append(problems, "カラム名を指定してください")
+	} else if utf8.RuneCountInString(row.Column) > 50 {
+		problems = append(problems, "カラム名は50文字以内で指定してください")
+	}
+	if row.Title == "" {
+		problems = append(problems, "タイトルを指定してください")
+	} else if utf8.RuneCountInString(row.Title) > 100 {
+		problems = append(problems, "タイトルは100文字以内で指定してください")
+	}
+
+	if s := value("due_date"); s != "" {
+		dueDate, err := parseTaskCSVDate(s)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("期限の形式が不正です: %s", s))
+		} else {
+			row.DueDate = &dueDate
+		}
+	}
+
+	for _, field := range []struct {
+		name  string
+		label string
+		dest  **int
+	}{
+		{"estimated_time", "目標時間", &row.EstimatedTime},
+		{"actual_time", "実際にかかった時間", &row.ActualTime},
+	} {
+		s := value(field.name)
+		if s == "" {
+			continue
+		}
+		minutes, err := strconv.Atoi(s)
+		if err != nil || minutes < 0 {
+			problems = append(problems, fmt.Sprintf("%sは0以上の整数（分）で指定してください: %s", field.label, s))
+			continue
+		}
+		*field.dest = &minutes
+	}
+
+	if s := value("is_completed"); s != "" {
+		completed, err := strconv.ParseBool(strings.ToLower(s))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("完了は true または false で指定してください: %s", s))
+		}
+		row.IsCompleted = completed
+	}
+
+	return row, problems
+}
+
+// parseTaskCSVDate 期限を YYYY-MM-DD または RFC3339 の形式で読み込みます
+func parseTaskCSVDate(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// WriteTaskCSV タスクをCSVとして書き出します
+func WriteTaskCSV(w io.Writer, rows []TaskCSVRow) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(TaskCSVHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := []string{
+			row.Column,
+			row.Title,
+			row.Description,
+			row.AssigneeEmail,
+			"",
+			formatTaskCSVMinutes(row.EstimatedTime),
+			formatTaskCSVMinutes(row.ActualTime),
+			strconv.FormatBool(row.IsCompleted),
+		}
+		if row.DueDate != nil {
+			record[4] = row.DueDate.Format(time.RFC3339)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatTaskCSVMinutes 時間（分）をCSVの値に変換します（未設定は空欄）
+func formatTaskCSVMinutes(minutes *int) string {
+	if minutes == nil {
+		return ""
+	}
+	return strconv.Itoa(*minutes)
+}
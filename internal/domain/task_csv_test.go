@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskCSV_RoundTrip(t *testing.T) {
+	due := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	estimate := 90
+	rows := []TaskCSVRow{
+		{Column: "To Do", Title: "設計", Description: "改行を\n含む, 説明", AssigneeEmail: "alice@example.com", DueDate: &due, EstimatedTime: &estimate},
+		{Column: "Done", Title: "調査", IsCompleted: true},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTaskCSV(&buf, rows))
+
+	parsed, rowErrors, err := ParseTaskCSV(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	require.Len(t, parsed, 2)
+	assert.Equal(t, 2, parsed[0].Line)
+	assert.Equal(t, 4, parsed[1].Line, "改行を含むセルの分だけ行番号が進む")
+
+	parsed[0].Line, parsed[1].Line = 0, 0
+	assert.True(t, due.Equal(*parsed[0].DueDate))
+	parsed[0].DueDate = &due
+	assert.Equal(t, rows, parsed)
+}
+
+func TestParseTaskCSV_RowErrors(t *testing.T) {
+	input := strings.Join([]string{
+		"Title,Column,Due_Date,Estimated_Time,Is_Completed,Memo",
+		"設計,To Do,2025-04-01,30,yes,任意の列",
+		",To Do,,,,",
+		"",
+		"実装,Doing,4/1,-5,false,",
+	}, "\n")
+
+	rows, rowErrors, err := ParseTaskCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 0)
+	assert.Equal(t, []TaskCSVRowError{
+		{Line: 2, Message: "完了は true または false で指定してください: yes"},
+		{Line: 3, Message: "タイトルを指定してください"},
+		{Line: 5, Message: "期限の形式が不正です: 4/1"},
+		{Line: 5, Message: "目標時間は0以上の整数（分）で指定してください: -5"},
+	}, rowErrors)
+
+	_, _, err = ParseTaskCSV(strings.NewReader("title,description\n設計,\n"))
+	assert.Error(t, err, "column の列がない場合はエラー")
+}
+
+func TestParseTaskCSV_MalformedQuotes(t *testing.T) {
+	input := strings.Join([]string{
+		"column,title",
+		"To Do,\"設計\"の続き",
+		"To Do,実装",
+		"To Do,途中に\"がある",
+		"Done,調査",
+	}, "\n")
+
+	rows, rowErrors, err := ParseTaskCSV(strings.NewReader(input))
+	require.NoError(t, err, "引用符の誤りは行ごとのエラーとして読み込みを続ける")
+	require.Len(t, rows, 2)
+	assert.Equal(t, "実装", rows[0].Title)
+	assert.Equal(t, "調査", rows[1].Title)
+	require.Len(t, rowErrors, 2)
+	assert.Equal(t, 2, rowErrors[0].Line)
+	assert.Equal(t, 4, rowErrors[1].Line)
+}
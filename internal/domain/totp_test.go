@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 のテストベクター（SHA1、下6桁）で検証
+func TestTOTPCode(t *testing.T) {
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range cases {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, unix)
+	}
+
+	_, err := TOTPCode("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	assert.Len(t, codes, RecoveryCodeCount)
+	assert.Len(t, codes[0], 11)
+	assert.NotEqual(t, codes[0], codes[1])
+
+	// 大文字・区切りなしでも同じコードとして扱う
+	assert.Equal(t, HashRecoveryCode("abcde-fghij"), HashRecoveryCode("ABCDEFGHIJ"))
+}
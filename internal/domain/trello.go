@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// TrelloBoard TrelloのボードのJSONエクスポート（メニュー →「印刷とエクスポート」→「JSONとしてエクスポート」）のうち、インポートに使用する項目
+type TrelloBoard struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Lists        []TrelloList        `json:"lists"`
+	Cards        []TrelloCard        `json:"cards"`
+	Labels       []TrelloLabel       `json:"labels"`
+	Checklists   []TrelloChecklist   `json:"checklists"`
+	Actions      []TrelloAction      `json:"actions"`
+	CustomFields []TrelloCustomField `json:"customFields"`
+}
+
+// TrelloList Trelloのリスト
+type TrelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"` // アーカイブ済み
+	Pos    float64 `json:"pos"`
+}
+
+// TrelloCard Trelloのカード
+type TrelloCard struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Desc         string     `json:"desc"`
+	IDList       string     `json:"idList"`
+	Closed       bool       `json:"closed"` // アーカイブ済み
+	Pos          float64    `json:"pos"`
+	Due          *time.Time `json:"due"`
+	DueComplete  bool       `json:"dueComplete"`
+	IDLabels     []string   `json:"idLabels"`
+	IDChecklists []string   `json:"idChecklists"`
+	IDMembers    []string   `json:"idMembers"`
+	Badges       struct {
+		Attachments int `json:"attachments"`
+	} `json:"badges"`
+}
+
+// TrelloLabel Trelloのラベル（名前が空で色だけのラベルもあります）
+type TrelloLabel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TrelloChecklist Trelloのチェックリスト
+type TrelloChecklist struct {
+	ID         string                `json:"id"`
+	IDCard     string                `json:"idCard"`
+	Name       string                `json:"name"`
+	Pos        float64               `json:"pos"`
+	CheckItems []TrelloChecklistItem `json:"checkItems"`
+}
+
+// TrelloChecklistItem Trelloのチェックリストの項目
+type TrelloChecklistItem struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"` // complete / incomplete
+	Pos   float64 `json:"pos"`
+}
+
+// TrelloAction Trelloのアクション（コメントなどの履歴）
+type TrelloAction struct {
+	Type string `json:"type"`
+	Data struct {
+		Card struct {
+			ID string `json:"id"`
+		} `json:"card"`
+	} `json:"data"`
+}
+
+// TrelloCustomField Trelloのカスタムフィールド（Power-Up）の定義
+type TrelloCustomField struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TrelloLabelColors Trelloのラベルの色名と、対応する色コード
+var TrelloLabelColors = map[string]string{
+	"green":  "#61BD4F",
+	"yellow": "#F2D600",
+	"orange": "#FF9F1A",
+	"red":    "#EB5A46",
+	"purple": "#C377E0",
+	"blue":   "#0079BF",
+	"sky":    "#00C2E0",
+	"lime":   "#51E898",
+	"pink":   "#FF78CB",
+	"black":  "#344563",
+}
@@ -1,6 +1,10 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,17 +14,92 @@ import (
 // User ユーザー情報を表すエンティティ
 // 認証とボードの所有者情報を管理します
 type User struct {
-	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Email        string         `json:"email" gorm:"uniqueIndex;not null" validate:"required,email"`
-	PasswordHash string         `json:"-" gorm:"not null"` // JSONでは出力しない（セキュリティ）
-	CreatedAt    time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt    time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Email           string         `json:"email" gorm:"uniqueIndex;not null" validate:"required,email"`
+	PasswordHash    string         `json:"-" gorm:"not null"` // JSONでは出力しない（セキュリティ）
+	DisplayName     string         `json:"display_name" gorm:"type:varchar(50)"`
+	AvatarURL       string         `json:"avatar_url,omitempty" gorm:"type:varchar(500)"`
+	TimeZone        string         `json:"time_zone" gorm:"type:varchar(64);not null;default:'UTC'"` // IANAのタイムゾーン名
+	Locale          string         `json:"locale" gorm:"type:varchar(35);not null;default:'ja'"`     // BCP 47の言語タグ
+	PendingEmail    string         `json:"pending_email,omitempty"`                                  // 変更の確認待ちのメールアドレス
+	EmailVerifiedAt *time.Time     `json:"email_verified_at,omitempty"`                              // メールアドレスを確認した日時（nilの場合は未確認）
+	TOTPSecret      string         `json:"-"`                                                        // 二要素認証（TOTP）のシークレット（設定中または有効）
+	TOTPEnabledAt   *time.Time     `json:"totp_enabled_at,omitempty"`                                // 二要素認証を有効にした日時（nilの場合は無効）
+	TOTPLastStep    int64          `json:"-"`                                                        // 最後に使用したコードの時間ステップ（同じコードの再利用を防ぐ）
+	RecoveryCodes   StringList     `json:"-" gorm:"type:jsonb"`                                      // 未使用のリカバリーコードのハッシュ
+	FailedLogins    int            `json:"-" gorm:"not null;default:0"`                              // 連続したログイン失敗の回数（成功で0に戻す）
+	LockedUntil     *time.Time     `json:"-"`                                                        // ログインをロックしている期限
+	TokenVersion    int            `json:"-" gorm:"not null;default:0"`                              // JWTの世代（パスワードの変更・再設定で増やし、以前のJWTを無効にする）
+	CreatedAt       time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"` // ソフトデリート対応
 
 	// リレーション：ユーザーが所有するボード一覧
 	Boards []Board `json:"boards,omitempty" gorm:"foreignKey:OwnerID"`
 }
 
+// プロフィールの既定値
+const (
+	DefaultUserTimeZone = "UTC"
+	DefaultUserLocale   = "ja"
+)
+
+// Name 表示名を返します（未設定の場合はメールアドレスの@より前）
+func (u *User) Name() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	name, _, _ := strings.Cut(u.Email, "@")
+	return name
+}
+
+// ValidateTimeZone IANAのタイムゾーン名として有効かどうかをチェックします
+func ValidateTimeZone(name string) error {
+	if name == "" || name == "Local" {
+		return fmt.Errorf("不正なタイムゾーンです: %s", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("不正なタイムゾーンです: %s", name)
+	}
+	return nil
+}
+
+// ValidateAvatarURL アバター画像のURL（http / https）として有効かどうかをチェックします
+func ValidateAvatarURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("アバターには http または https の URL を指定してください")
+	}
+	return nil
+}
+
+// TwoFactorEnabled 二要素認証が有効かどうかを判定します
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// IsLocked ログインがロックされているかどうかを判定します
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// DeletedUserDisplayName 削除したユーザーの表示名（タスクの履歴などに表示）
+const DeletedUserDisplayName = "削除されたユーザー"
+
+// Anonymize アカウントの削除のため、個人を特定できる情報と認証情報を消去します
+// 履歴の参照先として残すため、IDと作成日時のみ残します
+func (u *User) Anonymize() {
+	*u = User{
+		ID:          u.ID,
+		Email:       fmt.Sprintf("deleted-%s@deleted.invalid", u.ID), // メールアドレスの一意制約のため、IDから作成する
+		DisplayName: DeletedUserDisplayName,
+		TimeZone:    DefaultUserTimeZone,
+		Locale:      DefaultUserLocale,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+	}
+}
+
 // TableName テーブル名を明示的に指定
 func (User) TableName() string {
 	return "users"
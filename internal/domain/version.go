@@ -0,0 +1,21 @@
+package domain
+
+import "errors"
+
+// AnyVersion 更新・削除の際にバージョンを確認しないことを表す値（If-Match: * に相当）
+const AnyVersion = 0
+
+// ErrCodeVersionConflict 楽観的排他制御の競合を表すエラーコード
+const ErrCodeVersionConflict = "VERSION_CONFLICT"
+
+// ErrVersionConflict 更新・削除しようとしたデータが、既に他の操作によって変更されている場合のエラー
+var ErrVersionConflict = errors.New("データが他の操作によって更新されています。最新の状態を取得してから再度実行してください")
+
+// CheckVersion 期待するバージョンと現在のバージョンが一致するかをチェックします
+// expectedがAnyVersionの場合は常に一致するものとします
+func CheckVersion(expected, current int) error {
+	if expected != AnyVersion && expected != current {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// WebhookEvent Webhookで通知するイベントの種類
+type WebhookEvent string
+
+const (
+	WebhookEventTaskCreated    WebhookEvent = "task.created"    // タスク作成
+	WebhookEventTaskUpdated    WebhookEvent = "task.updated"    // タスク更新（ラベル・カスタムフィールドの変更を含む）
+	WebhookEventTaskMoved      WebhookEvent = "task.moved"      // タスクの移動（カラム・レーン・ボード）
+	WebhookEventTaskDeleted    WebhookEvent = "task.deleted"    // タスク削除
+	WebhookEventTaskRestored   WebhookEvent = "task.restored"   // ゴミ箱からのタスクの復元
+	WebhookEventColumnUpdated  WebhookEvent = "column.updated"  // カラム更新（WIP制限）
+	WebhookEventColumnRestored WebhookEvent = "column.restored" // ゴミ箱からのカラムの復元
+	WebhookEventBoardUpdated   WebhookEvent = "board.updated"   // ボード更新
+	WebhookEventBoardDeleted   WebhookEvent = "board.deleted"   // ボード削除
+	WebhookEventBoardRestored  WebhookEvent = "board.restored"  // ゴミ箱からのボードの復元
+	WebhookEventPing           WebhookEvent = "ping"            // 疎通確認（テスト送信）
+)
+
+// WebhookEvents 購読できるイベントの一覧
+var WebhookEvents = []WebhookEvent{
+	WebhookEventTaskCreated,
+	WebhookEventTaskUpdated,
+	WebhookEventTaskMoved,
+	WebhookEventTaskDeleted,
+	WebhookEventTaskRestored,
+	WebhookEventColumnUpdated,
+	WebhookEventColumnRestored,
+	WebhookEventBoardUpdated,
+	WebhookEventBoardDeleted,
+	WebhookEventBoardRestored,
+}
+
+// IsValid 購読できるイベントかどうかを判定します
+func (e WebhookEvent) IsValid() bool {
+	for _, event := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook ボードのイベントを外部のURLに通知する購読設定を表すエンティティ
+type Webhook struct {
+	ID        uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	BoardID   uint       `json:"board_id" gorm:"not null;index"`
+	URL       string     `json:"url" gorm:"not null"`
+	Secret    string     `json:"-" gorm:"not null"`                              // 署名用のシークレット（作成時のみレスポンスに含める）
+	Events    StringList `json:"events" gorm:"type:jsonb;not null;default:'[]'"` // 通知するイベント（空の場合はすべて）
+	Active    bool       `json:"active" gorm:"not null;default:true"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName テーブル名を明示的に指定
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Subscribes イベントを通知する対象かどうかを判定します
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	if event == WebhookEventPing || len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if WebhookEvent(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWebhookURL 通知先のURLをチェックします
+func ValidateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("通知先のURLは http または https の絶対URLで指定してください")
+	}
+	return nil
+}
+
+// ValidateWebhookEvents 購読するイベントをチェックします
+func ValidateWebhookEvents(events []string) error {
+	for _, event := range events {
+		if !WebhookEvent(event).IsValid() {
+			return fmt.Errorf("不正なイベントです: %s", event)
+		}
+	}
+	return nil
+}
+
+// WebhookDeliveryStatus Webhookの配信状態
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // 配信待ち（再試行待ちを含む）
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // 配信成功
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // 再試行の上限に達したため配信を断念
+)
+
+// 配信の再試行の設定
+const (
+	WebhookMaxAttempts      = 8                // 配信を試みる最大回数
+	WebhookInitialRetryWait = 30 * time.Second // 1回目の失敗後の待ち時間（失敗するたびに2倍）
+	WebhookMaxRetryWait     = 6 * time.Hour    // 待ち時間の上限
+)
+
+// WebhookDelivery Webhookの配信（配信キューと配信ログを兼ねる）を表すエンティティ
+// イベントごと・Webhookごとに1件作成し、配信の結果と再試行の予定を記録します
+type WebhookDelivery struct {
+	ID             uint                  `json:"id" gorm:"primaryKey;autoIncrement"`
+	WebhookID      uint                  `json:"webhook_id" gorm:"not null;index"`
+	Event          WebhookEvent          `json:"event" gorm:"type:varchar(32);not null"`
+	Payload        string                `json:"payload" gorm:"type:text;not null"` // 送信するJSON
+	Status         WebhookDeliveryStatus `json:"status" gorm:"type:varchar(16);not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int                   `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" gorm:"not null;index:idx_webhook_deliveries_due,priority:2"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int                  `json:"response_status,omitempty"` // 最後の配信のHTTPステータス
+	LastError      string                `json:"last_error,omitempty" gorm:"type:text"`
+	CreatedAt      time.Time             `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt      time.Time             `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName テーブル名を明示的に指定
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// RecordAttempt 配信の結果を記録し、失敗した場合は次の再試行の予定を決めます
+// errorMessageが空の場合は成功として扱います
+func (d *WebhookDelivery) RecordAttempt(at time.Time, responseStatus *int, errorMessage string) {
+	d.Attempts++
+	d.LastAttemptAt = &at
+	d.ResponseStatus = responseStatus
+	d.LastError = errorMessage
+
+	switch {
+	case errorMessage == "":
+		d.Status = WebhookDeliverySucceeded
+	case d.Attempts >= WebhookMaxAttempts:
+		d.Status = WebhookDeliveryFailed
+	default:
+		d.Status = WebhookDeliveryPending
+		d.NextAttemptAt = at.Add(WebhookRetryWait(d.Attempts))
+	}
+}
+
+// WebhookRetryWait attempts回失敗した後、次に配信を試みるまでの待ち時間を返します（指数バックオフ）
+func WebhookRetryWait(attempts int) time.Duration {
+	wait := WebhookInitialRetryWait
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= WebhookMaxRetryWait {
+			return WebhookMaxRetryWait
+		}
+	}
+	return wait
+}
+
+// WebhookPayload Webhookで送信するJSONの内容
+type WebhookPayload struct {
+	Event      WebhookEvent `json:"event"`
+	BoardID    uint         `json:"board_id"`
+	OccurredAt time.Time    `json:"occurred_at"`
+	Data       interface{}  `json:"data"`
+}
+
+// SignWebhookPayload 送信するJSONのHMAC-SHA256署名を「sha256=<16進数>」の形式で返します
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceRole ワークスペースのメンバーの役割
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"  // ワークスペースの設定・メンバーの管理ができる
+	WorkspaceRoleMember WorkspaceRole = "member" // ワークスペースのボードを利用できる
+)
+
+// IsValid 有効な役割かどうかを判定します
+func (r WorkspaceRole) IsValid() bool {
+	return r == WorkspaceRoleAdmin || r == WorkspaceRoleMember
+}
+
+// PersonalWorkspaceName 個人用ワークスペースの名前
+const PersonalWorkspaceName = "個人用"
+
+// Workspace ボードを所有し、メンバーで共有するワークスペースを表すエンティティ
+// ユーザーごとに1つ、本人のみがメンバーの個人用ワークスペースがあります
+type Workspace struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null"`
+	Personal  bool      `json:"personal" gorm:"not null;default:false"` // 個人用ワークスペース（メンバーの追加・削除はできない）
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// リレーション：このワークスペースのメンバー一覧
+	Members []WorkspaceMember `json:"members,omitempty" gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName テーブル名を明示的に指定
+func (Workspace) TableName() string {
+	return "workspaces"
+}
+
+// WorkspaceMember ワークスペースのメンバーと役割を表すエンティティ
+type WorkspaceMember struct {
+	WorkspaceID uint          `json:"workspace_id" gorm:"primaryKey"`
+	UserID      uuid.UUID     `json:"user_id" gorm:"type:uuid;primaryKey;index"`
+	Role        WorkspaceRole `json:"role" gorm:"type:varchar(16);not null"`
+	CreatedAt   time.Time     `json:"created_at" gorm:"autoCreateTime"`
+
+	// リレーション：メンバーのユーザー（表示名の表示に使用）
+	User *User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TableName テーブル名を明示的に指定
+func (WorkspaceMember) TableName() string {
+	return "workspace_members"
+}
+
+// NewPersonalWorkspace ユーザーの個人用ワークスペースを作成します（ユーザーは管理者）
+func NewPersonalWorkspace(userID uuid.UUID) *Workspace {
+	return &Workspace{
+		Name:     PersonalWorkspaceName,
+		Personal: true,
+		Members:  []WorkspaceMember{{UserID: userID, Role: WorkspaceRoleAdmin}},
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// AccountHandler アカウントのデータのエクスポート・削除関連のHTTPハンドラ
+type AccountHandler struct {
+	accountService service.AccountService
+	validator      *validator.Validate
+}
+
+// NewAccountHandler AccountHandlerの新しいインスタンスを作成
+func NewAccountHandler(accountService service.AccountService) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		validator:      validator.New(),
+	}
+}
+
+// DeleteAccountRequest アカウント削除リクエスト構造体
+type DeleteAccountRequest struct {
+	Password         string `json:"password" validate:"required"`
+	TransferBoardsTo string `json:"transfer_boards_to" validate:"omitempty,email"` // 省略時は所有するボードを削除
+}
+
+// ExportAccount ユーザーが所有するデータをZIPアーカイブとしてエクスポートするハンドラ
+// GET /api/v1/account/export
+func (h *AccountHandler) ExportAccount(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	export, err := h.accountService.ExportAccount(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// 途中で失敗した場合にエラーを返せるよう、アーカイブ全体を作成してから送信する
+	var buf bytes.Buffer
+	if err := export.WriteArchive(&buf); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "エクスポートファイルの作成に失敗しました",
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("simple-kanban-export-%s.zip", export.ExportedAt.Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// DeleteAccount アカウント削除ハンドラ
+// DELETE /api/v1/account
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	var req DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.accountService.DeleteAccount(userID, req.Password, req.TransferBoardsTo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
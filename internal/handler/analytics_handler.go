@@ -72,7 +72,8 @@ func (h *AnalyticsHandler) getTaskCompletionByDate(userID interface{}, startDate
 		Select("DATE(t.updated_at) as date, COUNT(*) as count").
 		Joins("JOIN columns c ON c.id = t.column_id").
 		Joins("JOIN boards b ON b.id = c.board_id").
-		Where("b.owner_id = ? AND t.is_completed = ? AND t.updated_at >= ? AND t.updated_at < ?", userID, true, startDate, endDate).
+		Where("b.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?)", userID).
+		Where("t.is_completed = ? AND t.updated_at >= ? AND t.updated_at < ?", true, startDate, endDate).
 		Group("DATE(t.updated_at)").
 		Order("DATE(t.updated_at)")
 	if err := q.Scan(&rows).Error; err != nil {
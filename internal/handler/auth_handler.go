@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
+	"simple-kanban/internal/domain"
 	"simple-kanban/internal/service"
 	"simple-kanban/pkg/middleware"
 
@@ -36,6 +40,42 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// PasswordResetRequest パスワード再設定の申請リクエスト構造体
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// PasswordResetConfirmRequest パスワード再設定リクエスト構造体
+type PasswordResetConfirmRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// VerifyEmailRequest メールアドレス確認リクエスト構造体
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// UpdateProfileRequest プロフィール更新リクエスト構造体（指定した項目のみ更新）
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name" validate:"omitempty,max=50"`
+	AvatarURL   *string `json:"avatar_url" validate:"omitempty,max=500"` // 空文字で削除
+	TimeZone    *string `json:"time_zone" validate:"omitempty,max=64"`   // IANAのタイムゾーン名（例: Asia/Tokyo）
+	Locale      *string `json:"locale" validate:"omitempty,bcp47_language_tag"`
+}
+
+// ChangePasswordRequest パスワード変更リクエスト構造体
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+}
+
+// ChangeEmailRequest メールアドレス変更リクエスト構造体
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
 // AuthResponse 認証レスポンス構造体
 type AuthResponse struct {
 	User  UserResponse `json:"user"`
@@ -44,8 +84,38 @@ type AuthResponse struct {
 
 // UserResponse ユーザー情報レスポンス構造体
 type UserResponse struct {
-	ID    string `json:"id"`
-	Email string `json:"email"`
+	ID               string `json:"id"`
+	Email            string `json:"email"`
+	DisplayName      string `json:"display_name"` // 未設定の場合はメールアドレスの@より前
+	AvatarURL        string `json:"avatar_url,omitempty"`
+	TimeZone         string `json:"time_zone,omitempty"` // 以下は本人の情報の場合のみ含める
+	Locale           string `json:"locale,omitempty"`
+	PendingEmail     string `json:"pending_email,omitempty"`
+	EmailVerified    *bool  `json:"email_verified,omitempty"`
+	TwoFactorEnabled *bool  `json:"two_factor_enabled,omitempty"`
+}
+
+// newPublicUserResponse ほかのユーザーにも表示するユーザー情報（担当者・所有者など）のレスポンスを作成します
+func newPublicUserResponse(user *domain.User) UserResponse {
+	return UserResponse{
+		ID:          user.ID.String(),
+		Email:       user.Email,
+		DisplayName: user.Name(),
+		AvatarURL:   user.AvatarURL,
+	}
+}
+
+// newUserResponse 本人のユーザー情報のレスポンスを作成します
+func newUserResponse(user *domain.User) UserResponse {
+	verified := user.EmailVerifiedAt != nil
+	twoFactor := user.TwoFactorEnabled()
+	response := newPublicUserResponse(user)
+	response.TimeZone = user.TimeZone
+	response.Locale = user.Locale
+	response.PendingEmail = user.PendingEmail
+	response.EmailVerified = &verified
+	response.TwoFactorEnabled = &twoFactor
+	return response
 }
 
 // Register ユーザー登録ハンドラ
@@ -81,10 +151,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	// レスポンスを返す
 	response := AuthResponse{
-		User: UserResponse{
-			ID:    user.ID.String(),
-			Email: user.Email,
-		},
+		User:  newUserResponse(user),
 		Token: token,
 	}
 
@@ -115,6 +182,19 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// ログイン処理
 	user, token, err := h.userService.Login(req.Email, req.Password)
+	var challenge *service.TwoFactorRequiredError
+	if errors.As(err, &challenge) {
+		// 二要素認証のコードを /auth/2fa/verify で送信するとログインが完了する
+		c.JSON(http.StatusOK, gin.H{
+			"two_factor_required": true,
+			"challenge_token":     challenge.ChallengeToken,
+			"expires_in":          int(service.TwoFactorChallengeTTL.Seconds()),
+		})
+		return
+	}
+	if writeAccountLocked(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": err.Error(),
@@ -124,10 +204,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// レスポンスを返す
 	response := AuthResponse{
-		User: UserResponse{
-			ID:    user.ID.String(),
-			Email: user.Email,
-		},
+		User:  newUserResponse(user),
 		Token: token,
 	}
 
@@ -156,12 +233,277 @@ func (h *AuthHandler) Profile(c *gin.Context) {
 	}
 
 	// レスポンスを返す
-	response := UserResponse{
-		ID:    user.ID.String(),
-		Email: user.Email,
-	}
+	response := newUserResponse(user)
 
 	c.JSON(http.StatusOK, gin.H{
 		"user": response,
 	})
 }
+
+// UpdateProfile プロフィール更新ハンドラ
+// PUT /api/v1/auth/profile
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.DisplayName != nil {
+		updates["display_name"] = *req.DisplayName
+	}
+	if req.AvatarURL != nil {
+		updates["avatar_url"] = *req.AvatarURL
+	}
+	if req.TimeZone != nil {
+		updates["time_zone"] = *req.TimeZone
+	}
+	if req.Locale != nil {
+		updates["locale"] = *req.Locale
+	}
+
+	user, err := h.userService.UpdateProfile(userID, updates)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": newUserResponse(user),
+	})
+}
+
+// ChangePassword パスワード変更ハンドラ
+// PUT /api/v1/auth/password
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	token, err := h.userService.ChangePassword(userID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// 変更前に発行したトークンは使用できなくなるため、新しいトークンを返す
+	c.JSON(http.StatusOK, gin.H{
+		"message": "パスワードを変更しました",
+		"token":   token,
+	})
+}
+
+// ChangeEmail メールアドレス変更ハンドラ（新しいメールアドレスの確認後に変更される）
+// PUT /api/v1/auth/email
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	var req ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.userService.ChangeEmail(userID, req.Email, req.Password); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "新しいメールアドレスに確認メールを送信しました。確認後にメールアドレスが変更されます",
+	})
+}
+
+// RequestPasswordReset パスワード再設定の申請ハンドラ
+// POST /api/v1/auth/password-reset
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.userService.RequestPasswordReset(req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// 登録されているかどうかにかかわらず同じレスポンスを返す
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "登録されているメールアドレスの場合、パスワード再設定のメールを送信しました",
+	})
+}
+
+// ResetPassword パスワード再設定ハンドラ
+// POST /api/v1/auth/password-reset/confirm
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req PasswordResetConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.userService.ResetPassword(req.Token, req.Password); err != nil {
+		c.JSON(accountTokenErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "パスワードを再設定しました",
+	})
+}
+
+// VerifyEmail メールアドレス確認ハンドラ
+// POST /api/v1/auth/verify-email
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.VerifyEmail(req.Token)
+	if err != nil {
+		c.JSON(accountTokenErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": newUserResponse(user),
+	})
+}
+
+// ResendVerificationEmail メールアドレス確認メールの再送ハンドラ
+// POST /api/v1/auth/verify-email/resend
+func (h *AuthHandler) ResendVerificationEmail(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	if err := h.userService.ResendVerificationEmail(userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "確認メールを送信しました",
+	})
+}
+
+// writeAccountLocked アカウントがロックされている場合に429とRetry-Afterヘッダーを返します
+func writeAccountLocked(c *gin.Context, err error) bool {
+	var locked *service.AccountLockedError
+	if !errors.As(err, &locked) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error": err.Error(),
+	})
+	return true
+}
+
+// accountTokenErrorStatus トークンの使用時のエラーに対応するステータスコードを返します
+func accountTokenErrorStatus(err error) int {
+	if errors.Is(err, service.ErrInvalidAccountToken) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// AutomationHandler 自動化ルール関連のHTTPハンドラ
+type AutomationHandler struct {
+	automationService service.AutomationService
+	validator         *validator.Validate
+}
+
+// NewAutomationHandler AutomationHandlerの新しいインスタンスを作成
+func NewAutomationHandler(automationService service.AutomationService) *AutomationHandler {
+	return &AutomationHandler{
+		automationService: automationService,
+		validator:         validator.New(),
+	}
+}
+
+// AutomationRuleRequest 自動化ルールの作成・更新リクエスト構造体
+type AutomationRuleRequest struct {
+	Name            string                       `json:"name" validate:"required,min=1,max=100"`
+	Enabled         *bool                        `json:"enabled"` // 省略時は有効
+	Trigger         domain.AutomationTrigger     `json:"trigger" validate:"required"`
+	TriggerColumnID *uint                        `json:"trigger_column_id"`
+	Conditions      []domain.AutomationCondition `json:"conditions"`
+	Actions         []domain.AutomationAction    `json:"actions" validate:"required,min=1"`
+}
+
+// toRule リクエストを自動化ルールに変換します
+func (r *AutomationRuleRequest) toRule() *domain.AutomationRule {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+	conditions := domain.AutomationConditions(r.Conditions)
+	if conditions == nil {
+		conditions = domain.AutomationConditions{}
+	}
+	return &domain.AutomationRule{
+		Name:            r.Name,
+		Enabled:         enabled,
+		Trigger:         r.Trigger,
+		TriggerColumnID: r.TriggerColumnID,
+		Conditions:      conditions,
+		Actions:         domain.AutomationActions(r.Actions),
+	}
+}
+
+// CreateRule 自動化ルール作成ハンドラ
+// POST /api/v1/boards/:id/automation-rules
+func (h *AutomationHandler) CreateRule(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	var req AutomationRuleRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.automationService.CreateRule(uint(boardID), userID, req.toRule())
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"rule": rule,
+	})
+}
+
+// GetBoardRules ボードの自動化ルール一覧取得ハンドラ
+// GET /api/v1/boards/:id/automation-rules
+func (h *AutomationHandler) GetBoardRules(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	rules, err := h.automationService.GetBoardRules(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if rules == nil {
+		rules = []domain.AutomationRule{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+	})
+}
+
+// UpdateRule 自動化ルール更新ハンドラ（内容をリクエストで置き換える）
+// PUT /api/v1/automation-rules/:id
+func (h *AutomationHandler) UpdateRule(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからルールIDを取得
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なルールIDです",
+		})
+		return
+	}
+
+	var req AutomationRuleRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.automationService.UpdateRule(uint(ruleID), userID, req.toRule())
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rule": rule,
+	})
+}
+
+// DeleteRule 自動化ルール削除ハンドラ
+// DELETE /api/v1/automation-rules/:id
+func (h *AutomationHandler) DeleteRule(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからルールIDを取得
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なルールIDです",
+		})
+		return
+	}
+
+	if err := h.automationService.DeleteRule(uint(ruleID), userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetExecutions 自動化ルールの実行ログ取得ハンドラ
+// GET /api/v1/boards/:id/automation-executions
+func (h *AutomationHandler) GetExecutions(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	executions, err := h.automationService.GetExecutions(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if executions == nil {
+		executions = []domain.AutomationExecution{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"executions": executions,
+	})
+}
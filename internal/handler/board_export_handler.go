@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BoardExportHandler ボードのエクスポート・インポート関連のHTTPハンドラ
+type BoardExportHandler struct {
+	exportService service.BoardExportService
+	trelloService service.TrelloImportService
+}
+
+// NewBoardExportHandler BoardExportHandlerの新しいインスタンスを作成
+func NewBoardExportHandler(exportService service.BoardExportService, trelloService service.TrelloImportService) *BoardExportHandler {
+	return &BoardExportHandler{
+		exportService: exportService,
+		trelloService: trelloService,
+	}
+}
+
+// ExportBoard ボードをJSONファイルとしてエクスポートするハンドラ
+// GET /api/v1/boards/:id/export
+func (h *BoardExportHandler) ExportBoard(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	export, err := h.exportService.ExportBoard(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"board-%d.json\"", boardID))
+	c.JSON(http.StatusOK, export)
+}
+
+// ImportBoard エクスポートしたJSONファイルからボードを作成するハンドラ
+// POST /api/v1/boards/import
+func (h *BoardExportHandler) ImportBoard(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// 形式の誤りに気付けるよう、未知の項目を含むファイルは受け付けない
+	var export domain.BoardExport
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&export); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "不正なリクエスト形式です",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// スキーマのバリデーション
+	if err := export.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.exportService.ImportBoard(userID, &export)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"board":               newBoardResponse(result.Board),
+		"unmatched_assignees": result.UnmatchedAssignees,
+	})
+}
+
+// ImportTrelloBoard TrelloのボードのJSONエクスポートからボードを作成するハンドラ
+// POST /api/v1/boards/import/trello
+func (h *BoardExportHandler) ImportTrelloBoard(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// TrelloのエクスポートにはインポートしないTrello固有の項目が多数含まれるため、未知の項目は無視する
+	var board domain.TrelloBoard
+	if err := c.ShouldBindJSON(&board); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "不正なリクエスト形式です",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(board.Lists) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": "Trelloのボードにリストがありません",
+		})
+		return
+	}
+
+	report, err := h.trelloService.ImportTrelloBoard(userID, &board)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"board": newBoardResponse(report.Board),
+		"report": gin.H{
+			"columns":          report.Columns,
+			"tasks":            report.Tasks,
+			"labels":           report.Labels,
+			"checklists":       report.Checklists,
+			"archived_columns": report.ArchivedColumns,
+			"archived_tasks":   report.ArchivedTasks,
+			"unmapped":         report.Unmapped,
+		},
+	})
+}
@@ -5,11 +5,13 @@ import (
 	"strconv"
 	"time"
 
+	"simple-kanban/internal/domain"
 	"simple-kanban/internal/service"
 	"simple-kanban/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 // BoardHandler ボード関連のHTTPハンドラ
@@ -28,50 +30,154 @@ func NewBoardHandler(boardService service.BoardService) *BoardHandler {
 
 // CreateBoardRequest ボード作成リクエスト構造体
 type CreateBoardRequest struct {
-	Name string `json:"name" validate:"required,min=1,max=100"`
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	TemplateID  string `json:"template_id"`  // 作成に使用するテンプレート（省略時は To Do / In Progress / Done）
+	WorkspaceID uint   `json:"workspace_id"` // 作成先のワークスペース（省略時は個人用ワークスペース）
 }
 
 // UpdateBoardRequest ボード更新リクエスト構造体
 type UpdateBoardRequest struct {
-	Name string `json:"name" validate:"required,min=1,max=100"`
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	WorkspaceID *uint  `json:"workspace_id"` // 指定した場合はボードをそのワークスペースに移動
+}
+
+// CloneBoardRequest ボード複製リクエスト構造体
+type CloneBoardRequest struct {
+	Name              string `json:"name" validate:"omitempty,max=100"`                  // 複製先のボード名（省略時は「<元の名前> のコピー」）
+	IncludeCompleted  bool   `json:"include_completed"`                                  // 完了済みのタスクも複製するか
+	IncludeAssignees  bool   `json:"include_assignees"`                                  // 担当者を引き継ぐか
+	IncludeDueDates   bool   `json:"include_due_dates"`                                  // 期限を引き継ぐか
+	DueDateOffsetDays int    `json:"due_date_offset_days" validate:"min=-3650,max=3650"` // 引き継ぐ期限をずらす日数
 }
 
 // BoardResponse ボード情報レスポンス構造体
 type BoardResponse struct {
-	ID        uint             `json:"id"`
-	Name      string           `json:"name"`
-	OwnerID   string           `json:"owner_id"`
-	CreatedAt time.Time        `json:"created_at"`
-	UpdatedAt time.Time        `json:"updated_at"`
-	Columns   []ColumnResponse `json:"columns,omitempty"`
+	ID          uint             `json:"id"`
+	Name        string           `json:"name"`
+	WorkspaceID uint             `json:"workspace_id"`
+	OwnerID     string           `json:"owner_id"`
+	Owner       *UserResponse    `json:"owner,omitempty"` // 作成したユーザー（表示名）
+	Version     int              `json:"version"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	Columns     []ColumnResponse `json:"columns,omitempty"`
+}
+
+// newBoardResponse ボードのレスポンスを作成します（作成したユーザーを読み込んでいる場合は表示名も含める）
+func newBoardResponse(board *domain.Board) BoardResponse {
+	response := BoardResponse{
+		ID:          board.ID,
+		Name:        board.Name,
+		WorkspaceID: board.WorkspaceID,
+		OwnerID:     board.OwnerID.String(),
+		Version:     board.Version,
+		CreatedAt:   board.CreatedAt,
+		UpdatedAt:   board.UpdatedAt,
+	}
+	if board.Owner.ID != uuid.Nil {
+		owner := newPublicUserResponse(&board.Owner)
+		response.Owner = &owner
+	}
+	return response
 }
 
 // ColumnResponse カラム情報レスポンス構造体
 type ColumnResponse struct {
-	ID    uint           `json:"id"`
-	Title string         `json:"title"`
-	Order int            `json:"order"`
-	Tasks []TaskResponse `json:"tasks,omitempty"`
+	ID           uint           `json:"id"`
+	Title        string         `json:"title"`
+	Order        int            `json:"order"`
+	WIPLimit     *int           `json:"wip_limit"`
+	WIPLimitMode string         `json:"wip_limit_mode"`
+	TaskCount    int            `json:"task_count"`
+	Version      int            `json:"version"`
+	Tasks        []TaskResponse `json:"tasks,omitempty"`
 }
 
 // TaskResponse タスク情報レスポンス構造体
 type TaskResponse struct {
-	ID             uint          `json:"id"`
-	ColumnID       uint          `json:"column_id"`
-	Title          string        `json:"title"`
-	Description    string        `json:"description"`
-	Order          int           `json:"order"`
-	AssigneeID     *string       `json:"assignee_id"`
-	Assignee       *UserResponse `json:"assignee,omitempty"`
-	DueDate        *time.Time    `json:"due_date"`
-	EstimatedTime  *int          `json:"estimated_time,omitempty"`
-	ActualTime     *int          `json:"actual_time,omitempty"`
-	IsCompleted    bool          `json:"is_completed"`
-	ScheduledStart *time.Time    `json:"scheduled_start,omitempty"`
-	ScheduledEnd   *time.Time    `json:"scheduled_end,omitempty"`
-	CalendarDate   *time.Time    `json:"calendar_date,omitempty"`
-	CreatedAt      time.Time     `json:"created_at"`
-	UpdatedAt      time.Time     `json:"updated_at"`
+	ID             uint                       `json:"id"`
+	ColumnID       uint                       `json:"column_id"`
+	LaneID         *uint                      `json:"lane_id"`
+	Title          string                     `json:"title"`
+	Description    string                     `json:"description"`
+	Order          int                        `json:"order"`
+	AssigneeID     *string                    `json:"assignee_id"`
+	Assignee       *UserResponse              `json:"assignee,omitempty"`
+	DueDate        *time.Time                 `json:"due_date"`
+	EstimatedTime  *int                       `json:"estimated_time,omitempty"`
+	ActualTime     *int                       `json:"actual_time,omitempty"`
+	IsCompleted    bool                       `json:"is_completed"`
+	ScheduledStart *time.Time                 `json:"scheduled_start,omitempty"`
+	ScheduledEnd   *time.Time                 `json:"scheduled_end,omitempty"`
+	CalendarDate   *time.Time                 `json:"calendar_date,omitempty"`
+	Priority       string                     `json:"priority"`
+	Version        int                        `json:"version"`
+	Labels         []LabelResponse            `json:"labels,omitempty"`
+	CustomFields   []CustomFieldValueResponse `json:"custom_fields,omitempty"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+}
+
+// newColumnResponse カラムからレスポンスを構築します（タスク数はプリロードされたタスクから数えます）
+func newColumnResponse(column *domain.Column) ColumnResponse {
+	response := ColumnResponse{
+		ID:           column.ID,
+		Title:        column.Title,
+		Order:        column.Order,
+		WIPLimit:     column.WIPLimit,
+		WIPLimitMode: string(column.WIPLimitMode),
+		TaskCount:    len(column.Tasks),
+		Version:      column.Version,
+	}
+	for i := range column.Tasks {
+		response.Tasks = append(response.Tasks, newTaskResponse(&column.Tasks[i]))
+	}
+	return response
+}
+
+// newTaskResponse タスクエンティティからレスポンスを構築します
+func newTaskResponse(task *domain.Task) TaskResponse {
+	response := TaskResponse{
+		ID:             task.ID,
+		ColumnID:       task.ColumnID,
+		LaneID:         task.LaneID,
+		Title:          task.Title,
+		Description:    task.Description,
+		Order:          task.Order,
+		DueDate:        task.DueDate,
+		EstimatedTime:  task.EstimatedTime,
+		ActualTime:     task.ActualTime,
+		IsCompleted:    task.IsCompleted,
+		ScheduledStart: task.ScheduledStart,
+		ScheduledEnd:   task.ScheduledEnd,
+		CalendarDate:   task.CalendarDate,
+		Priority:       string(task.Priority),
+		Version:        task.Version,
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+	}
+
+	// 担当者情報が存在する場合は追加
+	if task.AssigneeID != nil {
+		assigneeIDStr := task.AssigneeID.String()
+		response.AssigneeID = &assigneeIDStr
+	}
+	if task.Assignee != nil {
+		assignee := newPublicUserResponse(task.Assignee)
+		response.Assignee = &assignee
+	}
+
+	// ラベルを追加
+	for _, label := range task.Labels {
+		response.Labels = append(response.Labels, newLabelResponse(&label))
+	}
+
+	// カスタムフィールド値を追加
+	for _, value := range task.CustomFieldValues {
+		response.CustomFields = append(response.CustomFields, newCustomFieldValueResponse(&value))
+	}
+
+	return response
 }
 
 // CreateBoard ボード作成ハンドラ
@@ -106,7 +212,7 @@ func (h *BoardHandler) CreateBoard(c *gin.Context) {
 	}
 
 	// ボード作成処理
-	board, err := h.boardService.CreateBoard(userID, req.Name)
+	board, err := h.boardService.CreateBoard(userID, req.WorkspaceID, req.Name, req.TemplateID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -115,13 +221,7 @@ func (h *BoardHandler) CreateBoard(c *gin.Context) {
 	}
 
 	// レスポンスを返す
-	response := BoardResponse{
-		ID:        board.ID,
-		Name:      board.Name,
-		OwnerID:   board.OwnerID.String(),
-		CreatedAt: board.CreatedAt,
-		UpdatedAt: board.UpdatedAt,
-	}
+	response := newBoardResponse(board)
 
 	c.JSON(http.StatusCreated, gin.H{
 		"board": response,
@@ -129,7 +229,7 @@ func (h *BoardHandler) CreateBoard(c *gin.Context) {
 }
 
 // GetUserBoards ユーザーのボード一覧取得ハンドラ
-// GET /api/v1/boards
+// GET /api/v1/boards?workspace_id=1
 func (h *BoardHandler) GetUserBoards(c *gin.Context) {
 	// JWT認証ミドルウェアからユーザーIDを取得
 	userID, err := middleware.GetUserIDFromContext(c)
@@ -140,8 +240,14 @@ func (h *BoardHandler) GetUserBoards(c *gin.Context) {
 		return
 	}
 
+	// ワークスペースで絞り込む場合はクエリパラメータから取得
+	workspaceID, ok := parseWorkspaceQuery(c)
+	if !ok {
+		return
+	}
+
 	// ユーザーのボード一覧を取得
-	boards, err := h.boardService.GetUserBoards(userID)
+	boards, err := h.boardService.GetUserBoards(userID, workspaceID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -152,13 +258,7 @@ func (h *BoardHandler) GetUserBoards(c *gin.Context) {
 	// レスポンスを構築
 	var response []BoardResponse
 	for _, board := range boards {
-		response = append(response, BoardResponse{
-			ID:        board.ID,
-			Name:      board.Name,
-			OwnerID:   board.OwnerID.String(),
-			CreatedAt: board.CreatedAt,
-			UpdatedAt: board.UpdatedAt,
-		})
+		response = append(response, newBoardResponse(&board))
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -199,58 +299,14 @@ func (h *BoardHandler) GetBoardWithColumns(c *gin.Context) {
 
 	// レスポンスを構築
 	var columns []ColumnResponse
-	for _, column := range board.Columns {
-		var tasks []TaskResponse
-		for _, task := range column.Tasks {
-			taskResponse := TaskResponse{
-				ID:             task.ID,
-				ColumnID:       task.ColumnID,
-				Title:          task.Title,
-				Description:    task.Description,
-				Order:          task.Order,
-				DueDate:        task.DueDate,
-				EstimatedTime:  task.EstimatedTime,
-				ActualTime:     task.ActualTime,
-				IsCompleted:    task.IsCompleted,
-				ScheduledStart: task.ScheduledStart,
-				ScheduledEnd:   task.ScheduledEnd,
-				CalendarDate:   task.CalendarDate,
-				CreatedAt:      task.CreatedAt,
-				UpdatedAt:      task.UpdatedAt,
-			}
-
-			// 担当者情報が存在する場合は追加
-			if task.AssigneeID != nil {
-				assigneeIDStr := task.AssigneeID.String()
-				taskResponse.AssigneeID = &assigneeIDStr
-			}
-			if task.Assignee != nil {
-				taskResponse.Assignee = &UserResponse{
-					ID:    task.Assignee.ID.String(),
-					Email: task.Assignee.Email,
-				}
-			}
-
-			tasks = append(tasks, taskResponse)
-		}
-
-		columns = append(columns, ColumnResponse{
-			ID:    column.ID,
-			Title: column.Title,
-			Order: column.Order,
-			Tasks: tasks,
-		})
+	for i := range board.Columns {
+		columns = append(columns, newColumnResponse(&board.Columns[i]))
 	}
 
-	response := BoardResponse{
-		ID:        board.ID,
-		Name:      board.Name,
-		OwnerID:   board.OwnerID.String(),
-		CreatedAt: board.CreatedAt,
-		UpdatedAt: board.UpdatedAt,
-		Columns:   columns,
-	}
+	response := newBoardResponse(board)
+	response.Columns = columns
 
+	setETag(c, board.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"board": response,
 	})
@@ -278,6 +334,12 @@ func (h *BoardHandler) UpdateBoard(c *gin.Context) {
 		return
 	}
 
+	// 前提とするバージョンをIf-Matchヘッダーから取得
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var req UpdateBoardRequest
 
 	// リクエストボディをバインド
@@ -301,8 +363,14 @@ func (h *BoardHandler) UpdateBoard(c *gin.Context) {
 	updates := map[string]interface{}{
 		"name": req.Name,
 	}
-	board, err := h.boardService.UpdateBoard(uint(boardID), userID, updates)
+	if req.WorkspaceID != nil {
+		updates["workspace_id"] = *req.WorkspaceID
+	}
+	board, err := h.boardService.UpdateBoard(uint(boardID), userID, version, updates)
 	if err != nil {
+		if respondVersionConflict(c, err, h.currentBoard(uint(boardID), userID)) {
+			return
+		}
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": err.Error(),
 		})
@@ -310,14 +378,9 @@ func (h *BoardHandler) UpdateBoard(c *gin.Context) {
 	}
 
 	// レスポンスを返す
-	response := BoardResponse{
-		ID:        board.ID,
-		Name:      board.Name,
-		OwnerID:   board.OwnerID.String(),
-		CreatedAt: board.CreatedAt,
-		UpdatedAt: board.UpdatedAt,
-	}
+	response := newBoardResponse(board)
 
+	setETag(c, board.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"board": response,
 	})
@@ -345,8 +408,17 @@ func (h *BoardHandler) DeleteBoard(c *gin.Context) {
 		return
 	}
 
+	// 前提とするバージョンをIf-Matchヘッダーから取得
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// ボード削除処理
-	if err := h.boardService.DeleteBoard(uint(boardID), userID); err != nil {
+	if err := h.boardService.DeleteBoard(uint(boardID), userID, version); err != nil {
+		if respondVersionConflict(c, err, h.currentBoard(uint(boardID), userID)) {
+			return
+		}
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": err.Error(),
 		})
@@ -357,7 +429,7 @@ func (h *BoardHandler) DeleteBoard(c *gin.Context) {
 }
 
 // GetUserBoardsWithColumns ユーザーのボード一覧をカラム・タスク情報付きで取得
-// GET /api/v1/boards/with-columns
+// GET /api/v1/boards/with-columns?workspace_id=1
 func (h *BoardHandler) GetUserBoardsWithColumns(c *gin.Context) {
 	// JWT認証ミドルウェアからユーザーIDを取得
 	userID, err := middleware.GetUserIDFromContext(c)
@@ -368,8 +440,14 @@ func (h *BoardHandler) GetUserBoardsWithColumns(c *gin.Context) {
 		return
 	}
 
+	// ワークスペースで絞り込む場合はクエリパラメータから取得
+	workspaceID, ok := parseWorkspaceQuery(c)
+	if !ok {
+		return
+	}
+
 	// ユーザーのボード一覧を取得
-	boards, err := h.boardService.GetUserBoards(userID)
+	boards, err := h.boardService.GetUserBoards(userID, workspaceID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -384,72 +462,193 @@ func (h *BoardHandler) GetUserBoardsWithColumns(c *gin.Context) {
 		boardWithColumns, err := h.boardService.GetBoardWithColumns(board.ID, userID)
 		if err != nil {
 			// エラーが発生した場合は、カラム情報なしでボード情報のみを追加
-			response = append(response, BoardResponse{
-				ID:        board.ID,
-				Name:      board.Name,
-				OwnerID:   board.OwnerID.String(),
-				CreatedAt: board.CreatedAt,
-				UpdatedAt: board.UpdatedAt,
-			})
+			response = append(response, newBoardResponse(&board))
 			continue
 		}
 
 		// カラム情報を構築
 		var columns []ColumnResponse
-		for _, column := range boardWithColumns.Columns {
-			var tasks []TaskResponse
-			for _, task := range column.Tasks {
-				taskResponse := TaskResponse{
-					ID:             task.ID,
-					ColumnID:       task.ColumnID,
-					Title:          task.Title,
-					Description:    task.Description,
-					Order:          task.Order,
-					DueDate:        task.DueDate,
-					EstimatedTime:  task.EstimatedTime,
-					ActualTime:     task.ActualTime,
-					IsCompleted:    task.IsCompleted,
-					ScheduledStart: task.ScheduledStart,
-					ScheduledEnd:   task.ScheduledEnd,
-					CalendarDate:   task.CalendarDate,
-					CreatedAt:      task.CreatedAt,
-					UpdatedAt:      task.UpdatedAt,
-				}
-
-				// 担当者情報が存在する場合は追加
-				if task.AssigneeID != nil {
-					assigneeIDStr := task.AssigneeID.String()
-					taskResponse.AssigneeID = &assigneeIDStr
-				}
-				if task.Assignee != nil {
-					taskResponse.Assignee = &UserResponse{
-						ID:    task.Assignee.ID.String(),
-						Email: task.Assignee.Email,
-					}
-				}
-
-				tasks = append(tasks, taskResponse)
-			}
-
-			columns = append(columns, ColumnResponse{
-				ID:    column.ID,
-				Title: column.Title,
-				Order: column.Order,
-				Tasks: tasks,
-			})
+		for i := range boardWithColumns.Columns {
+			columns = append(columns, newColumnResponse(&boardWithColumns.Columns[i]))
 		}
 
-		response = append(response, BoardResponse{
-			ID:        board.ID,
-			Name:      board.Name,
-			OwnerID:   board.OwnerID.String(),
-			CreatedAt: board.CreatedAt,
-			UpdatedAt: board.UpdatedAt,
-			Columns:   columns,
-		})
+		boardResponse := newBoardResponse(&board)
+		boardResponse.Columns = columns
+		response = append(response, boardResponse)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"boards": response,
 	})
 }
+
+// UpdateWIPLimitRequest WIP制限更新リクエスト構造体
+type UpdateWIPLimitRequest struct {
+	Limit *int   `json:"limit" validate:"omitempty,min=1"`          // nullの場合は制限を解除
+	Mode  string `json:"mode" validate:"omitempty,oneof=soft hard"` // 省略時はsoft
+}
+
+// UpdateColumnWIPLimit カラムのWIP制限更新ハンドラ
+// PUT /api/v1/columns/:columnId/wip-limit
+func (h *BoardHandler) UpdateColumnWIPLimit(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからカラムIDを取得
+	columnID, err := strconv.ParseUint(c.Param("columnId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なカラムIDです",
+		})
+		return
+	}
+
+	// 前提とするバージョンをIf-Matchヘッダーから取得
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateWIPLimitRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	column, err := h.boardService.UpdateColumnWIPLimit(uint(columnID), userID, version, req.Limit, domain.WIPLimitMode(req.Mode))
+	if err != nil {
+		if respondVersionConflict(c, err, h.currentColumn(uint(columnID), userID)) {
+			return
+		}
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := newColumnResponse(column)
+	response.Tasks = nil
+	setETag(c, column.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"column": response,
+	})
+}
+
+// currentBoard バージョン競合時に返すボードの現在の状態を取得する関数を返します
+func (h *BoardHandler) currentBoard(boardID uint, userID uuid.UUID) func() (interface{}, int, error) {
+	return func() (interface{}, int, error) {
+		board, err := h.boardService.GetBoardWithColumns(boardID, userID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return newBoardResponse(board), board.Version, nil
+	}
+}
+
+// currentColumn バージョン競合時に返すカラムの現在の状態を取得する関数を返します
+func (h *BoardHandler) currentColumn(columnID uint, userID uuid.UUID) func() (interface{}, int, error) {
+	return func() (interface{}, int, error) {
+		column, err := h.boardService.GetColumn(columnID, userID)
+		if err != nil {
+			return nil, 0, err
+		}
+		response := newColumnResponse(column)
+		response.Tasks = nil
+		return response, column.Version, nil
+	}
+}
+
+// CloneBoard ボード複製ハンドラ
+// POST /api/v1/boards/:id/clone
+func (h *BoardHandler) CloneBoard(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	var req CloneBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	board, err := h.boardService.CloneBoard(uint(boardID), userID, service.BoardCloneOptions{
+		Name:              req.Name,
+		IncludeCompleted:  req.IncludeCompleted,
+		IncludeAssignees:  req.IncludeAssignees,
+		IncludeDueDates:   req.IncludeDueDates,
+		DueDateOffsetDays: req.DueDateOffsetDays,
+	})
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := newBoardResponse(board)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"board": response,
+	})
+}
+
+// parseWorkspaceQuery クエリパラメータworkspace_idを取得します（省略時は0＝全ワークスペース）
+// 不正な値の場合は400を返し、falseを返します
+func parseWorkspaceQuery(c *gin.Context) (uint, bool) {
+	workspaceIDStr := c.Query("workspace_id")
+	if workspaceIDStr == "" {
+		return 0, true
+	}
+	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なワークスペースIDです",
+		})
+		return 0, false
+	}
+	return uint(workspaceID), true
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// BoardTemplateHandler ボードテンプレート関連のHTTPハンドラ
+type BoardTemplateHandler struct {
+	templateService service.BoardTemplateService
+	validator       *validator.Validate
+}
+
+// NewBoardTemplateHandler BoardTemplateHandlerの新しいインスタンスを作成
+func NewBoardTemplateHandler(templateService service.BoardTemplateService) *BoardTemplateHandler {
+	return &BoardTemplateHandler{
+		templateService: templateService,
+		validator:       validator.New(),
+	}
+}
+
+// CreateBoardTemplateRequest テンプレート作成リクエスト構造体
+type CreateBoardTemplateRequest struct {
+	Name        string                         `json:"name" validate:"required,min=1,max=100"`
+	Description string                         `json:"description" validate:"max=500"`
+	Definition  domain.BoardTemplateDefinition `json:"definition"`
+}
+
+// SaveBoardAsTemplateRequest ボードをテンプレートとして保存するリクエスト構造体
+type SaveBoardAsTemplateRequest struct {
+	Name         string `json:"name" validate:"required,min=1,max=100"`
+	Description  string `json:"description" validate:"max=500"`
+	IncludeTasks bool   `json:"include_tasks"` // ボードのタスクを初期タスクとして含めるか
+}
+
+// BoardTemplateResponse テンプレート情報レスポンス構造体
+type BoardTemplateResponse struct {
+	ID          string                         `json:"id"` // 組み込みテンプレートはキー、ユーザーのテンプレートは数値のID
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Builtin     bool                           `json:"builtin"`
+	Definition  domain.BoardTemplateDefinition `json:"definition"`
+	CreatedAt   *time.Time                     `json:"created_at,omitempty"`
+}
+
+// newBoardTemplateResponse テンプレートからレスポンスを構築します
+func newBoardTemplateResponse(template *domain.BoardTemplate) BoardTemplateResponse {
+	response := BoardTemplateResponse{
+		ID:          template.TemplateID(),
+		Name:        template.Name,
+		Description: template.Description,
+		Builtin:     template.IsBuiltin(),
+		Definition:  template.Definition,
+	}
+	if !template.IsBuiltin() {
+		response.CreatedAt = &template.CreatedAt
+	}
+	return response
+}
+
+// ListTemplates テンプレート一覧取得ハンドラ
+// GET /api/v1/board-templates
+func (h *BoardTemplateHandler) ListTemplates(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	templates, err := h.templateService.ListTemplates(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	responses := make([]BoardTemplateResponse, 0, len(templates))
+	for i := range templates {
+		responses = append(responses, newBoardTemplateResponse(&templates[i]))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"templates": responses,
+	})
+}
+
+// GetTemplate テンプレート取得ハンドラ
+// GET /api/v1/board-templates/:id
+func (h *BoardTemplateHandler) GetTemplate(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(c.Param("id"), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"template": newBoardTemplateResponse(template),
+	})
+}
+
+// CreateTemplate テンプレート作成ハンドラ
+// POST /api/v1/board-templates
+func (h *BoardTemplateHandler) CreateTemplate(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	var req CreateBoardTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(userID, req.Name, req.Description, req.Definition)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"template": newBoardTemplateResponse(template),
+	})
+}
+
+// SaveBoardAsTemplate ボードをテンプレートとして保存するハンドラ
+// POST /api/v1/boards/:id/save-as-template
+func (h *BoardTemplateHandler) SaveBoardAsTemplate(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	var req SaveBoardAsTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.SaveBoardAsTemplate(uint(boardID), userID, req.Name, req.Description, req.IncludeTasks)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"template": newBoardTemplateResponse(template),
+	})
+}
+
+// DeleteTemplate テンプレート削除ハンドラ
+// DELETE /api/v1/board-templates/:id
+func (h *BoardTemplateHandler) DeleteTemplate(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(c.Param("id"), userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
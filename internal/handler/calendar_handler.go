@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // CalendarHandler カレンダー関連のHTTPハンドラー
@@ -183,10 +184,13 @@ func (h *CalendarHandler) CreateEvent(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "イベントID"
+// @Param If-Match header string true "イベントのETag（バージョン）"
 // @Param event body domain.CalendarEvent true "カレンダーイベント"
 // @Success 200 {object} domain.CalendarEvent
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/calendar/events/{id} [put]
 func (h *CalendarHandler) UpdateEvent(c *gin.Context) {
@@ -202,18 +206,28 @@ func (h *CalendarHandler) UpdateEvent(c *gin.Context) {
 		return
 	}
 
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var event domain.CalendarEvent
 	if err := c.ShouldBindJSON(&event); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "リクエストデータが無効です"})
 		return
 	}
 
-	if err := h.calendarService.UpdateEvent(userID, uint(eventID), &event); err != nil {
+	updated, err := h.calendarService.UpdateEvent(userID, uint(eventID), version, &event)
+	if err != nil {
+		if respondVersionConflict(c, err, h.currentEvent(uint(eventID), userID)) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, event)
+	setETag(c, updated.Version)
+	c.JSON(http.StatusOK, updated)
 }
 
 // DeleteEvent カレンダーイベントを削除
@@ -223,9 +237,12 @@ func (h *CalendarHandler) UpdateEvent(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "イベントID"
+// @Param If-Match header string true "イベントのETag（バージョン）"
 // @Success 204 {string} string "No Content"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/calendar/events/{id} [delete]
 func (h *CalendarHandler) DeleteEvent(c *gin.Context) {
@@ -241,7 +258,15 @@ func (h *CalendarHandler) DeleteEvent(c *gin.Context) {
 		return
 	}
 
-	if err := h.calendarService.DeleteEvent(userID, uint(eventID)); err != nil {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	if err := h.calendarService.DeleteEvent(userID, uint(eventID), version); err != nil {
+		if respondVersionConflict(c, err, h.currentEvent(uint(eventID), userID)) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -249,6 +274,17 @@ func (h *CalendarHandler) DeleteEvent(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// currentEvent バージョン競合時に返すイベントの現在の状態を取得する関数を返します
+func (h *CalendarHandler) currentEvent(eventID uint, userID uuid.UUID) func() (interface{}, int, error) {
+	return func() (interface{}, int, error) {
+		event, err := h.calendarService.GetEvent(userID, eventID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return event, event.Version, nil
+	}
+}
+
 // CreateTaskEvent タスクからカレンダーイベントを作成
 // @Summary タスクからカレンダーイベント作成
 // @Description タスクを基にカレンダーイベントを作成します
@@ -0,0 +1,388 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// CustomFieldHandler カスタムフィールド関連のHTTPハンドラ
+type CustomFieldHandler struct {
+	customFieldService service.CustomFieldService
+	validator          *validator.Validate
+}
+
+// NewCustomFieldHandler CustomFieldHandlerの新しいインスタンスを作成
+func NewCustomFieldHandler(customFieldService service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		customFieldService: customFieldService,
+		validator:          validator.New(),
+	}
+}
+
+// CreateCustomFieldRequest カスタムフィールド作成リクエスト構造体
+type CreateCustomFieldRequest struct {
+	Name    string   `json:"name" validate:"required,min=1,max=50"`
+	Type    string   `json:"type" validate:"required,oneof=text number date single_select multi_select"`
+	Options []string `json:"options"` // 選択肢（single_select / multi_select のみ）
+}
+
+// UpdateCustomFieldRequest カスタムフィールド更新リクエスト構造体
+type UpdateCustomFieldRequest struct {
+	Name    *string  `json:"name" validate:"omitempty,min=1,max=50"`
+	Options []string `json:"options"`
+	Order   *int     `json:"order" validate:"omitempty,min=1"`
+}
+
+// SetCustomFieldValueRequest カスタムフィールド値設定リクエスト構造体
+// valueはフィールド型に応じて文字列・数値・日付（YYYY-MM-DD）・選択肢の配列を指定します
+type SetCustomFieldValueRequest struct {
+	Value interface{} `json:"value"`
+}
+
+// CustomFieldResponse カスタムフィールド定義レスポンス構造体
+type CustomFieldResponse struct {
+	ID        uint      `json:"id"`
+	BoardID   uint      `json:"board_id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	Order     int       `json:"order"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CustomFieldValueResponse タスクのカスタムフィールド値レスポンス構造体
+type CustomFieldValueResponse struct {
+	FieldID uint        `json:"field_id"`
+	Value   interface{} `json:"value"`
+}
+
+// CreateField カスタムフィールド作成ハンドラ
+// POST /api/v1/boards/:id/custom-fields
+func (h *CustomFieldHandler) CreateField(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	var req CreateCustomFieldRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	field, err := h.customFieldService.CreateField(uint(boardID), userID, req.Name, domain.CustomFieldType(req.Type), req.Options)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"custom_field": newCustomFieldResponse(field),
+	})
+}
+
+// GetBoardFields ボードのカスタムフィールド一覧取得ハンドラ
+// GET /api/v1/boards/:id/custom-fields
+func (h *CustomFieldHandler) GetBoardFields(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	fields, err := h.customFieldService.GetBoardFields(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]CustomFieldResponse, 0, len(fields))
+	for i := range fields {
+		response = append(response, newCustomFieldResponse(&fields[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"custom_fields": response,
+	})
+}
+
+// UpdateField カスタムフィールド更新ハンドラ
+// PUT /api/v1/custom-fields/:id
+func (h *CustomFieldHandler) UpdateField(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからフィールドIDを取得
+	fieldID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なカスタムフィールドIDです",
+		})
+		return
+	}
+
+	var req UpdateCustomFieldRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// 更新データを構築
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Options != nil {
+		updates["options"] = req.Options
+	}
+	if req.Order != nil {
+		updates["order"] = *req.Order
+	}
+
+	field, err := h.customFieldService.UpdateField(uint(fieldID), userID, updates)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"custom_field": newCustomFieldResponse(field),
+	})
+}
+
+// DeleteField カスタムフィールド削除ハンドラ
+// DELETE /api/v1/custom-fields/:id
+func (h *CustomFieldHandler) DeleteField(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからフィールドIDを取得
+	fieldID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なカスタムフィールドIDです",
+		})
+		return
+	}
+
+	if err := h.customFieldService.DeleteField(uint(fieldID), userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// SetTaskValue タスクのカスタムフィールド値設定ハンドラ
+// PUT /api/v1/tasks/:id/custom-fields/:fieldId
+func (h *CustomFieldHandler) SetTaskValue(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	taskID, fieldID, ok := parseTaskFieldParams(c)
+	if !ok {
+		return
+	}
+
+	var req SetCustomFieldValueRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// nullが指定された場合は値を削除する
+	if req.Value == nil {
+		if err := h.customFieldService.ClearTaskValue(taskID, fieldID, userID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	value, err := h.customFieldService.SetTaskValue(taskID, fieldID, userID, req.Value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"custom_field_value": newCustomFieldValueResponse(value),
+	})
+}
+
+// ClearTaskValue タスクのカスタムフィールド値削除ハンドラ
+// DELETE /api/v1/tasks/:id/custom-fields/:fieldId
+func (h *CustomFieldHandler) ClearTaskValue(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	taskID, fieldID, ok := parseTaskFieldParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.customFieldService.ClearTaskValue(taskID, fieldID, userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// parseTaskFieldParams パスパラメータからタスクIDとフィールドIDを取得します
+// 不正な値の場合はエラーレスポンスを書き込み、falseを返します
+func parseTaskFieldParams(c *gin.Context) (uint, uint, bool) {
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なタスクIDです",
+		})
+		return 0, 0, false
+	}
+
+	fieldID, err := strconv.ParseUint(c.Param("fieldId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なカスタムフィールドIDです",
+		})
+		return 0, 0, false
+	}
+
+	return uint(taskID), uint(fieldID), true
+}
+
+// newCustomFieldResponse フィールド定義からレスポンスを構築します
+func newCustomFieldResponse(field *domain.CustomFieldDefinition) CustomFieldResponse {
+	return CustomFieldResponse{
+		ID:        field.ID,
+		BoardID:   field.BoardID,
+		Name:      field.Name,
+		Type:      string(field.Type),
+		Options:   field.Options,
+		Order:     field.Order,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+}
+
+// newCustomFieldValueResponse フィールド値からレスポンスを構築します
+// 値カラムのうち設定されているものを value として返します
+func newCustomFieldValueResponse(value *domain.TaskCustomFieldValue) CustomFieldValueResponse {
+	response := CustomFieldValueResponse{FieldID: value.FieldID}
+
+	switch {
+	case value.TextValue != nil:
+		response.Value = *value.TextValue
+	case value.NumberValue != nil:
+		response.Value = *value.NumberValue
+	case value.DateValue != nil:
+		response.Value = value.DateValue.Format("2006-01-02")
+	case value.OptionValues != nil:
+		response.Value = []string(value.OptionValues)
+	}
+
+	return response
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag エンティティのバージョンをETagヘッダーに設定します
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", fmt.Sprintf("\"%d\"", version))
+}
+
+// requireIfMatch If-Matchヘッダーから、更新・削除の前提とするバージョンを取得します
+// 「*」の場合はバージョンを確認しません（domain.AnyVersion）
+// ヘッダーがない場合は428、形式が不正な場合は400のレスポンスを書き込み、falseを返します
+func requireIfMatch(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{
+			"error": "If-Matchヘッダーにバージョン（ETag）を指定してください",
+		})
+		return 0, false
+	}
+	if header == "*" {
+		return domain.AnyVersion, true
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), "\"")
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なIf-Matchヘッダーです",
+		})
+		return 0, false
+	}
+	return version, true
+}
+
+// respondVersionConflict エラーがバージョンの競合の場合は412レスポンスを書き込み、trueを返します
+// currentで取得した現在の状態とそのバージョン（ETag）をレスポンスに含めます
+func respondVersionConflict(c *gin.Context, err error, current func() (interface{}, int, error)) bool {
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		return false
+	}
+
+	response := gin.H{
+		"error": domain.ErrVersionConflict.Error(),
+		"code":  domain.ErrCodeVersionConflict,
+	}
+	if body, version, err := current(); err == nil {
+		setETag(c, version)
+		response["current"] = body
+	}
+	c.JSON(http.StatusPreconditionFailed, response)
+	return true
+}
@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// LabelHandler ラベル関連のHTTPハンドラ
+type LabelHandler struct {
+	labelService service.LabelService
+	validator    *validator.Validate
+}
+
+// NewLabelHandler LabelHandlerの新しいインスタンスを作成
+func NewLabelHandler(labelService service.LabelService) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+		validator:    validator.New(),
+	}
+}
+
+// CreateLabelRequest ラベル作成リクエスト構造体
+type CreateLabelRequest struct {
+	Name  string `json:"name" validate:"required,min=1,max=30"`
+	Color string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+// UpdateLabelRequest ラベル更新リクエスト構造体
+type UpdateLabelRequest struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=30"`
+	Color *string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+// LabelResponse ラベル情報レスポンス構造体
+type LabelResponse struct {
+	ID      uint   `json:"id"`
+	BoardID uint   `json:"board_id"`
+	Name    string `json:"name"`
+	Color   string `json:"color"`
+}
+
+// CreateLabel ラベル作成ハンドラ
+// POST /api/v1/boards/:id/labels
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	var req CreateLabelRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	label, err := h.labelService.CreateLabel(uint(boardID), userID, req.Name, req.Color)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"label": newLabelResponse(label),
+	})
+}
+
+// GetBoardLabels ボードのラベル一覧取得ハンドラ
+// GET /api/v1/boards/:id/labels
+func (h *LabelHandler) GetBoardLabels(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	labels, err := h.labelService.GetBoardLabels(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]LabelResponse, 0, len(labels))
+	for i := range labels {
+		response = append(response, newLabelResponse(&labels[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"labels": response,
+	})
+}
+
+// UpdateLabel ラベル更新ハンドラ
+// PUT /api/v1/labels/:id
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからラベルIDを取得
+	labelID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なラベルIDです",
+		})
+		return
+	}
+
+	var req UpdateLabelRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// 更新データを構築
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Color != nil {
+		updates["color"] = *req.Color
+	}
+
+	label, err := h.labelService.UpdateLabel(uint(labelID), userID, updates)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"label": newLabelResponse(label),
+	})
+}
+
+// DeleteLabel ラベル削除ハンドラ
+// DELETE /api/v1/labels/:id
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからラベルIDを取得
+	labelID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なラベルIDです",
+		})
+		return
+	}
+
+	if err := h.labelService.DeleteLabel(uint(labelID), userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// AddLabelToTask タスクへのラベル付与ハンドラ
+// POST /api/v1/tasks/:id/labels/:labelId
+func (h *LabelHandler) AddLabelToTask(c *gin.Context) {
+	h.changeTaskLabel(c, h.labelService.AddLabelToTask)
+}
+
+// RemoveLabelFromTask タスクからのラベル解除ハンドラ
+// DELETE /api/v1/tasks/:id/labels/:labelId
+func (h *LabelHandler) RemoveLabelFromTask(c *gin.Context) {
+	h.changeTaskLabel(c, h.labelService.RemoveLabelFromTask)
+}
+
+// changeTaskLabel タスクとラベルの関連付けを変更する共通処理
+func (h *LabelHandler) changeTaskLabel(c *gin.Context, change func(taskID, labelID uint, userID uuid.UUID) error) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからタスクIDとラベルIDを取得
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なタスクIDです",
+		})
+		return
+	}
+	labelID, err := strconv.ParseUint(c.Param("labelId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なラベルIDです",
+		})
+		return
+	}
+
+	if err := change(uint(taskID), uint(labelID), userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// newLabelResponse ラベルエンティティからレスポンスを構築します
+func newLabelResponse(label *domain.Label) LabelResponse {
+	return LabelResponse{
+		ID:      label.ID,
+		BoardID: label.BoardID,
+		Name:    label.Name,
+		Color:   label.Color,
+	}
+}
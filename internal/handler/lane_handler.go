@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// LaneHandler スイムレーン関連のHTTPハンドラ
+type LaneHandler struct {
+	laneService service.LaneService
+	validator   *validator.Validate
+}
+
+// NewLaneHandler LaneHandlerの新しいインスタンスを作成
+func NewLaneHandler(laneService service.LaneService) *LaneHandler {
+	return &LaneHandler{
+		laneService: laneService,
+		validator:   validator.New(),
+	}
+}
+
+// CreateLaneRequest レーン作成リクエスト構造体
+type CreateLaneRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+// UpdateLaneRequest レーン更新リクエスト構造体
+type UpdateLaneRequest struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Order *int    `json:"order" validate:"omitempty,min=1"`
+}
+
+// LaneResponse レーン情報レスポンス構造体
+type LaneResponse struct {
+	ID      uint   `json:"id"`
+	BoardID uint   `json:"board_id"`
+	Name    string `json:"name"`
+	Order   int    `json:"order"`
+}
+
+// SwimlaneCellResponse スイムレーンのセル（レーンとカラムの組）レスポンス構造体
+type SwimlaneCellResponse struct {
+	ColumnID uint           `json:"column_id"`
+	Tasks    []TaskResponse `json:"tasks"`
+}
+
+// SwimlaneResponse スイムレーンの行レスポンス構造体
+type SwimlaneResponse struct {
+	Key    string                 `json:"key"`
+	Name   string                 `json:"name"`
+	LaneID *uint                  `json:"lane_id,omitempty"`
+	Cells  []SwimlaneCellResponse `json:"cells"`
+}
+
+// CreateLane レーン作成ハンドラ
+// POST /api/v1/boards/:id/lanes
+func (h *LaneHandler) CreateLane(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	var req CreateLaneRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	lane, err := h.laneService.CreateLane(uint(boardID), userID, req.Name)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"lane": newLaneResponse(lane),
+	})
+}
+
+// GetBoardLanes ボードのレーン一覧取得ハンドラ
+// GET /api/v1/boards/:id/lanes
+func (h *LaneHandler) GetBoardLanes(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	lanes, err := h.laneService.GetBoardLanes(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]LaneResponse, 0, len(lanes))
+	for i := range lanes {
+		response = append(response, newLaneResponse(&lanes[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"lanes": response,
+	})
+}
+
+// UpdateLane レーン更新ハンドラ
+// PUT /api/v1/lanes/:id
+func (h *LaneHandler) UpdateLane(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからレーンIDを取得
+	laneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なレーンIDです",
+		})
+		return
+	}
+
+	var req UpdateLaneRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// 更新データを構築
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Order != nil {
+		updates["order"] = *req.Order
+	}
+
+	lane, err := h.laneService.UpdateLane(uint(laneID), userID, updates)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"lane": newLaneResponse(lane),
+	})
+}
+
+// DeleteLane レーン削除ハンドラ
+// DELETE /api/v1/lanes/:id
+func (h *LaneHandler) DeleteLane(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからレーンIDを取得
+	laneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なレーンIDです",
+		})
+		return
+	}
+
+	if err := h.laneService.DeleteLane(uint(laneID), userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetSwimlanes スイムレーン表示取得ハンドラ
+// GET /api/v1/boards/:id/swimlanes?group_by=lane|assignee|priority
+func (h *LaneHandler) GetSwimlanes(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	groupBy := domain.SwimlaneGroupBy(c.DefaultQuery("group_by", string(domain.SwimlaneByLane)))
+	if !groupBy.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "group_byには lane / assignee / priority のいずれかを指定してください",
+		})
+		return
+	}
+
+	swimlanes, err := h.laneService.GetSwimlanes(uint(boardID), userID, groupBy)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// カラム一覧（タスクはセルに含めるため省略）
+	columns := make([]ColumnResponse, 0, len(swimlanes.Board.Columns))
+	for i := range swimlanes.Board.Columns {
+		column := newColumnResponse(&swimlanes.Board.Columns[i])
+		column.Tasks = nil
+		columns = append(columns, column)
+	}
+
+	lanes := make([]SwimlaneResponse, 0, len(swimlanes.Lanes))
+	for _, lane := range swimlanes.Lanes {
+		response := SwimlaneResponse{
+			Key:    lane.Key,
+			Name:   lane.Name,
+			LaneID: lane.LaneID,
+			Cells:  make([]SwimlaneCellResponse, 0, len(columns)),
+		}
+		for _, column := range swimlanes.Board.Columns {
+			cell := SwimlaneCellResponse{ColumnID: column.ID, Tasks: []TaskResponse{}}
+			for i := range lane.Cells[column.ID] {
+				cell.Tasks = append(cell.Tasks, newTaskResponse(&lane.Cells[column.ID][i]))
+			}
+			response.Cells = append(response.Cells, cell)
+		}
+		lanes = append(lanes, response)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"board_id":  swimlanes.Board.ID,
+		"group_by":  swimlanes.GroupBy,
+		"columns":   columns,
+		"swimlanes": lanes,
+	})
+}
+
+// newLaneResponse レーンからレスポンスを構築します
+func newLaneResponse(lane *domain.Lane) LaneResponse {
+	return LaneResponse{
+		ID:      lane.ID,
+		BoardID: lane.BoardID,
+		Name:    lane.Name,
+		Order:   lane.Order,
+	}
+}
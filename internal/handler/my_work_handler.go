@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MyWorkHandler 個人ダッシュボード関連のHTTPハンドラ
+type MyWorkHandler struct {
+	myWorkService service.MyWorkService
+	userService   service.UserService // tzを省略した場合のユーザーのタイムゾーンの取得に使用
+}
+
+// NewMyWorkHandler MyWorkHandlerの新しいインスタンスを作成
+func NewMyWorkHandler(myWorkService service.MyWorkService, userService service.UserService) *MyWorkHandler {
+	return &MyWorkHandler{
+		myWorkService: myWorkService,
+		userService:   userService,
+	}
+}
+
+// MyWorkTaskResponse ボード・カラム情報付きのタスクレスポンス構造体
+type MyWorkTaskResponse struct {
+	TaskResponse
+	BoardID     uint   `json:"board_id"`
+	BoardName   string `json:"board_name"`
+	ColumnTitle string `json:"column_title"`
+}
+
+// MyWorkResponse 個人ダッシュボードレスポンス構造体
+type MyWorkResponse struct {
+	Overdue     []MyWorkTaskResponse    `json:"overdue"`
+	Today       []MyWorkTaskResponse    `json:"today"`
+	ThisWeek    []MyWorkTaskResponse    `json:"this_week"`
+	Later       []MyWorkTaskResponse    `json:"later"`
+	NoDueDate   []MyWorkTaskResponse    `json:"no_due_date"`
+	Events      []*domain.CalendarEvent `json:"events"`
+	ActiveTimer *domain.TimerSession    `json:"active_timer"`
+}
+
+// GetMyWork 自分が担当するタスクを全ボード横断で取得するハンドラ
+// GET /api/v1/tasks/my-work?tz=Asia/Tokyo
+// tzを省略した場合はユーザーの設定のタイムゾーン（未設定の場合はサーバーのタイムゾーン）で「今日」を判定します
+func (h *MyWorkHandler) GetMyWork(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	now := time.Now()
+	if tz := c.Query("tz"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不正なタイムゾーンです",
+			})
+			return
+		}
+		now = now.In(loc)
+	} else {
+		user, err := h.userService.GetProfile(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if user.TimeZone != "" {
+			// 保存時にチェック済みのため、読み込めない場合はサーバーのタイムゾーンを使用する
+			if loc, err := time.LoadLocation(user.TimeZone); err == nil {
+				now = now.In(loc)
+			}
+		}
+	}
+
+	work, err := h.myWorkService.GetMyWork(userID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	events := work.Events
+	if events == nil {
+		events = []*domain.CalendarEvent{}
+	}
+
+	c.JSON(http.StatusOK, MyWorkResponse{
+		Overdue:     newMyWorkTaskResponses(work.Overdue),
+		Today:       newMyWorkTaskResponses(work.Today),
+		ThisWeek:    newMyWorkTaskResponses(work.ThisWeek),
+		Later:       newMyWorkTaskResponses(work.Later),
+		NoDueDate:   newMyWorkTaskResponses(work.NoDueDate),
+		Events:      events,
+		ActiveTimer: work.ActiveTimer,
+	})
+}
+
+// newMyWorkTaskResponses タスク一覧からボード・カラム情報付きのレスポンスを構築します
+func newMyWorkTaskResponses(tasks []domain.Task) []MyWorkTaskResponse {
+	responses := make([]MyWorkTaskResponse, 0, len(tasks))
+	for i := range tasks {
+		task := &tasks[i]
+		responses = append(responses, MyWorkTaskResponse{
+			TaskResponse: newTaskResponse(task),
+			BoardID:      task.Column.BoardID,
+			BoardName:    task.Column.Board.Name,
+			ColumnTitle:  task.Column.Title,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMyWorkService モックサービス
+type MockMyWorkService struct {
+	mock.Mock
+}
+
+func (m *MockMyWorkService) GetMyWork(userID uuid.UUID, now time.Time) (*service.MyWork, error) {
+	args := m.Called(userID, now.Location().String())
+	work, _ := args.Get(0).(*service.MyWork)
+	return work, args.Error(1)
+}
+
+// MockUserService モックサービス（使用するメソッドのみ実装）
+type MockUserService struct {
+	service.UserService
+	mock.Mock
+}
+
+func (m *MockUserService) GetProfile(userID uuid.UUID) (*domain.User, error) {
+	args := m.Called(userID)
+	user, _ := args.Get(0).(*domain.User)
+	return user, args.Error(1)
+}
+
+// tzを省略した場合はユーザーの設定のタイムゾーンで「今日」を判定することのテスト
+func TestGetMyWork_TimeZone(t *testing.T) {
+	userID := uuid.New()
+	myWorkService := new(MockMyWorkService)
+	userService := new(MockUserService)
+	handler := NewMyWorkHandler(myWorkService, userService)
+	userService.On("GetProfile", userID).Return(&domain.User{ID: userID, TimeZone: "Asia/Tokyo"}, nil)
+	myWorkService.On("GetMyWork", userID, "Asia/Tokyo").Return(&service.MyWork{}, nil)
+	myWorkService.On("GetMyWork", userID, "America/New_York").Return(&service.MyWork{}, nil)
+
+	router := setupTestRouter()
+	router.GET("/tasks/my-work", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		handler.GetMyWork(c)
+	})
+
+	// tzを省略した場合はユーザーの設定を使用する
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/my-work", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// tzを指定した場合はユーザーの設定より優先する
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/my-work?tz=America/New_York", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	myWorkService.AssertExpectations(t)
+	userService.AssertNumberOfCalls(t, "GetProfile", 1)
+}
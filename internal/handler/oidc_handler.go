@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ログインを開始したブラウザにstateを保存するCookie
+// コールバックのURLはリバースプロキシで変わることがあるため、パスは限定しない
+const oidcStateCookieName = "oidc_state"
+
+// OIDCExchangeRequest ログインのコードをJWTトークンと交換するリクエスト
+type OIDCExchangeRequest struct {
+	Code string `json:"code"`
+}
+
+// OIDCHandler OpenID Connectによるログインのハンドラ
+type OIDCHandler struct {
+	oidcService service.OIDCService
+}
+
+// NewOIDCHandler OIDCHandlerの新しいインスタンスを作成
+func NewOIDCHandler(oidcService service.OIDCService) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+	}
+}
+
+// Login IDプロバイダーでのログインを開始するハンドラ（認可エンドポイントにリダイレクト）
+// GET /api/v1/auth/oidc/login
+func (h *OIDCHandler) Login(c *gin.Context) {
+	authorization, err := h.oidcService.Authorize()
+	if err != nil {
+		if errors.Is(err, service.ErrOIDCDisabled) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// 他のブラウザで開始したログインのコールバックを受け付けないよう、stateをこのブラウザに保存する
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookieName, authorization.State, int(domain.OIDCLoginStateTTL.Seconds()), "/", "", authorization.SecureCookie, true)
+	c.Redirect(http.StatusFound, authorization.URL)
+}
+
+// Callback IDプロバイダーからのコールバックを処理し、フロントエンドにリダイレクトするハンドラ
+// 成功した場合は1回限りのログインのコード（code）を、失敗した場合はエラー（error）をクエリで渡します
+// GET /api/v1/auth/oidc/callback
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	// stateのCookieは成否にかかわらず削除する
+	stateCookie, _ := c.Cookie(oidcStateCookieName)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookieName, "", -1, "/", "", false, true)
+
+	// IDプロバイダーでログインが拒否された場合
+	if errorCode := c.Query("error"); errorCode != "" {
+		h.redirectToFrontend(c, url.Values{
+			"error":             {"IDプロバイダーでログインできませんでした"},
+			"error_description": {errorCode + ": " + c.Query("error_description")},
+		})
+		return
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		h.redirectToFrontend(c, url.Values{"error": {"code と state を指定してください"}})
+		return
+	}
+	if stateCookie == "" || subtle.ConstantTimeCompare([]byte(stateCookie), []byte(state)) != 1 {
+		h.redirectToFrontend(c, url.Values{"error": {"ログインを開始したブラウザと異なるため、ログインできません。もう一度ログインしてください"}})
+		return
+	}
+
+	loginCode, err := h.oidcService.Login(code, state)
+	if err != nil {
+		if errors.Is(err, service.ErrOIDCDisabled) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		h.redirectToFrontend(c, url.Values{"error": {err.Error()}})
+		return
+	}
+
+	h.redirectToFrontend(c, url.Values{"code": {loginCode}})
+}
+
+// Exchange コールバックでフロントエンドに渡したログインのコードをJWTトークンと交換するハンドラ
+// POST /api/v1/auth/oidc/exchange
+func (h *OIDCHandler) Exchange(c *gin.Context) {
+	var req OIDCExchangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "code を指定してください",
+		})
+		return
+	}
+
+	user, token, err := h.oidcService.ExchangeLoginCode(req.Code)
+	var challenge *service.TwoFactorRequiredError
+	if errors.As(err, &challenge) {
+		// IDプロバイダーでのログインでも、二要素認証のコードを /auth/2fa/verify で送信するとログインが完了する
+		c.JSON(http.StatusOK, gin.H{
+			"two_factor_required": true,
+			"challenge_token":     challenge.ChallengeToken,
+			"expires_in":          int(service.TwoFactorChallengeTTL.Seconds()),
+		})
+		return
+	}
+	if err != nil {
+		status := http.StatusUnauthorized
+		if errors.Is(err, service.ErrOIDCDisabled) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// パスワードでのログインと同じ形式で返す
+	c.JSON(http.StatusOK, AuthResponse{
+		User:  newUserResponse(user),
+		Token: token,
+	})
+}
+
+// redirectToFrontend ログインの結果をクエリに付けてフロントエンドのページにリダイレクトします
+func (h *OIDCHandler) redirectToFrontend(c *gin.Context, query url.Values) {
+	c.Redirect(http.StatusFound, h.oidcService.FrontendCallbackURL(query))
+}
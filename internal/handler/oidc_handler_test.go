@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockOIDCService モックサービス
+type MockOIDCService struct {
+	mock.Mock
+}
+
+func (m *MockOIDCService) Authorize() (*service.OIDCAuthorization, error) {
+	args := m.Called()
+	authorization, _ := args.Get(0).(*service.OIDCAuthorization)
+	return authorization, args.Error(1)
+}
+
+func (m *MockOIDCService) Login(code, state string) (string, error) {
+	args := m.Called(code, state)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOIDCService) ExchangeLoginCode(loginCode string) (*domain.User, string, error) {
+	args := m.Called(loginCode)
+	user, _ := args.Get(0).(*domain.User)
+	return user, args.String(1), args.Error(2)
+}
+
+func (m *MockOIDCService) FrontendCallbackURL(query url.Values) string {
+	return "http://app.example.com/oidc/callback?" + query.Encode()
+}
+
+// ログインの開始時にstateをCookieに保存し、コールバックでは同じブラウザのstateのみ受け付けることのテスト
+func TestOIDCCallback_RequiresStateCookie(t *testing.T) {
+	mockService := new(MockOIDCService)
+	handler := NewOIDCHandler(mockService)
+	mockService.On("Authorize").Return(&service.OIDCAuthorization{URL: "https://idp.example.com/authorize", State: "state-1"}, nil)
+	mockService.On("Login", "code-1", "state-1").Return("login-code", nil)
+
+	router := setupTestRouter()
+	router.GET("/oidc/login", handler.Login)
+	router.GET("/oidc/callback", handler.Callback)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/oidc/login", nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "state-1", cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+
+	// Cookieのない（他のブラウザで開始された）コールバックは拒否する
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/oidc/callback?code=code-1&state=state-1", nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, location.Query().Get("error"))
+	mockService.AssertNotCalled(t, "Login", "code-1", "state-1")
+
+	// 同じブラウザのコールバックでは、JWTトークンではなく1回限りのコードをフロントエンドに渡す
+	req := httptest.NewRequest(http.MethodGet, "/oidc/callback?code=code-1&state=state-1", nil)
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusFound, w.Code)
+	location, err = url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "/oidc/callback", location.Path)
+	assert.Equal(t, "login-code", location.Query().Get("code"))
+
+	mockService.AssertExpectations(t)
+}
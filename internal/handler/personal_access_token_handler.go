@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// PersonalAccessTokenHandler パーソナルアクセストークン関連のHTTPハンドラ
+type PersonalAccessTokenHandler struct {
+	tokenService service.PersonalAccessTokenService
+	validator    *validator.Validate
+}
+
+// NewPersonalAccessTokenHandler PersonalAccessTokenHandlerの新しいインスタンスを作成
+func NewPersonalAccessTokenHandler(tokenService service.PersonalAccessTokenService) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		tokenService: tokenService,
+		validator:    validator.New(),
+	}
+}
+
+// CreateTokenRequest トークン発行リクエスト構造体
+type CreateTokenRequest struct {
+	Name          string              `json:"name" validate:"required,min=1,max=100"`
+	Scopes        []domain.TokenScope `json:"scopes" validate:"required,min=1"`
+	ExpiresInDays *int                `json:"expires_in_days" validate:"omitempty,min=1,max=3650"` // 省略時は無期限
+}
+
+// CreateToken トークン発行ハンドラ
+// POST /api/v1/tokens
+func (h *PersonalAccessTokenHandler) CreateToken(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	var req CreateTokenRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		t := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		expiresAt = &t
+	}
+
+	token, secret, err := h.tokenService.CreateToken(userID, req.Name, domain.TokenScopes(req.Scopes), expiresAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// トークンはハッシュのみを保存するため、発行時のみ返す
+	c.JSON(http.StatusCreated, gin.H{
+		"token":        token,
+		"access_token": secret,
+	})
+}
+
+// ListTokens トークン一覧取得ハンドラ
+// GET /api/v1/tokens
+func (h *PersonalAccessTokenHandler) ListTokens(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	tokens, err := h.tokenService.ListTokens(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if tokens == nil {
+		tokens = []domain.PersonalAccessToken{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+	})
+}
+
+// RevokeToken トークン失効ハンドラ
+// DELETE /api/v1/tokens/:id
+func (h *PersonalAccessTokenHandler) RevokeToken(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからトークンIDを取得
+	tokenID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なトークンIDです",
+		})
+		return
+	}
+
+	if err := h.tokenService.RevokeToken(uint(tokenID), userID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
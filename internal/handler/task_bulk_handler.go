@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// TaskBulkHandler タスク一括操作のHTTPハンドラ
+type TaskBulkHandler struct {
+	taskBulkService service.TaskBulkService
+	validator       *validator.Validate
+}
+
+// NewTaskBulkHandler TaskBulkHandlerの新しいインスタンスを作成
+func NewTaskBulkHandler(taskBulkService service.TaskBulkService) *TaskBulkHandler {
+	return &TaskBulkHandler{
+		taskBulkService: taskBulkService,
+		validator:       validator.New(),
+	}
+}
+
+// BulkTaskRequest タスク一括操作リクエスト構造体
+type BulkTaskRequest struct {
+	TaskIDs    []uint  `json:"task_ids" validate:"required,min=1,max=200"`
+	Operation  string  `json:"operation" validate:"required,oneof=move set_assignee set_due_date complete delete add_label"`
+	ColumnID   uint    `json:"column_id" validate:"required_if=Operation move"`     // move: 移動先カラム
+	AssigneeID *string `json:"assignee_id"`                                         // set_assignee: 空文字またはnullで担当者を外す
+	DueDate    *string `json:"due_date"`                                            // set_due_date: 空文字またはnullで期限を外す
+	LabelID    uint    `json:"label_id" validate:"required_if=Operation add_label"` // add_label: 付与するラベル
+}
+
+// BulkTaskResult タスクごとの一括操作結果
+type BulkTaskResult struct {
+	TaskID  uint   `json:"task_id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkUpdate タスク一括操作ハンドラ
+// POST /api/v1/tasks/bulk
+func (h *TaskBulkHandler) BulkUpdate(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	var req BulkTaskRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	op := service.TaskBulkOperation{
+		Type:     service.TaskBulkOperationType(req.Operation),
+		ColumnID: req.ColumnID,
+		LabelID:  req.LabelID,
+	}
+
+	// 担当者IDを解析（指定されている場合）
+	if req.AssigneeID != nil && *req.AssigneeID != "" {
+		parsed, err := uuid.Parse(*req.AssigneeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不正な担当者IDです",
+			})
+			return
+		}
+		op.AssigneeID = &parsed
+	}
+
+	// 期限日を解析（指定されている場合）
+	if req.DueDate != nil && *req.DueDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.DueDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不正な期限日の形式です（YYYY-MM-DD形式で入力してください）",
+			})
+			return
+		}
+		op.DueDate = &parsed
+	}
+
+	results, err := h.taskBulkService.Apply(userID, req.TaskIDs, op)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]BulkTaskResult, 0, len(results))
+	succeeded := 0
+	for _, result := range results {
+		response = append(response, BulkTaskResult{
+			TaskID:  result.TaskID,
+			Success: result.Success,
+			Error:   result.Error,
+		})
+		if result.Success {
+			succeeded++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":   response,
+		"succeeded": succeeded,
+		"failed":    len(response) - succeeded,
+	})
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxTaskCSVSize インポートできるCSVの最大サイズ（10MB）
+const maxTaskCSVSize = 10 << 20
+
+// TaskCSVHandler タスクのCSVエクスポート・インポート関連のHTTPハンドラ
+type TaskCSVHandler struct {
+	csvService service.TaskCSVService
+}
+
+// NewTaskCSVHandler TaskCSVHandlerの新しいインスタンスを作成
+func NewTaskCSVHandler(csvService service.TaskCSVService) *TaskCSVHandler {
+	return &TaskCSVHandler{
+		csvService: csvService,
+	}
+}
+
+// TaskCSVImportResponse タスクのCSVインポート結果のレスポンス
+type TaskCSVImportResponse struct {
+	DryRun         bool                     `json:"dry_run"`
+	Rows           int                      `json:"rows"`
+	CreatedColumns []string                 `json:"created_columns"`
+	CreatedTasks   int                      `json:"created_tasks"`
+	Errors         []domain.TaskCSVRowError `json:"errors"`
+}
+
+// ExportTasksCSV ボードのタスクをCSVファイルとしてエクスポートするハンドラ
+// GET /api/v1/boards/:id/export/csv
+func (h *TaskCSVHandler) ExportTasksCSV(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	rows, err := h.csvService.ExportTasks(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := domain.WriteTaskCSV(&buf, rows); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "CSVの作成に失敗しました",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"board-%d-tasks.csv\"", boardID))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// ImportTasksCSV CSVファイルからボードにタスクを作成するハンドラ
+// POST /api/v1/boards/:id/import/csv?dry_run=true
+// CSVはリクエストボディ（text/csv）か、multipart/form-dataの file で受け付けます
+func (h *TaskCSVHandler) ImportTasksCSV(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	dryRun := false
+	if s := c.Query("dry_run"); s != "" {
+		dryRun, err = strconv.ParseBool(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "dry_run には true または false を指定してください",
+			})
+			return
+		}
+	}
+
+	body := io.Reader(http.MaxBytesReader(c.Writer, c.Request.Body, maxTaskCSVSize))
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "CSVファイル（file）を指定してください",
+			})
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "CSVファイルを開けません",
+			})
+			return
+		}
+		defer file.Close()
+		body = io.LimitReader(file, maxTaskCSVSize)
+	}
+
+	rows, rowErrors, err := domain.ParseTaskCSV(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "不正なリクエスト形式です",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.csvService.ImportTasks(uint(boardID), userID, rows, rowErrors, dryRun)
+	if err != nil {
+		if respondWIPLimitError(c, err) {
+			return
+		}
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := TaskCSVImportResponse{
+		DryRun:         result.DryRun,
+		Rows:           result.Rows,
+		CreatedColumns: result.CreatedColumns,
+		CreatedTasks:   result.CreatedTasks,
+		Errors:         result.Errors,
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		// エラーのある行があれば何も作成しない
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": response,
+		})
+	case dryRun:
+		c.JSON(http.StatusOK, response)
+	default:
+		c.JSON(http.StatusCreated, response)
+	}
+}
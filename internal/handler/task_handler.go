@@ -2,12 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
 	"simple-kanban/internal/service"
 	"simple-kanban/pkg/middleware"
 
@@ -60,17 +63,19 @@ func NewTaskHandler(taskService service.TaskService) *TaskHandler {
 // CreateTaskRequest タスク作成リクエスト構造体
 type CreateTaskRequest struct {
 	ColumnID    uint    `json:"column_id" validate:"required"`
+	LaneID      *uint   `json:"lane_id"` // スイムレーン（省略時はレーンなし）
 	Title       string  `json:"title" validate:"required,min=1,max=100"`
 	Description string  `json:"description"`
 	Order       int     `json:"order" validate:"min=1"`
 	AssigneeID  *string `json:"assignee_id"`
 	DueDate     *string `json:"due_date"`
 	// 新機能用フィールド
-	EstimatedTime  *int    `json:"estimated_time"`  // 目標時間（分）
-	IsCompleted    *bool   `json:"is_completed"`    // 完了状態
-	ScheduledStart *string `json:"scheduled_start"` // スケジュール開始時刻
-	ScheduledEnd   *string `json:"scheduled_end"`   // スケジュール終了時刻
-	CalendarDate   *string `json:"calendar_date"`   // カレンダー配置日
+	EstimatedTime  *int    `json:"estimated_time"`                                                  // 目標時間（分）
+	IsCompleted    *bool   `json:"is_completed"`                                                    // 完了状態
+	ScheduledStart *string `json:"scheduled_start"`                                                 // スケジュール開始時刻
+	ScheduledEnd   *string `json:"scheduled_end"`                                                   // スケジュール終了時刻
+	CalendarDate   *string `json:"calendar_date"`                                                   // カレンダー配置日
+	Priority       string  `json:"priority" validate:"omitempty,oneof=none low medium high urgent"` // 優先度
 }
 
 // UpdateTaskRequest タスク更新リクエスト構造体
@@ -80,21 +85,24 @@ type UpdateTaskRequest struct {
 	AssigneeID  *string `json:"assignee_id"`
 	DueDate     *string `json:"due_date"`
 	// 新機能用フィールド
-	EstimatedTime  *int    `json:"estimated_time"`  // 目標時間（分）
-	IsCompleted    *bool   `json:"is_completed"`    // 完了状態
-	ScheduledStart *string `json:"scheduled_start"` // スケジュール開始時刻
-	ScheduledEnd   *string `json:"scheduled_end"`   // スケジュール終了時刻
-	CalendarDate   *string `json:"calendar_date"`   // カレンダー配置日
+	EstimatedTime  *int    `json:"estimated_time"`                                                  // 目標時間（分）
+	IsCompleted    *bool   `json:"is_completed"`                                                    // 完了状態
+	ScheduledStart *string `json:"scheduled_start"`                                                 // スケジュール開始時刻
+	ScheduledEnd   *string `json:"scheduled_end"`                                                   // スケジュール終了時刻
+	CalendarDate   *string `json:"calendar_date"`                                                   // カレンダー配置日
+	Priority       *string `json:"priority" validate:"omitempty,oneof=none low medium high urgent"` // 優先度
 }
 
 // MoveTaskRequest タスク移動リクエスト構造体
 type MoveTaskRequest struct {
-	NewColumnID uint `json:"new_column_id" validate:"required"`
-	NewOrder    int  `json:"new_order" validate:"min=1"`
+	NewColumnID uint  `json:"new_column_id" validate:"required"`
+	NewLaneID   *uint `json:"new_lane_id"` // 省略時は現在のレーンのまま、0でレーンなし
+	NewOrder    int   `json:"new_order" validate:"min=1"`
 }
 
 // ReorderTasksRequest タスク順序変更リクエスト構造体
 type ReorderTasksRequest struct {
+	LaneID  *uint  `json:"lane_id"` // 対象のレーン（省略時はレーンなしのセル）
 	TaskIDs []uint `json:"task_ids" validate:"required"`
 }
 
@@ -156,8 +164,11 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 	}
 
 	// タスク作成処理
-	task, err := h.taskService.CreateTask(req.ColumnID, userID, req.Title, req.Description, req.Order, assigneeID, dueDate)
+	task, warning, err := h.taskService.CreateTask(req.ColumnID, req.LaneID, userID, req.Title, req.Description, req.Order, assigneeID, dueDate, domain.TaskPriority(req.Priority))
 	if err != nil {
+		if respondWIPLimitError(c, err) {
+			return
+		}
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": err.Error(),
 		})
@@ -165,10 +176,13 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 	}
 
 	// レスポンスを構築
-	response := h.buildTaskResponse(task)
-	c.JSON(http.StatusCreated, gin.H{
-		"task": response,
-	})
+	response := gin.H{
+		"task": h.buildTaskResponse(task),
+	}
+	if warning != nil {
+		response["warning"] = newWIPLimitErrorResponse(warning)
+	}
+	c.JSON(http.StatusCreated, response)
 }
 
 // GetTask タスク取得ハンドラ
@@ -204,6 +218,7 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 
 	// レスポンスを構築
 	response := h.buildTaskResponse(task)
+	setETag(c, task.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"task": response,
 	})
@@ -239,6 +254,12 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 
 	debugLog("タスクID: %d", taskID)
 
+	// If-Matchヘッダーから更新対象のバージョンを取得
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var req UpdateTaskRequest
 
 	// リクエストボディをバインド
@@ -312,6 +333,10 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		updates["estimated_time"] = *req.EstimatedTime
 		debugLog("目標時間更新: %d", *req.EstimatedTime)
 	}
+	if req.Priority != nil {
+		updates["priority"] = *req.Priority
+		debugLog("優先度更新: %s", *req.Priority)
+	}
 	if req.IsCompleted != nil {
 		updates["is_completed"] = *req.IsCompleted
 		debugLog("完了状態更新: %v", *req.IsCompleted)
@@ -347,9 +372,12 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	debugLog("更新データ: %+v", updates)
 
 	// タスク更新処理
-	task, err := h.taskService.UpdateTask(uint(taskID), userID, updates)
+	task, err := h.taskService.UpdateTask(uint(taskID), userID, version, updates)
 	if err != nil {
 		debugError(c, err, "タスク更新")
+		if respondVersionConflict(c, err, h.currentTask(uint(taskID), userID)) {
+			return
+		}
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": err.Error(),
 		})
@@ -360,6 +388,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 
 	// レスポンスを構築
 	response := h.buildTaskResponse(task)
+	setETag(c, task.Version)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -385,8 +414,17 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
+	// If-Matchヘッダーから削除対象のバージョンを取得
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// タスク削除処理
-	if err := h.taskService.DeleteTask(uint(taskID), userID); err != nil {
+	if err := h.taskService.DeleteTask(uint(taskID), userID, version); err != nil {
+		if respondVersionConflict(c, err, h.currentTask(uint(taskID), userID)) {
+			return
+		}
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": err.Error(),
 		})
@@ -438,16 +476,24 @@ func (h *TaskHandler) MoveTask(c *gin.Context) {
 	}
 
 	// タスク移動処理
-	if err := h.taskService.MoveTask(uint(taskID), req.NewColumnID, req.NewOrder, userID); err != nil {
+	warning, err := h.taskService.MoveTask(uint(taskID), req.NewColumnID, req.NewLaneID, req.NewOrder, userID)
+	if err != nil {
+		if respondWIPLimitError(c, err) {
+			return
+		}
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"message": "タスクが正常に移動されました",
-	})
+	}
+	if warning != nil {
+		response["warning"] = newWIPLimitErrorResponse(warning)
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // ReorderTasks タスク順序変更ハンドラ
@@ -492,7 +538,7 @@ func (h *TaskHandler) ReorderTasks(c *gin.Context) {
 	}
 
 	// タスク順序変更処理
-	if err := h.taskService.ReorderTasks(uint(columnID), req.TaskIDs, userID); err != nil {
+	if err := h.taskService.ReorderTasks(uint(columnID), req.LaneID, req.TaskIDs, userID); err != nil {
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": err.Error(),
 		})
@@ -504,36 +550,291 @@ func (h *TaskHandler) ReorderTasks(c *gin.Context) {
 	})
 }
 
+// ListBoardTasks ボード内タスクの絞り込み・並び替えハンドラ
+// GET /api/v1/boards/:id/tasks?priority=high,urgent&cf.<fieldId>=<value>&sort=priority&direction=desc
+func (h *TaskHandler) ListBoardTasks(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardIDStr := c.Param("id")
+	boardID, err := strconv.ParseUint(boardIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	// クエリパラメータから検索条件を構築
+	query, err := parseTaskQuery(c, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	tasks, err := h.taskService.ListBoardTasks(uint(boardID), userID, query)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		response = append(response, h.buildTaskResponse(&tasks[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tasks": response,
+	})
+}
+
+// SearchTasks アクセス可能な全ボードを対象としたタスク検索ハンドラ
+// GET /api/v1/tasks/search?q=<キーワード>&assignee=me&overdue=true&sort=due_date&limit=20&cursor=<カーソル>
+func (h *TaskHandler) SearchTasks(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// クエリパラメータから検索条件を構築
+	query, err := parseTaskQuery(c, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if query.BoardIDs, err = parseUintList(c.Query("board")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "limit には1以上の数値を指定してください",
+			})
+			return
+		}
+		query.Limit = limit
+	}
+
+	tasks, nextCursor, err := h.taskService.SearchTasks(userID, query, c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		response = append(response, h.buildTaskResponse(&tasks[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tasks":       response,
+		"next_cursor": nextCursor,
+	})
+}
+
+// parseTaskQuery クエリパラメータからタスクの絞り込み・並び替え条件を構築します
+// カスタムフィールドの値は文字列のまま渡し、型の変換はサービス層で行います
+func parseTaskQuery(c *gin.Context, userID uuid.UUID) (repository.TaskQuery, error) {
+	var query repository.TaskQuery
+	var err error
+
+	query.Text = strings.TrimSpace(c.Query("q"))
+
+	if query.ColumnIDs, err = parseUintList(c.Query("column")); err != nil {
+		return query, errors.New("不正なカラムIDです")
+	}
+	if query.LabelIDs, err = parseUintList(c.Query("label")); err != nil {
+		return query, errors.New("不正なラベルIDです")
+	}
+
+	// assignee=me で自分が担当のタスクに絞り込む
+	if assignees := c.Query("assignee"); assignees != "" {
+		for _, a := range strings.Split(assignees, ",") {
+			a = strings.TrimSpace(a)
+			if a == "me" {
+				query.AssigneeIDs = append(query.AssigneeIDs, userID)
+				continue
+			}
+			id, err := uuid.Parse(a)
+			if err != nil {
+				return query, errors.New("不正な担当者IDです")
+			}
+			query.AssigneeIDs = append(query.AssigneeIDs, id)
+		}
+	}
+
+	// 期限日の範囲（YYYY-MM-DD形式、due_toの日付を含む）
+	if dueFrom := c.Query("due_from"); dueFrom != "" {
+		parsed, err := time.Parse("2006-01-02", dueFrom)
+		if err != nil {
+			return query, errors.New("不正な期限日の形式です（YYYY-MM-DD形式で入力してください）")
+		}
+		query.DueFrom = &parsed
+	}
+	if dueTo := c.Query("due_to"); dueTo != "" {
+		parsed, err := time.Parse("2006-01-02", dueTo)
+		if err != nil {
+			return query, errors.New("不正な期限日の形式です（YYYY-MM-DD形式で入力してください）")
+		}
+		next := parsed.AddDate(0, 0, 1)
+		query.DueTo = &next
+	}
+
+	if completed := c.Query("completed"); completed != "" {
+		v, err := strconv.ParseBool(completed)
+		if err != nil {
+			return query, errors.New("completed には true または false を指定してください")
+		}
+		query.IsCompleted = &v
+	}
+	if overdue := c.Query("overdue"); overdue != "" {
+		v, err := strconv.ParseBool(overdue)
+		if err != nil {
+			return query, errors.New("overdue には true または false を指定してください")
+		}
+		query.Overdue = v
+	}
+
+	if priorities := c.Query("priority"); priorities != "" {
+		for _, p := range strings.Split(priorities, ",") {
+			query.Priorities = append(query.Priorities, domain.TaskPriority(strings.TrimSpace(p)))
+		}
+	}
+
+	// cf.<フィールドID>=<値> 形式のパラメータをカスタムフィールド条件とする
+	for key, values := range c.Request.URL.Query() {
+		if !strings.HasPrefix(key, "cf.") || len(values) == 0 {
+			continue
+		}
+		fieldID, err := strconv.ParseUint(strings.TrimPrefix(key, "cf."), 10, 32)
+		if err != nil {
+			return query, errors.New("不正なカスタムフィールドIDです")
+		}
+		query.CustomFields = append(query.CustomFields, repository.CustomFieldFilter{
+			FieldID: uint(fieldID),
+			Value:   values[0],
+		})
+	}
+
+	// sort=order|priority|due_date|created_at|cf.<フィールドID>
+	switch sort := c.Query("sort"); {
+	case sort == "":
+	case strings.HasPrefix(sort, "cf."):
+		fieldID, err := strconv.ParseUint(strings.TrimPrefix(sort, "cf."), 10, 32)
+		if err != nil {
+			return query, errors.New("不正なカスタムフィールドIDです")
+		}
+		query.SortBy = repository.TaskSortCustomField
+		query.SortFieldID = uint(fieldID)
+	default:
+		switch key := repository.TaskSortKey(sort); key {
+		case repository.TaskSortOrder, repository.TaskSortPriority, repository.TaskSortDueDate,
+			repository.TaskSortCreatedAt, repository.TaskSortUpdatedAt:
+			query.SortBy = key
+		default:
+			return query, errors.New("不正な並び替えキーです")
+		}
+	}
+
+	switch c.DefaultQuery("direction", "asc") {
+	case "asc":
+	case "desc":
+		query.SortDesc = true
+	default:
+		return query, errors.New("並び順には asc または desc を指定してください")
+	}
+
+	return query, nil
+}
+
+// parseUintList カンマ区切りのID一覧を解析します
+func parseUintList(value string) ([]uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var ids []uint
+	for _, v := range strings.Split(value, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(v), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
 // buildTaskResponse タスクレスポンスを構築するヘルパー関数
 func (h *TaskHandler) buildTaskResponse(task *domain.Task) TaskResponse {
-	response := TaskResponse{
-		ID:             task.ID,
-		ColumnID:       task.ColumnID,
-		Title:          task.Title,
-		Description:    task.Description,
-		Order:          task.Order,
-		DueDate:        task.DueDate,
-		EstimatedTime:  task.EstimatedTime,
-		ActualTime:     task.ActualTime,
-		IsCompleted:    task.IsCompleted,
-		ScheduledStart: task.ScheduledStart,
-		ScheduledEnd:   task.ScheduledEnd,
-		CalendarDate:   task.CalendarDate,
-		CreatedAt:      task.CreatedAt,
-		UpdatedAt:      task.UpdatedAt,
-	}
-
-	// 担当者情報が存在する場合は追加
-	if task.AssigneeID != nil {
-		assigneeIDStr := task.AssigneeID.String()
-		response.AssigneeID = &assigneeIDStr
-	}
-	if task.Assignee != nil {
-		response.Assignee = &UserResponse{
-			ID:    task.Assignee.ID.String(),
-			Email: task.Assignee.Email,
+	return newTaskResponse(task)
+}
+
+// currentTask バージョン競合時に返すタスクの現在の状態を取得する関数を返します
+func (h *TaskHandler) currentTask(taskID uint, userID uuid.UUID) func() (interface{}, int, error) {
+	return func() (interface{}, int, error) {
+		task, err := h.taskService.GetTask(taskID, userID)
+		if err != nil {
+			return nil, 0, err
 		}
+		return h.buildTaskResponse(task), task.Version, nil
+	}
+}
+
+// WIPLimitErrorResponse WIP制限超過のエラー・警告レスポンス構造体
+type WIPLimitErrorResponse struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	ColumnID uint   `json:"column_id"`
+	Limit    int    `json:"limit"`
+	Count    int    `json:"count"`
+	Mode     string `json:"mode"`
+}
+
+// newWIPLimitErrorResponse WIP制限超過のレスポンスを構築します
+func newWIPLimitErrorResponse(wipErr *domain.WIPLimitError) WIPLimitErrorResponse {
+	return WIPLimitErrorResponse{
+		Code:     wipErr.Code(),
+		Message:  wipErr.Error(),
+		ColumnID: wipErr.ColumnID,
+		Limit:    wipErr.Limit,
+		Count:    wipErr.Count,
+		Mode:     string(wipErr.Mode),
 	}
+}
 
-	return response
+// respondWIPLimitError エラーがWIP制限超過の場合は409レスポンスを書き込み、trueを返します
+func respondWIPLimitError(c *gin.Context, err error) bool {
+	var wipErr *domain.WIPLimitError
+	if !errors.As(err, &wipErr) {
+		return false
+	}
+
+	c.JSON(http.StatusConflict, gin.H{
+		"error":   wipErr.Error(),
+		"code":    wipErr.Code(),
+		"details": newWIPLimitErrorResponse(wipErr),
+	})
+	return true
 }
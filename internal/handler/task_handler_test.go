@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -21,9 +22,11 @@ type MockTaskService struct {
 	mock.Mock
 }
 
-func (m *MockTaskService) CreateTask(columnID uint, userID uuid.UUID, title, description string, order int, assigneeID *uuid.UUID, dueDate *time.Time) (*domain.Task, error) {
-	args := m.Called(columnID, userID, title, description, order, assigneeID, dueDate)
-	return args.Get(0).(*domain.Task), args.Error(1)
+func (m *MockTaskService) CreateTask(columnID uint, laneID *uint, userID uuid.UUID, title, description string, order int, assigneeID *uuid.UUID, dueDate *time.Time, priority domain.TaskPriority) (*domain.Task, *domain.WIPLimitError, error) {
+	args := m.Called(columnID, laneID, userID, title, description, order, assigneeID, dueDate, priority)
+	task, _ := args.Get(0).(*domain.Task)
+	warning, _ := args.Get(1).(*domain.WIPLimitError)
+	return task, warning, args.Error(2)
 }
 
 func (m *MockTaskService) GetTask(taskID uint, userID uuid.UUID) (*domain.Task, error) {
@@ -31,26 +34,38 @@ func (m *MockTaskService) GetTask(taskID uint, userID uuid.UUID) (*domain.Task,
 	return args.Get(0).(*domain.Task), args.Error(1)
 }
 
-func (m *MockTaskService) UpdateTask(taskID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Task, error) {
-	args := m.Called(taskID, userID, updates)
-	return args.Get(0).(*domain.Task), args.Error(1)
+func (m *MockTaskService) UpdateTask(taskID uint, userID uuid.UUID, version int, updates map[string]interface{}) (*domain.Task, error) {
+	args := m.Called(taskID, userID, version, updates)
+	task, _ := args.Get(0).(*domain.Task)
+	return task, args.Error(1)
 }
 
-func (m *MockTaskService) DeleteTask(taskID uint, userID uuid.UUID) error {
-	args := m.Called(taskID, userID)
+func (m *MockTaskService) DeleteTask(taskID uint, userID uuid.UUID, version int) error {
+	args := m.Called(taskID, userID, version)
 	return args.Error(0)
 }
 
-func (m *MockTaskService) MoveTask(taskID uint, newColumnID uint, newOrder int, userID uuid.UUID) error {
-	args := m.Called(taskID, newColumnID, newOrder, userID)
-	return args.Error(0)
+func (m *MockTaskService) MoveTask(taskID uint, newColumnID uint, newLaneID *uint, newOrder int, userID uuid.UUID) (*domain.WIPLimitError, error) {
+	args := m.Called(taskID, newColumnID, newLaneID, newOrder, userID)
+	warning, _ := args.Get(0).(*domain.WIPLimitError)
+	return warning, args.Error(1)
 }
 
-func (m *MockTaskService) ReorderTasks(columnID uint, taskIDs []uint, userID uuid.UUID) error {
-	args := m.Called(columnID, taskIDs, userID)
+func (m *MockTaskService) ReorderTasks(columnID uint, laneID *uint, taskIDs []uint, userID uuid.UUID) error {
+	args := m.Called(columnID, laneID, taskIDs, userID)
 	return args.Error(0)
 }
 
+func (m *MockTaskService) ListBoardTasks(boardID uint, userID uuid.UUID, query repository.TaskQuery) ([]domain.Task, error) {
+	args := m.Called(boardID, userID, query)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) SearchTasks(userID uuid.UUID, query repository.TaskQuery, cursor string) ([]domain.Task, string, error) {
+	args := m.Called(userID, query, cursor)
+	return args.Get(0).([]domain.Task), args.String(1), args.Error(2)
+}
+
 // テスト用のヘルパー関数
 func setupTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
@@ -104,10 +119,11 @@ func TestUpdateTask_Success(t *testing.T) {
 		Title:       "更新されたタスク",
 		Description: "更新された説明",
 		DueDate:     parseTime("2024-01-15"),
+		Version:     4,
 	}
 
 	// モックの設定
-	mockService.On("UpdateTask", taskID, userID, mock.AnythingOfType("map[string]interface {}")).Return(expectedTask, nil)
+	mockService.On("UpdateTask", taskID, userID, 3, mock.AnythingOfType("map[string]interface {}")).Return(expectedTask, nil)
 
 	// テスト実行
 	router := setupTestRouter()
@@ -118,12 +134,14 @@ func TestUpdateTask_Success(t *testing.T) {
 
 	req, err := createTestRequest("PUT", "/tasks/1", requestBody)
 	assert.NoError(t, err)
+	req.Header.Set("If-Match", `"3"`)
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	// アサーション
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
 
 	var response map[string]interface{}
 	err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -155,6 +173,7 @@ func TestUpdateTask_ValidationError(t *testing.T) {
 
 	req, err := createTestRequest("PUT", "/tasks/1", requestBody)
 	assert.NoError(t, err)
+	req.Header.Set("If-Match", "*")
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -186,6 +205,7 @@ func TestUpdateTask_JSONBindError(t *testing.T) {
 	req, err := http.NewRequest("PUT", "/tasks/1", bytes.NewBufferString(`{"title": "test"`))
 	assert.NoError(t, err)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", "*")
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -200,6 +220,180 @@ func TestUpdateTask_JSONBindError(t *testing.T) {
 	assert.Contains(t, response["error"], "不正なリクエスト形式")
 }
 
+// If-Matchヘッダーなしの更新は428を返すテスト
+func TestUpdateTask_IfMatchRequired(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	userID := uuid.New()
+
+	router := setupTestRouter()
+	router.PUT("/tasks/:id", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		handler.UpdateTask(c)
+	})
+
+	req, err := createTestRequest("PUT", "/tasks/1", UpdateTaskRequest{Title: stringPtr("更新")})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	mockService.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// バージョン競合時は412と現在の状態を返すテスト
+func TestDeleteTask_VersionConflict(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	userID := uuid.New()
+	taskID := uint(1)
+
+	current := &domain.Task{ID: taskID, Title: "他のユーザーが更新", Version: 5}
+	mockService.On("DeleteTask", taskID, userID, 3).Return(domain.ErrVersionConflict)
+	mockService.On("GetTask", taskID, userID).Return(current, nil)
+
+	router := setupTestRouter()
+	router.DELETE("/tasks/:id", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		handler.DeleteTask(c)
+	})
+
+	req, err := createTestRequest("DELETE", "/tasks/1", nil)
+	assert.NoError(t, err)
+	req.Header.Set("If-Match", `W/"3"`)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, domain.ErrCodeVersionConflict, response["code"])
+	currentTask, ok := response["current"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "他のユーザーが更新", currentTask["title"])
+	assert.Equal(t, float64(5), currentTask["version"])
+
+	mockService.AssertExpectations(t)
+}
+
+// ListBoardTasksのクエリパラメータ解析テスト
+func TestListBoardTasks_ParsesQuery(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	userID := uuid.New()
+
+	expectedQuery := repository.TaskQuery{
+		Priorities: []domain.TaskPriority{domain.TaskPriorityHigh, domain.TaskPriorityUrgent},
+		CustomFields: []repository.CustomFieldFilter{
+			{FieldID: 3, Value: "Acme"},
+		},
+		SortBy:      repository.TaskSortCustomField,
+		SortFieldID: 5,
+		SortDesc:    true,
+	}
+	tasks := []domain.Task{{ID: 1, Title: "タスク", Priority: domain.TaskPriorityHigh}}
+	mockService.On("ListBoardTasks", uint(2), userID, expectedQuery).Return(tasks, nil)
+
+	router := setupTestRouter()
+	router.GET("/boards/:id/tasks", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		handler.ListBoardTasks(c)
+	})
+
+	req, err := createTestRequest("GET", "/boards/2/tasks?priority=high,urgent&cf.3=Acme&sort=cf.5&direction=desc", nil)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string][]map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response["tasks"], 1)
+	assert.Equal(t, "high", response["tasks"][0]["priority"])
+
+	mockService.AssertExpectations(t)
+}
+
+// ListBoardTasksの不正な並び替えキーのテスト
+func TestListBoardTasks_InvalidSort(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	router := setupTestRouter()
+	router.GET("/boards/:id/tasks", func(c *gin.Context) {
+		c.Set("user_id", uuid.New())
+		handler.ListBoardTasks(c)
+	})
+
+	req, err := createTestRequest("GET", "/boards/2/tasks?sort=unknown", nil)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListBoardTasks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// SearchTasksの検索条件とカーソルのテスト
+func TestSearchTasks_Success(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	userID := uuid.New()
+	completed := false
+	dueFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	dueTo := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	expectedQuery := repository.TaskQuery{
+		Text:        "請求書",
+		BoardIDs:    []uint{1, 2},
+		AssigneeIDs: []uuid.UUID{userID},
+		LabelIDs:    []uint{4},
+		DueFrom:     &dueFrom,
+		DueTo:       &dueTo,
+		IsCompleted: &completed,
+		SortBy:      repository.TaskSortDueDate,
+		Limit:       20,
+	}
+	tasks := []domain.Task{{ID: 10, Title: "請求書を送る"}}
+	mockService.On("SearchTasks", userID, expectedQuery, "abc").Return(tasks, "next", nil)
+
+	router := setupTestRouter()
+	router.GET("/tasks/search", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		handler.SearchTasks(c)
+	})
+
+	req, err := createTestRequest("GET", "/tasks/search?q=%E8%AB%8B%E6%B1%82%E6%9B%B8&board=1,2&assignee=me&label=4&due_from=2025-01-01&due_to=2025-01-31&completed=false&sort=due_date&limit=20&cursor=abc", nil)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "next", response["next_cursor"])
+	assert.Len(t, response["tasks"], 1)
+
+	mockService.AssertExpectations(t)
+}
+
 // ベンチマークテスト
 func BenchmarkUpdateTask(b *testing.B) {
 	mockService := new(MockTaskService)
@@ -221,7 +415,7 @@ func BenchmarkUpdateTask(b *testing.B) {
 		DueDate:     parseTime("2024-01-15"),
 	}
 
-	mockService.On("UpdateTask", taskID, userID, mock.AnythingOfType("map[string]interface {}")).Return(expectedTask, nil)
+	mockService.On("UpdateTask", taskID, userID, domain.AnyVersion, mock.AnythingOfType("map[string]interface {}")).Return(expectedTask, nil)
 
 	router := setupTestRouter()
 	router.PUT("/tasks/:id", func(c *gin.Context) {
@@ -233,11 +427,49 @@ func BenchmarkUpdateTask(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		req, _ := createTestRequest("PUT", "/tasks/1", requestBody)
+		req.Header.Set("If-Match", "*")
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 	}
 }
 
+// MoveTaskでWIP制限（hard）を超えた場合のテスト
+func TestMoveTask_WIPLimitExceeded(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	userID := uuid.New()
+	wipErr := &domain.WIPLimitError{ColumnID: 2, Limit: 3, Count: 4, Mode: domain.WIPLimitModeHard}
+
+	mockService.On("MoveTask", uint(1), uint(2), (*uint)(nil), 1, userID).Return(nil, wipErr)
+
+	router := setupTestRouter()
+	router.PUT("/tasks/:id/move", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		handler.MoveTask(c)
+	})
+
+	req, err := createTestRequest("PUT", "/tasks/1/move", MoveTaskRequest{NewColumnID: 2, NewOrder: 1})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// アサーション
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, domain.ErrCodeWIPLimitExceeded, response["code"])
+	details := response["details"].(map[string]interface{})
+	assert.Equal(t, float64(3), details["limit"])
+	assert.Equal(t, float64(4), details["count"])
+
+	mockService.AssertExpectations(t)
+}
+
 // ヘルパー関数
 func stringPtr(s string) *string {
 	return &s
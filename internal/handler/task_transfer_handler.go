@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// TaskTransferHandler タスクのボード間移動・履歴関連のHTTPハンドラ
+type TaskTransferHandler struct {
+	transferService service.TaskTransferService
+	activityService service.TaskActivityService
+	validator       *validator.Validate
+}
+
+// NewTaskTransferHandler TaskTransferHandlerの新しいインスタンスを作成
+func NewTaskTransferHandler(transferService service.TaskTransferService, activityService service.TaskActivityService) *TaskTransferHandler {
+	return &TaskTransferHandler{
+		transferService: transferService,
+		activityService: activityService,
+		validator:       validator.New(),
+	}
+}
+
+// MoveTaskToBoardRequest ボード間移動リクエスト構造体
+type MoveTaskToBoardRequest struct {
+	ColumnID          uint   `json:"column_id" validate:"required"`                           // 移動先ボードのカラム
+	LabelPolicy       string `json:"label_policy" validate:"omitempty,oneof=map drop"`        // ラベルの扱い（省略時はmap）
+	CustomFieldPolicy string `json:"custom_field_policy" validate:"omitempty,oneof=map drop"` // カスタムフィールドの扱い（省略時はmap）
+}
+
+// MoveTaskToBoardResponse ボード間移動レスポンス構造体
+type MoveTaskToBoardResponse struct {
+	Task                TaskResponse           `json:"task"`
+	MappedLabels        []string               `json:"mapped_labels"`
+	DroppedLabels       []string               `json:"dropped_labels"`
+	MappedCustomFields  []string               `json:"mapped_custom_fields"`
+	DroppedCustomFields []string               `json:"dropped_custom_fields"`
+	Warning             *WIPLimitErrorResponse `json:"warning,omitempty"`
+}
+
+// MoveTaskToBoard タスクを別のボードへ移動するハンドラ
+// POST /api/v1/tasks/:id/move-to-board
+func (h *TaskTransferHandler) MoveTaskToBoard(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからタスクIDを取得
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なタスクIDです",
+		})
+		return
+	}
+
+	var req MoveTaskToBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.transferService.MoveToBoard(uint(taskID), req.ColumnID, userID, service.TaskTransferOptions{
+		LabelPolicy:       service.TaskTransferPolicy(req.LabelPolicy),
+		CustomFieldPolicy: service.TaskTransferPolicy(req.CustomFieldPolicy),
+	})
+	if err != nil {
+		if respondWIPLimitError(c, err) {
+			return
+		}
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := MoveTaskToBoardResponse{
+		Task:                newTaskResponse(result.Task),
+		MappedLabels:        nonNilStrings(result.MappedLabels),
+		DroppedLabels:       nonNilStrings(result.DroppedLabels),
+		MappedCustomFields:  nonNilStrings(result.MappedCustomFields),
+		DroppedCustomFields: nonNilStrings(result.DroppedCustomFields),
+	}
+	if result.Warning != nil {
+		warning := newWIPLimitErrorResponse(result.Warning)
+		response.Warning = &warning
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTaskHistory タスクの履歴一覧を取得するハンドラ
+// GET /api/v1/tasks/:id/history
+func (h *TaskTransferHandler) GetTaskHistory(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからタスクIDを取得
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なタスクIDです",
+		})
+		return
+	}
+
+	activities, err := h.activityService.GetTaskActivities(uint(taskID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	response := make([]TaskActivityResponse, 0, len(activities))
+	for _, activity := range activities {
+		item := TaskActivityResponse{TaskActivity: activity}
+		if activity.User != nil {
+			user := newPublicUserResponse(activity.User)
+			item.User = &user
+		}
+		response = append(response, item)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"history": response,
+	})
+}
+
+// TaskActivityResponse タスクの履歴レスポンス構造体（操作したユーザーの表示名を含む）
+type TaskActivityResponse struct {
+	domain.TaskActivity
+	User *UserResponse `json:"user,omitempty"`
+}
+
+// nonNilStrings JSONで null ではなく空配列を返すために nil を空スライスに置き換えます
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
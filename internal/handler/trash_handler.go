@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashHandler ゴミ箱関連のHTTPハンドラ
+type TrashHandler struct {
+	trashService service.TrashService
+}
+
+// NewTrashHandler TrashHandlerの新しいインスタンスを作成
+func NewTrashHandler(trashService service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// TrashItemResponse ゴミ箱の項目レスポンス構造体
+type TrashItemResponse struct {
+	ID        uint      `json:"id"`
+	BoardID   uint      `json:"board_id,omitempty"`
+	ColumnID  uint      `json:"column_id,omitempty"`
+	Title     string    `json:"title"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TrashResponse ゴミ箱レスポンス構造体
+type TrashResponse struct {
+	Boards  []TrashItemResponse `json:"boards,omitempty"`
+	Columns []TrashItemResponse `json:"columns"`
+	Tasks   []TrashItemResponse `json:"tasks"`
+}
+
+// GetUserTrash ユーザーのゴミ箱取得ハンドラ
+// GET /api/v1/trash
+func (h *TrashHandler) GetUserTrash(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	trash, err := h.trashService.GetUserTrash(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := newTrashResponse(trash)
+	if response.Boards == nil {
+		response.Boards = []TrashItemResponse{}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBoardTrash ボードのゴミ箱取得ハンドラ
+// GET /api/v1/boards/:id/trash
+func (h *TrashHandler) GetBoardTrash(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	trash, err := h.trashService.GetBoardTrash(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTrashResponse(trash))
+}
+
+// RestoreBoard ボード復元ハンドラ
+// POST /api/v1/trash/boards/:id/restore
+func (h *TrashHandler) RestoreBoard(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	board, err := h.trashService.RestoreBoard(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"board": newBoardResponse(board),
+	})
+}
+
+// RestoreColumn カラム復元ハンドラ
+// POST /api/v1/trash/columns/:id/restore
+func (h *TrashHandler) RestoreColumn(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからカラムIDを取得
+	columnID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なカラムIDです",
+		})
+		return
+	}
+
+	column, err := h.trashService.RestoreColumn(uint(columnID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"column": newColumnResponse(column),
+	})
+}
+
+// RestoreTask タスク復元ハンドラ
+// POST /api/v1/trash/tasks/:id/restore
+func (h *TrashHandler) RestoreTask(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからタスクIDを取得
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なタスクIDです",
+		})
+		return
+	}
+
+	task, err := h.trashService.RestoreTask(uint(taskID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task": newTaskResponse(task),
+	})
+}
+
+// newTrashResponse ゴミ箱の内容からレスポンスを構築します
+func newTrashResponse(trash *service.Trash) TrashResponse {
+	response := TrashResponse{
+		Columns: make([]TrashItemResponse, 0, len(trash.Columns)),
+		Tasks:   make([]TrashItemResponse, 0, len(trash.Tasks)),
+	}
+
+	for _, board := range trash.Boards {
+		response.Boards = append(response.Boards, TrashItemResponse{
+			ID:        board.ID,
+			Title:     board.Name,
+			DeletedAt: board.DeletedAt.Time,
+		})
+	}
+	for _, column := range trash.Columns {
+		response.Columns = append(response.Columns, TrashItemResponse{
+			ID:        column.ID,
+			BoardID:   column.BoardID,
+			Title:     column.Title,
+			DeletedAt: column.DeletedAt.Time,
+		})
+	}
+	for _, task := range trash.Tasks {
+		response.Tasks = append(response.Tasks, TrashItemResponse{
+			ID:        task.ID,
+			BoardID:   task.Column.BoardID,
+			ColumnID:  task.ColumnID,
+			Title:     task.Title,
+			DeletedAt: task.DeletedAt.Time,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TwoFactorCodeRequest 二要素認証のコード（認証アプリのコードまたはリカバリーコード）のリクエスト構造体
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// TwoFactorVerifyRequest ログイン時の二要素認証リクエスト構造体
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+// SetupTwoFactor 二要素認証の設定開始ハンドラ（シークレットの発行）
+// POST /api/v1/auth/2fa/setup
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	secret, uri, err := h.userService.SetupTwoFactor(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"secret":      secret,
+		"otpauth_uri": uri,
+	})
+}
+
+// EnableTwoFactor 二要素認証の有効化ハンドラ
+// POST /api/v1/auth/2fa/enable
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	userID, req, ok := h.bindTwoFactorCode(c)
+	if !ok {
+		return
+	}
+
+	codes, err := h.userService.EnableTwoFactor(userID, req.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactor 二要素認証の無効化ハンドラ
+// POST /api/v1/auth/2fa/disable
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	userID, req, ok := h.bindTwoFactorCode(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.DisableTwoFactor(userID, req.Code); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "二要素認証を無効にしました",
+	})
+}
+
+// RegenerateRecoveryCodes リカバリーコードの再発行ハンドラ
+// POST /api/v1/auth/2fa/recovery-codes
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, req, ok := h.bindTwoFactorCode(c)
+	if !ok {
+		return
+	}
+
+	codes, err := h.userService.RegenerateRecoveryCodes(userID, req.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recovery_codes": codes,
+	})
+}
+
+// VerifyTwoFactor ログイン時の二要素認証ハンドラ
+// POST /api/v1/auth/2fa/verify
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, token, err := h.userService.VerifyTwoFactor(req.ChallengeToken, req.Code)
+	if writeAccountLocked(c, err) {
+		return
+	}
+	if err != nil {
+		// データベースなどのエラー（ラップされたエラー）はサーバーエラーとする
+		status := http.StatusUnauthorized
+		if !errors.Is(err, service.ErrInvalidTwoFactorCode) && errors.Unwrap(err) != nil {
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, AuthResponse{
+		User:  newUserResponse(user),
+		Token: token,
+	})
+}
+
+// bindTwoFactorCode 認証済みのユーザーIDとコードのリクエストを取得します（失敗した場合はレスポンスを返してfalse）
+func (h *AuthHandler) bindTwoFactorCode(c *gin.Context) (uuid.UUID, TwoFactorCodeRequest, bool) {
+	var req TwoFactorCodeRequest
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return uuid.Nil, req, false
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return uuid.Nil, req, false
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return uuid.Nil, req, false
+	}
+	return userID, req, true
+}
@@ -0,0 +1,323 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// WebhookHandler Webhook関連のHTTPハンドラ
+type WebhookHandler struct {
+	webhookService service.WebhookService
+	validator      *validator.Validate
+}
+
+// NewWebhookHandler WebhookHandlerの新しいインスタンスを作成
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		validator:      validator.New(),
+	}
+}
+
+// CreateWebhookRequest Webhook作成リクエスト構造体
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2048"`
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=256"` // 省略時は自動生成
+	Events []string `json:"events"`                                     // 省略時はすべてのイベント
+}
+
+// UpdateWebhookRequest Webhook更新リクエスト構造体
+type UpdateWebhookRequest struct {
+	URL    *string   `json:"url" validate:"omitempty,url,max=2048"`
+	Secret *string   `json:"secret" validate:"omitempty,min=16,max=256"`
+	Events *[]string `json:"events"`
+	Active *bool     `json:"active"`
+}
+
+// WebhookResponse Webhook情報レスポンス構造体
+type WebhookResponse struct {
+	ID        uint      `json:"id"`
+	BoardID   uint      `json:"board_id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"` // 作成時のみ返す
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newWebhookResponse WebhookをWebhookResponseに変換します（シークレットは含めない）
+func newWebhookResponse(webhook *domain.Webhook) WebhookResponse {
+	events := []string(webhook.Events)
+	if events == nil {
+		events = []string{}
+	}
+	return WebhookResponse{
+		ID:        webhook.ID,
+		BoardID:   webhook.BoardID,
+		URL:       webhook.URL,
+		Events:    events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// CreateWebhook Webhook作成ハンドラ
+// POST /api/v1/boards/:id/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	var req CreateWebhookRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(uint(boardID), userID, req.URL, req.Secret, req.Events)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// シークレットは受信側で署名を検証するために必要なため、作成時のみ返す
+	response := newWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook": response,
+	})
+}
+
+// GetBoardWebhooks ボードのWebhook一覧取得ハンドラ
+// GET /api/v1/boards/:id/webhooks
+func (h *WebhookHandler) GetBoardWebhooks(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからボードIDを取得
+	boardID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なボードIDです",
+		})
+		return
+	}
+
+	webhooks, err := h.webhookService.GetBoardWebhooks(uint(boardID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]WebhookResponse, 0, len(webhooks))
+	for i := range webhooks {
+		response = append(response, newWebhookResponse(&webhooks[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhooks": response,
+	})
+}
+
+// UpdateWebhook Webhook更新ハンドラ
+// PUT /api/v1/webhooks/:id
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからWebhook IDを取得
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なWebhook IDです",
+		})
+		return
+	}
+
+	var req UpdateWebhookRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return
+	}
+
+	// バリデーション
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(uint(webhookID), userID, service.WebhookUpdate{
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+		Active: req.Active,
+	})
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhook": newWebhookResponse(webhook),
+	})
+}
+
+// DeleteWebhook Webhook削除ハンドラ
+// DELETE /api/v1/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからWebhook IDを取得
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なWebhook IDです",
+		})
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(uint(webhookID), userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// PingWebhook 疎通確認用のpingイベントを送信するハンドラ
+// POST /api/v1/webhooks/:id/ping
+func (h *WebhookHandler) PingWebhook(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからWebhook IDを取得
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なWebhook IDです",
+		})
+		return
+	}
+
+	if err := h.webhookService.PingWebhook(uint(webhookID), userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "pingイベントを配信キューに追加しました",
+	})
+}
+
+// GetDeliveries Webhookの配信ログ取得ハンドラ
+// GET /api/v1/webhooks/:id/deliveries
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	// パスパラメータからWebhook IDを取得
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なWebhook IDです",
+		})
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(uint(webhookID), userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+	})
+}
@@ -0,0 +1,396 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/service"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// WorkspaceHandler ワークスペース関連のHTTPハンドラ
+type WorkspaceHandler struct {
+	workspaceService service.WorkspaceService
+	validator        *validator.Validate
+}
+
+// NewWorkspaceHandler WorkspaceHandlerの新しいインスタンスを作成
+func NewWorkspaceHandler(workspaceService service.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+		validator:        validator.New(),
+	}
+}
+
+// WorkspaceRequest ワークスペース作成・更新リクエスト構造体
+type WorkspaceRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+// AddWorkspaceMemberRequest メンバー追加リクエスト構造体
+type AddWorkspaceMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"omitempty,oneof=admin member"` // 省略時は member
+}
+
+// UpdateWorkspaceMemberRequest メンバーの役割変更リクエスト構造体
+type UpdateWorkspaceMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin member"`
+}
+
+// WorkspaceResponse ワークスペース情報レスポンス構造体
+type WorkspaceResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Personal  bool      `json:"personal"`
+	Role      string    `json:"role,omitempty"` // リクエストしたユーザーの役割
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WorkspaceMemberResponse ワークスペースのメンバー情報レスポンス構造体
+type WorkspaceMemberResponse struct {
+	User     UserResponse `json:"user"`
+	Role     string       `json:"role"`
+	JoinedAt time.Time    `json:"joined_at"`
+}
+
+// newWorkspaceResponse ワークスペースのレスポンスを作成します（userIDのメンバー情報を読み込んでいる場合は役割も含める）
+func newWorkspaceResponse(workspace *domain.Workspace, userID uuid.UUID) WorkspaceResponse {
+	response := WorkspaceResponse{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Personal:  workspace.Personal,
+		CreatedAt: workspace.CreatedAt,
+		UpdatedAt: workspace.UpdatedAt,
+	}
+	for _, member := range workspace.Members {
+		if member.UserID == userID {
+			response.Role = string(member.Role)
+		}
+	}
+	return response
+}
+
+// newWorkspaceMemberResponse メンバーのレスポンスを作成します
+func newWorkspaceMemberResponse(member *domain.WorkspaceMember) WorkspaceMemberResponse {
+	response := WorkspaceMemberResponse{
+		User:     UserResponse{ID: member.UserID.String()},
+		Role:     string(member.Role),
+		JoinedAt: member.CreatedAt,
+	}
+	if member.User != nil {
+		response.User = newPublicUserResponse(member.User)
+	}
+	return response
+}
+
+// CreateWorkspace ワークスペース作成ハンドラ
+// POST /api/v1/workspaces
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	var req WorkspaceRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	workspace, err := h.workspaceService.CreateWorkspace(userID, req.Name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"workspace": newWorkspaceResponse(workspace, userID),
+	})
+}
+
+// GetWorkspaces ユーザーがメンバーのワークスペース一覧取得ハンドラ
+// GET /api/v1/workspaces
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	workspaces, err := h.workspaceService.GetUserWorkspaces(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]WorkspaceResponse, 0, len(workspaces))
+	for i := range workspaces {
+		response = append(response, newWorkspaceResponse(&workspaces[i], userID))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workspaces": response,
+	})
+}
+
+// UpdateWorkspace ワークスペース更新ハンドラ（管理者のみ）
+// PUT /api/v1/workspaces/:id
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	var req WorkspaceRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	workspace, err := h.workspaceService.UpdateWorkspace(workspaceID, userID, req.Name)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workspace": newWorkspaceResponse(workspace, userID),
+	})
+}
+
+// DeleteWorkspace ワークスペース削除ハンドラ（管理者のみ）
+// DELETE /api/v1/workspaces/:id
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.DeleteWorkspace(workspaceID, userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetMembers ワークスペースのメンバー一覧取得ハンドラ
+// GET /api/v1/workspaces/:id/members
+func (h *WorkspaceHandler) GetMembers(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.workspaceService.GetMembers(workspaceID, userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]WorkspaceMemberResponse, 0, len(members))
+	for i := range members {
+		response = append(response, newWorkspaceMemberResponse(&members[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"members": response,
+	})
+}
+
+// AddMember ワークスペースへのメンバー追加ハンドラ（管理者のみ）
+// POST /api/v1/workspaces/:id/members
+func (h *WorkspaceHandler) AddMember(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	var req AddWorkspaceMemberRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	member, err := h.workspaceService.AddMember(workspaceID, userID, req.Email, domain.WorkspaceRole(req.Role))
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"member": newWorkspaceMemberResponse(member),
+	})
+}
+
+// UpdateMember メンバーの役割変更ハンドラ（管理者のみ）
+// PUT /api/v1/workspaces/:id/members/:user_id
+func (h *WorkspaceHandler) UpdateMember(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+	memberID, ok := parseMemberID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateWorkspaceMemberRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	member, err := h.workspaceService.UpdateMemberRole(workspaceID, userID, memberID, domain.WorkspaceRole(req.Role))
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"member": newWorkspaceMemberResponse(member),
+	})
+}
+
+// RemoveMember メンバー削除ハンドラ（管理者、または本人の退出）
+// DELETE /api/v1/workspaces/:id/members/:user_id
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	// JWT認証ミドルウェアからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "認証情報が取得できません",
+		})
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+	memberID, ok := parseMemberID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.RemoveMember(workspaceID, userID, memberID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// bind リクエストボディをバインドしてバリデーションします（失敗した場合は400を返し、falseを返します）
+func (h *WorkspaceHandler) bind(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なリクエスト形式です",
+		})
+		return false
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "バリデーションエラー",
+			"details": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// parseWorkspaceID パスパラメータからワークスペースIDを取得します
+func parseWorkspaceID(c *gin.Context) (uint, bool) {
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なワークスペースIDです",
+		})
+		return 0, false
+	}
+	return uint(workspaceID), true
+}
+
+// parseMemberID パスパラメータからメンバーのユーザーIDを取得します
+func parseMemberID(c *gin.Context) (uuid.UUID, bool) {
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "不正なユーザーIDです",
+		})
+		return uuid.Nil, false
+	}
+	return memberID, true
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccountRepository アカウントの削除に伴うユーザーのデータのアクセスを管理するインターフェース
+type AccountRepository interface {
+	GetSoleMemberWorkspaceIDs(userID uuid.UUID) ([]uint, error)
+	GetSoleAdminWorkspaces(userID uuid.UUID) ([]domain.Workspace, error)
+	SharesWorkspace(userID, otherID uuid.UUID) (bool, error)
+	GetBoardIDs(workspaceIDs []uint) ([]uint, error)
+	MoveBoards(boardIDs []uint, workspaceID uint) (int64, error)
+	DeleteWorkspaces(workspaceIDs []uint) error
+	DeleteUserData(userID uuid.UUID) error
+}
+
+// accountRepository AccountRepositoryの実装
+type accountRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountRepository AccountRepositoryの新しいインスタンスを作成
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+// GetSoleMemberWorkspaceIDs ユーザーだけがメンバーのワークスペース（個人用を含む）のID一覧を取得します
+func (r *accountRepository) GetSoleMemberWorkspaceIDs(userID uuid.UUID) ([]uint, error) {
+	var workspaceIDs []uint
+	result := r.db.Model(&domain.WorkspaceMember{}).
+		Where("workspace_id IN (?)", memberWorkspaceIDs(r.db, userID)).
+		Group("workspace_id").Having("COUNT(*) = 1").
+		Pluck("workspace_id", &workspaceIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return workspaceIDs, nil
+}
+
+// GetSoleAdminWorkspaces ユーザーが唯一の管理者で、他のメンバーもいるワークスペースの一覧を取得します
+func (r *accountRepository) GetSoleAdminWorkspaces(userID uuid.UUID) ([]domain.Workspace, error) {
+	admins := r.db.Model(&domain.WorkspaceMember{}).Select("workspace_id").
+		Where("role = ?", domain.WorkspaceRoleAdmin).
+		Group("workspace_id").Having("COUNT(*) = 1")
+	shared := r.db.Model(&domain.WorkspaceMember{}).Select("workspace_id").
+		Group("workspace_id").Having("COUNT(*) > 1")
+
+	var workspaces []domain.Workspace
+	result := r.db.Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ? AND workspace_members.role = ?", userID, domain.WorkspaceRoleAdmin).
+		Where("workspaces.id IN (?) AND workspaces.id IN (?)", admins, shared).
+		Order("workspaces.name ASC").
+		Find(&workspaces)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return workspaces, nil
+}
+
+// SharesWorkspace 2人のユーザーが同じワークスペースのメンバーかどうかを判定します
+func (r *accountRepository) SharesWorkspace(userID, otherID uuid.UUID) (bool, error) {
+	var count int64
+	result := r.db.Model(&domain.WorkspaceMember{}).
+		Where("user_id = ? AND workspace_id IN (?)", otherID, memberWorkspaceIDs(r.db, userID)).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// GetBoardIDs ワークスペースのボードのID一覧を取得します（ゴミ箱のボードも含みます）
+func (r *accountRepository) GetBoardIDs(workspaceIDs []uint) ([]uint, error) {
+	var boardIDs []uint
+	if len(workspaceIDs) == 0 {
+		return boardIDs, nil
+	}
+	result := r.db.Unscoped().Model(&domain.Board{}).Where("workspace_id IN ?", workspaceIDs).Pluck("id", &boardIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return boardIDs, nil
+}
+
+// MoveBoards ボードを別のワークスペースに移します（ゴミ箱のボードも含みます）
+// 移動前のETagで更新できないよう、バージョンを進めます
+func (r *accountRepository) MoveBoards(boardIDs []uint, workspaceID uint) (int64, error) {
+	if len(boardIDs) == 0 {
+		return 0, nil
+	}
+	result := r.db.Unscoped().Model(&domain.Board{}).Where("id IN ?", boardIDs).
+		Updates(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"version":      gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteWorkspaces ワークスペースをメンバーとともに削除します
+func (r *accountRepository) DeleteWorkspaces(workspaceIDs []uint) error {
+	if len(workspaceIDs) == 0 {
+		return nil
+	}
+	if err := r.db.Where("workspace_id IN ?", workspaceIDs).Delete(&domain.WorkspaceMember{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("id IN ?", workspaceIDs).Delete(&domain.Workspace{}).Error
+}
+
+// DeleteUserData ユーザー個人のデータ（タイマー、予定、設定、テンプレート、トークン、ワークスペースのメンバー情報等）を完全に削除します
+// 他のユーザーのボードのタスクの担当者からは外します
+func (r *accountRepository) DeleteUserData(userID uuid.UUID) error {
+	if err := r.db.Unscoped().Model(&domain.Task{}).Where("assignee_id = ?", userID).
+		Update("assignee_id", nil).Error; err != nil {
+		return err
+	}
+
+	models := []interface{}{
+		&domain.TimerSession{},
+		&domain.CalendarEvent{},
+		&domain.CalendarSettings{},
+		&domain.PersonalAccessToken{},
+		&domain.UserIdentity{},
+		&domain.AccountToken{},
+		&domain.WorkspaceMember{},
+	}
+	for _, model := range models {
+		if err := r.db.Unscoped().Where("user_id = ?", userID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return r.db.Unscoped().Where("owner_id = ?", userID).Delete(&domain.BoardTemplate{}).Error
+}
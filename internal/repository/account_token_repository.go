@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AccountTokenRepository パスワードの再設定・メールアドレスの確認のトークンのデータアクセスを管理するインターフェース
+type AccountTokenRepository interface {
+	Create(token *domain.AccountToken) error
+	Consume(purpose domain.AccountTokenPurpose, tokenHash string, now time.Time) (*domain.AccountToken, error)
+	InvalidateByUser(userID uuid.UUID, purpose domain.AccountTokenPurpose, now time.Time) error
+	DeleteExpired(now time.Time) error
+}
+
+// accountTokenRepository AccountTokenRepositoryの実装
+type accountTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountTokenRepository AccountTokenRepositoryの新しいインスタンスを作成
+func NewAccountTokenRepository(db *gorm.DB) AccountTokenRepository {
+	return &accountTokenRepository{db: db}
+}
+
+// Create トークンを保存します
+func (r *accountTokenRepository) Create(token *domain.AccountToken) error {
+	return r.db.Create(token).Error
+}
+
+// Consume 未使用で有効期限内のトークンを使用済みにして返します（同じトークンは1回のみ使用できる）
+// 見つからない場合はnilを返します
+func (r *accountTokenRepository) Consume(purpose domain.AccountTokenPurpose, tokenHash string, now time.Time) (*domain.AccountToken, error) {
+	var tokens []domain.AccountToken
+	result := r.db.Model(&tokens).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return &tokens[0], nil
+}
+
+// InvalidateByUser ユーザーの未使用のトークンを使用済みにします（新しいトークンの発行時や、パスワードの再設定後に使用）
+func (r *accountTokenRepository) InvalidateByUser(userID uuid.UUID, purpose domain.AccountTokenPurpose, now time.Time) error {
+	return r.db.Model(&domain.AccountToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", now).Error
+}
+
+// DeleteExpired 有効期限を過ぎたトークンを削除します
+func (r *accountTokenRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&domain.AccountToken{}).Error
+}
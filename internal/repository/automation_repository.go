@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// AutomationRepository 自動化ルールと実行ログのデータアクセスを管理するインターフェース
+type AutomationRepository interface {
+	CreateRule(rule *domain.AutomationRule) error
+	GetRuleByID(id uint) (*domain.AutomationRule, error)
+	GetRulesByBoardID(boardID uint) ([]domain.AutomationRule, error)
+	GetEnabledRules(boardID uint, trigger domain.AutomationTrigger) ([]domain.AutomationRule, error)
+	GetEnabledRulesByTrigger(trigger domain.AutomationTrigger) ([]domain.AutomationRule, error)
+	UpdateRule(rule *domain.AutomationRule) error
+	DeleteRule(id uint) error
+	CreateExecution(execution *domain.AutomationExecution) error
+	GetExecutionsByBoardID(boardID uint, limit int) ([]domain.AutomationExecution, error)
+	HasExecutionSince(ruleID, taskID uint, since time.Time) (bool, error)
+}
+
+// automationRepository AutomationRepositoryの実装
+type automationRepository struct {
+	db *gorm.DB
+}
+
+// NewAutomationRepository AutomationRepositoryの新しいインスタンスを作成
+func NewAutomationRepository(db *gorm.DB) AutomationRepository {
+	return &automationRepository{db: db}
+}
+
+// CreateRule 新しい自動化ルールを作成します
+func (r *automationRepository) CreateRule(rule *domain.AutomationRule) error {
+	return r.db.Create(rule).Error
+}
+
+// GetRuleByID IDで自動化ルールを取得します
+func (r *automationRepository) GetRuleByID(id uint) (*domain.AutomationRule, error) {
+	var rule domain.AutomationRule
+	result := r.db.Where("id = ?", id).First(&rule)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // ルールが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &rule, nil
+}
+
+// GetRulesByBoardID ボードの自動化ルール一覧を取得します（作成順）
+func (r *automationRepository) GetRulesByBoardID(boardID uint) ([]domain.AutomationRule, error) {
+	var rules []domain.AutomationRule
+	result := r.db.Where("board_id = ?", boardID).Order("id ASC").Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rules, nil
+}
+
+// GetEnabledRules ボードの有効なルールのうち、指定したトリガーのものを取得します（作成順）
+func (r *automationRepository) GetEnabledRules(boardID uint, trigger domain.AutomationTrigger) ([]domain.AutomationRule, error) {
+	var rules []domain.AutomationRule
+	result := r.db.Where("board_id = ? AND trigger = ? AND enabled = ?", boardID, trigger, true).
+		Order("id ASC").
+		Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rules, nil
+}
+
+// GetEnabledRulesByTrigger すべてのボードの有効なルールのうち、指定したトリガーのものを取得します
+func (r *automationRepository) GetEnabledRulesByTrigger(trigger domain.AutomationTrigger) ([]domain.AutomationRule, error) {
+	var rules []domain.AutomationRule
+	result := r.db.Where("trigger = ? AND enabled = ?", trigger, true).Order("id ASC").Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rules, nil
+}
+
+// UpdateRule 自動化ルールを更新します
+func (r *automationRepository) UpdateRule(rule *domain.AutomationRule) error {
+	return r.db.Save(rule).Error
+}
+
+// DeleteRule 自動化ルールと実行ログを削除します
+func (r *automationRepository) DeleteRule(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rule_id = ?", id).Delete(&domain.AutomationExecution{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.AutomationRule{}, id).Error
+	})
+}
+
+// CreateExecution 実行ログを記録します
+func (r *automationRepository) CreateExecution(execution *domain.AutomationExecution) error {
+	return r.db.Create(execution).Error
+}
+
+// GetExecutionsByBoardID ボードの実行ログを新しい順に取得します
+func (r *automationRepository) GetExecutionsByBoardID(boardID uint, limit int) ([]domain.AutomationExecution, error) {
+	var executions []domain.AutomationExecution
+	result := r.db.Where("board_id = ?", boardID).Order("id DESC").Limit(limit).Find(&executions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return executions, nil
+}
+
+// HasExecutionSince 指定した日時以降にルールをタスクに実行したかどうかを判定します（スキップした実行は除く）
+func (r *automationRepository) HasExecutionSince(ruleID, taskID uint, since time.Time) (bool, error) {
+	var count int64
+	result := r.db.Model(&domain.AutomationExecution{}).
+		Where("rule_id = ? AND task_id = ? AND status <> ? AND created_at >= ?", ruleID, taskID, domain.AutomationExecutionSkipped, since).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
@@ -11,10 +11,12 @@ import (
 type BoardRepository interface {
 	Create(board *domain.Board) error
 	GetByID(id uint) (*domain.Board, error)
-	GetByOwnerID(ownerID uuid.UUID) ([]domain.Board, error)
+	GetByUserID(userID uuid.UUID) ([]domain.Board, error)
+	GetByWorkspaceID(workspaceID uint) ([]domain.Board, error)
+	IsAccessibleBy(boardID uint, userID uuid.UUID) (bool, error)
 	GetByIDWithColumns(id uint) (*domain.Board, error)
 	Update(board *domain.Board) error
-	Delete(id uint) error
+	Delete(id uint, version int) error
 	List(limit, offset int) ([]domain.Board, error)
 }
 
@@ -50,24 +52,52 @@ func (r *boardRepository) GetByID(id uint) (*domain.Board, error) {
 	return &board, nil
 }
 
-// GetByOwnerID 所有者IDでボード一覧を取得します
-func (r *boardRepository) GetByOwnerID(ownerID uuid.UUID) ([]domain.Board, error) {
+// GetByUserID ユーザーがメンバーのワークスペースのボード一覧を取得します
+func (r *boardRepository) GetByUserID(userID uuid.UUID) ([]domain.Board, error) {
 	var boards []domain.Board
-	result := r.db.Where("owner_id = ?", ownerID).Find(&boards)
+	result := r.db.Preload("Owner").Where("workspace_id IN (?)", memberWorkspaceIDs(r.db, userID)).Find(&boards)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return boards, nil
 }
 
+// GetByWorkspaceID ワークスペースのボード一覧を取得します
+func (r *boardRepository) GetByWorkspaceID(workspaceID uint) ([]domain.Board, error) {
+	var boards []domain.Board
+	result := r.db.Preload("Owner").Where("workspace_id = ?", workspaceID).Find(&boards)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return boards, nil
+}
+
+// IsAccessibleBy ユーザーがボードのワークスペースのメンバーかどうかを判定します（ゴミ箱のボードも対象です）
+func (r *boardRepository) IsAccessibleBy(boardID uint, userID uuid.UUID) (bool, error) {
+	var count int64
+	result := r.db.Table("boards").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = boards.workspace_id").
+		Where("boards.id = ? AND workspace_members.user_id = ?", boardID, userID).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// memberWorkspaceIDs ユーザーがメンバーのワークスペースのIDを返すサブクエリ
+func memberWorkspaceIDs(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Model(&domain.WorkspaceMember{}).Select("workspace_id").Where("user_id = ?", userID)
+}
+
 // GetByIDWithColumns IDでボードを取得し、カラム情報も含めます
 func (r *boardRepository) GetByIDWithColumns(id uint) (*domain.Board, error) {
 	var board domain.Board
 	result := r.db.Preload("Columns", func(db *gorm.DB) *gorm.DB {
 		return db.Order("columns.\"order\" ASC")
 	}).Preload("Columns.Tasks", func(db *gorm.DB) *gorm.DB {
-		return db.Order("tasks.\"order\" ASC")
-	}).Preload("Columns.Tasks.Assignee").Where("id = ?", id).First(&board)
+		return db.Order(taskRankOrder)
+	}).Preload("Owner").Preload("Columns.Tasks.Assignee").Preload("Columns.Tasks.Labels").Preload("Columns.Tasks.CustomFieldValues").Where("id = ?", id).First(&board)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
@@ -75,24 +105,28 @@ func (r *boardRepository) GetByIDWithColumns(id uint) (*domain.Board, error) {
 		}
 		return nil, result.Error
 	}
+	for i := range board.Columns {
+		domain.AssignCellOrders(board.Columns[i].Tasks)
+	}
 	return &board, nil
 }
 
 // Update ボード情報を更新します
+// 読み込んだ時点から他の操作で更新されていた場合はdomain.ErrVersionConflictを返します
 func (r *boardRepository) Update(board *domain.Board) error {
-	result := r.db.Save(board)
-	if result.Error != nil {
-		return result.Error
-	}
-	return nil
+	return saveVersioned(r.db, board, &board.Version)
 }
 
 // Delete ボードを削除します（ソフトデリート）
-func (r *boardRepository) Delete(id uint) error {
-	result := r.db.Delete(&domain.Board{}, id)
+// versionがdomain.AnyVersion以外の場合は、バージョンが一致するときのみ削除します
+func (r *boardRepository) Delete(id uint, version int) error {
+	result := r.db.Scopes(withVersion(version)).Delete(&domain.Board{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected == 0 && version != domain.AnyVersion {
+		return domain.ErrVersionConflict
+	}
 	return nil
 }
 
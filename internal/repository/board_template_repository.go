@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BoardTemplateRepository ボードテンプレートのデータアクセスを管理するインターフェース
+type BoardTemplateRepository interface {
+	Create(template *domain.BoardTemplate) error
+	GetByID(id uint) (*domain.BoardTemplate, error)
+	GetByOwnerID(ownerID uuid.UUID) ([]domain.BoardTemplate, error)
+	Delete(id uint) error
+}
+
+// boardTemplateRepository BoardTemplateRepositoryの実装
+type boardTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewBoardTemplateRepository BoardTemplateRepositoryの新しいインスタンスを作成
+func NewBoardTemplateRepository(db *gorm.DB) BoardTemplateRepository {
+	return &boardTemplateRepository{db: db}
+}
+
+// Create 新しいテンプレートを作成します
+func (r *boardTemplateRepository) Create(template *domain.BoardTemplate) error {
+	return r.db.Create(template).Error
+}
+
+// GetByID IDでテンプレートを取得します
+func (r *boardTemplateRepository) GetByID(id uint) (*domain.BoardTemplate, error) {
+	var template domain.BoardTemplate
+	result := r.db.Where("id = ?", id).First(&template)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // テンプレートが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &template, nil
+}
+
+// GetByOwnerID 所有者IDでテンプレート一覧を取得します（名前順）
+func (r *boardTemplateRepository) GetByOwnerID(ownerID uuid.UUID) ([]domain.BoardTemplate, error) {
+	var templates []domain.BoardTemplate
+	result := r.db.Where("owner_id = ?", ownerID).Order("name ASC").Find(&templates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return templates, nil
+}
+
+// Delete テンプレートを削除します（ソフトデリート）
+func (r *boardTemplateRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.BoardTemplate{}, id).Error
+}
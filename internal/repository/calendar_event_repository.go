@@ -16,7 +16,7 @@ type CalendarEventRepository interface {
 	GetByUserIDAndDateRange(userID uuid.UUID, start, end time.Time) ([]*domain.CalendarEvent, error)
 	GetByTaskID(taskID uint) (*domain.CalendarEvent, error)
 	Update(event *domain.CalendarEvent) error
-	Delete(id uint) error
+	Delete(id uint, version int) error
 }
 
 // calendarEventRepository カレンダーイベントリポジトリの実装
@@ -77,11 +77,20 @@ func (r *calendarEventRepository) GetByTaskID(taskID uint) (*domain.CalendarEven
 }
 
 // Update カレンダーイベントを更新します
+// 読み込んだ時点から他の操作で更新されていた場合はdomain.ErrVersionConflictを返します
 func (r *calendarEventRepository) Update(event *domain.CalendarEvent) error {
-	return r.db.Save(event).Error
+	return saveVersioned(r.db, event, &event.Version)
 }
 
 // Delete カレンダーイベントを削除します
-func (r *calendarEventRepository) Delete(id uint) error {
-	return r.db.Delete(&domain.CalendarEvent{}, id).Error
+// versionがdomain.AnyVersion以外の場合は、バージョンが一致するときのみ削除します
+func (r *calendarEventRepository) Delete(id uint, version int) error {
+	result := r.db.Scopes(withVersion(version)).Delete(&domain.CalendarEvent{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 && version != domain.AnyVersion {
+		return domain.ErrVersionConflict
+	}
+	return nil
 }
@@ -12,6 +12,7 @@ type ColumnRepository interface {
 	GetByID(id uint) (*domain.Column, error)
 	GetByBoardID(boardID uint) ([]domain.Column, error)
 	Update(column *domain.Column) error
+	UpdateWIPLimit(id uint, version int, limit *int, mode domain.WIPLimitMode) error
 	Delete(id uint) error
 	UpdateOrder(id uint, newOrder int) error
 	ReorderColumns(boardID uint, columnIDs []uint) error
@@ -47,8 +48,8 @@ func (r *columnRepository) Create(column *domain.Column) error {
 func (r *columnRepository) GetByID(id uint) (*domain.Column, error) {
 	var column domain.Column
 	result := r.db.Preload("Board").Preload("Tasks", func(db *gorm.DB) *gorm.DB {
-		return db.Order("tasks.\"order\" ASC")
-	}).Preload("Tasks.Assignee").Where("id = ?", id).First(&column)
+		return db.Order(taskRankOrder)
+	}).Preload("Tasks.Assignee").Preload("Tasks.Labels").Preload("Tasks.CustomFieldValues").Where("id = ?", id).First(&column)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
@@ -56,6 +57,7 @@ func (r *columnRepository) GetByID(id uint) (*domain.Column, error) {
 		}
 		return nil, result.Error
 	}
+	domain.AssignCellOrders(column.Tasks)
 	return &column, nil
 }
 
@@ -63,21 +65,38 @@ func (r *columnRepository) GetByID(id uint) (*domain.Column, error) {
 func (r *columnRepository) GetByBoardID(boardID uint) ([]domain.Column, error) {
 	var columns []domain.Column
 	result := r.db.Preload("Tasks", func(db *gorm.DB) *gorm.DB {
-		return db.Order("tasks.\"order\" ASC")
-	}).Preload("Tasks.Assignee").Where("board_id = ?", boardID).Order("\"order\" ASC").Find(&columns)
+		return db.Order(taskRankOrder)
+	}).Preload("Tasks.Assignee").Preload("Tasks.Labels").Preload("Tasks.CustomFieldValues").Where("board_id = ?", boardID).Order("\"order\" ASC").Find(&columns)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	for i := range columns {
+		domain.AssignCellOrders(columns[i].Tasks)
+	}
 	return columns, nil
 }
 
 // Update カラム情報を更新します
+// 読み込んだ時点から他の操作で更新されていた場合はdomain.ErrVersionConflictを返します
 func (r *columnRepository) Update(column *domain.Column) error {
-	result := r.db.Save(column)
+	return saveVersioned(r.db, column, &column.Version)
+}
+
+// UpdateWIPLimit カラムのWIP制限を更新します（limitがnilの場合は制限を解除）
+// versionがdomain.AnyVersion以外の場合は、バージョンが一致するときのみ更新します
+func (r *columnRepository) UpdateWIPLimit(id uint, version int, limit *int, mode domain.WIPLimitMode) error {
+	result := r.db.Model(&domain.Column{}).Where("id = ?", id).Scopes(withVersion(version)).Updates(map[string]interface{}{
+		"wip_limit":      limit,
+		"wip_limit_mode": mode,
+		"version":        bumpVersion,
+	})
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected == 0 && version != domain.AnyVersion {
+		return domain.ErrVersionConflict
+	}
 	return nil
 }
 
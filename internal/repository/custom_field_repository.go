@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"simple-kanban/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// CustomFieldRepository カスタムフィールドのデータアクセスを管理するインターフェース
+type CustomFieldRepository interface {
+	CreateDefinition(field *domain.CustomFieldDefinition) error
+	GetDefinitionByID(id uint) (*domain.CustomFieldDefinition, error)
+	GetDefinitionsByBoardID(boardID uint) ([]domain.CustomFieldDefinition, error)
+	UpdateDefinition(field *domain.CustomFieldDefinition) error
+	DeleteDefinition(id uint) error
+	GetValuesByTaskID(taskID uint) ([]domain.TaskCustomFieldValue, error)
+	SaveValue(value *domain.TaskCustomFieldValue) error
+	DeleteValue(taskID, fieldID uint) error
+}
+
+// customFieldRepository CustomFieldRepositoryの実装
+type customFieldRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomFieldRepository CustomFieldRepositoryの新しいインスタンスを作成
+func NewCustomFieldRepository(db *gorm.DB) CustomFieldRepository {
+	return &customFieldRepository{db: db}
+}
+
+// CreateDefinition 新しいフィールド定義を作成します
+func (r *customFieldRepository) CreateDefinition(field *domain.CustomFieldDefinition) error {
+	// 順序が未指定の場合はボード内の最後に追加する
+	if field.Order == 0 {
+		var maxOrder int
+		r.db.Model(&domain.CustomFieldDefinition{}).Where("board_id = ?", field.BoardID).Select("COALESCE(MAX(\"order\"), 0)").Scan(&maxOrder)
+		field.Order = maxOrder + 1
+	}
+	return r.db.Create(field).Error
+}
+
+// GetDefinitionByID IDでフィールド定義を取得します
+func (r *customFieldRepository) GetDefinitionByID(id uint) (*domain.CustomFieldDefinition, error) {
+	var field domain.CustomFieldDefinition
+	result := r.db.Where("id = ?", id).First(&field)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // フィールドが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &field, nil
+}
+
+// GetDefinitionsByBoardID ボードIDでフィールド定義一覧を取得します（順序順）
+func (r *customFieldRepository) GetDefinitionsByBoardID(boardID uint) ([]domain.CustomFieldDefinition, error) {
+	var fields []domain.CustomFieldDefinition
+	result := r.db.Where("board_id = ?", boardID).Order("\"order\" ASC").Find(&fields)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return fields, nil
+}
+
+// UpdateDefinition フィールド定義を更新します
+func (r *customFieldRepository) UpdateDefinition(field *domain.CustomFieldDefinition) error {
+	return r.db.Save(field).Error
+}
+
+// DeleteDefinition フィールド定義を削除します（ソフトデリート）
+// 定義に紐づくタスクの値も合わせて削除します
+func (r *customFieldRepository) DeleteDefinition(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", id).Delete(&domain.TaskCustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.CustomFieldDefinition{}, id).Error
+	})
+}
+
+// GetValuesByTaskID タスクIDでフィールド値一覧を取得します
+func (r *customFieldRepository) GetValuesByTaskID(taskID uint) ([]domain.TaskCustomFieldValue, error) {
+	var values []domain.TaskCustomFieldValue
+	result := r.db.Where("task_id = ?", taskID).Find(&values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return values, nil
+}
+
+// SaveValue タスクのフィールド値を保存します（既存の値があれば置き換え）
+func (r *customFieldRepository) SaveValue(value *domain.TaskCustomFieldValue) error {
+	var existing domain.TaskCustomFieldValue
+	result := r.db.Where("task_id = ? AND field_id = ?", value.TaskID, value.FieldID).First(&existing)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		return result.Error
+	}
+	if result.Error == nil {
+		value.ID = existing.ID
+		value.CreatedAt = existing.CreatedAt
+	}
+	return r.db.Save(value).Error
+}
+
+// DeleteValue タスクのフィールド値を削除します
+func (r *customFieldRepository) DeleteValue(taskID, fieldID uint) error {
+	return r.db.Where("task_id = ? AND field_id = ?", taskID, fieldID).Delete(&domain.TaskCustomFieldValue{}).Error
+}
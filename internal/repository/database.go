@@ -7,6 +7,7 @@ import (
 	"simple-kanban/config"
 	"simple-kanban/internal/domain"
 
+	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -69,15 +70,113 @@ func Migrate(db *gorm.DB) error {
 		&domain.CalendarSettings{},
 		&domain.TimerSession{},
 		&domain.CalendarEvent{},
+		&domain.CustomFieldDefinition{},
+		&domain.TaskCustomFieldValue{},
+		&domain.Label{},
+		&domain.Lane{},
+		&domain.TaskActivity{},
+		&domain.BoardTemplate{},
+		&domain.Webhook{},
+		&domain.WebhookDelivery{},
+		&domain.AutomationRule{},
+		&domain.AutomationExecution{},
+		&domain.PersonalAccessToken{},
+		&domain.UserIdentity{},
+		&domain.OIDCLoginState{},
+		&domain.AccountToken{},
+		&domain.Workspace{},
+		&domain.WorkspaceMember{},
 	)
 	if err != nil {
 		return fmt.Errorf("マイグレーションに失敗しました: %w", err)
 	}
 
+	// タスクの全文検索用インデックス（TaskRepository.Searchの式と一致させる）
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks USING GIN (to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(description, '')))`).Error; err != nil {
+		return fmt.Errorf("全文検索インデックスの作成に失敗しました: %w", err)
+	}
+
+	// セル内の並び順用インデックス（ランクキーはバイト順で比較するため、taskRankOrderと照合順序を一致させる）
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_tasks_cell_rank ON tasks (column_id, lane_id, rank COLLATE "C")`).Error; err != nil {
+		return fmt.Errorf("並び順インデックスの作成に失敗しました: %w", err)
+	}
+
+	// ランクキー導入前のタスクにランクを設定
+	if err := backfillTaskRanks(db); err != nil {
+		return fmt.Errorf("ランクキーの設定に失敗しました: %w", err)
+	}
+
+	// ワークスペース導入前のユーザーとボードを個人用ワークスペースに移行
+	if err := backfillPersonalWorkspaces(db); err != nil {
+		return fmt.Errorf("ワークスペースの移行に失敗しました: %w", err)
+	}
+
 	log.Println("データベースマイグレーションが完了しました")
 	return nil
 }
 
+// backfillTaskRanks ランクキーが未設定のタスクを含むセルに、旧来の整数の順序に沿ってランクを設定します
+func backfillTaskRanks(db *gorm.DB) error {
+	var cells []struct {
+		ColumnID uint
+		LaneID   *uint
+	}
+	if err := db.Unscoped().Model(&domain.Task{}).Distinct("column_id", "lane_id").Where("rank = ''").Scan(&cells).Error; err != nil {
+		return err
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+
+	order := "id ASC"
+	if db.Migrator().HasColumn(&domain.Task{}, "order") {
+		order = "\"order\" ASC, id ASC"
+	}
+
+	for _, cell := range cells {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var ids []uint
+			if err := inCell(tx.Unscoped().Model(&domain.Task{}), cell.ColumnID, cell.LaneID).Order(order).Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			return updateRanks(tx, ids, domain.RankSequence(len(ids)))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillPersonalWorkspaces 個人用ワークスペースのないユーザーに作成し、
+// ワークスペース未設定のボード（ゴミ箱のボードを含む）を作成したユーザーの個人用ワークスペースに移します
+func backfillPersonalWorkspaces(db *gorm.DB) error {
+	personal := db.Table("workspace_members").Select("workspace_members.user_id").
+		Joins("JOIN workspaces ON workspaces.id = workspace_members.workspace_id").
+		Where("workspaces.personal = ?", true)
+
+	var userIDs []uuid.UUID
+	if err := db.Model(&domain.User{}).Where("id NOT IN (?)", personal).Pluck("id", &userIDs).Error; err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := db.Create(domain.NewPersonalWorkspace(userID)).Error; err != nil {
+			return err
+		}
+	}
+
+	return db.Exec(`UPDATE boards SET workspace_id = (
+		SELECT workspaces.id FROM workspaces
+		JOIN workspace_members ON workspace_members.workspace_id = workspaces.id
+		WHERE workspaces.personal = TRUE AND workspace_members.user_id = boards.owner_id
+		LIMIT 1
+	) WHERE workspace_id = 0 AND EXISTS (
+		SELECT 1 FROM workspaces
+		JOIN workspace_members ON workspace_members.workspace_id = workspaces.id
+		WHERE workspaces.personal = TRUE AND workspace_members.user_id = boards.owner_id
+	)`).Error
+}
+
 // CloseDB データベース接続を閉じます
 func CloseDB() error {
 	if DB != nil {
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"simple-kanban/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// LabelRepository ラベルのデータアクセスを管理するインターフェース
+type LabelRepository interface {
+	Create(label *domain.Label) error
+	GetByID(id uint) (*domain.Label, error)
+	GetByBoardID(boardID uint) ([]domain.Label, error)
+	Update(label *domain.Label) error
+	Delete(id uint) error
+	AddToTask(taskID, labelID uint) error
+	RemoveFromTask(taskID, labelID uint) error
+}
+
+// labelRepository LabelRepositoryの実装
+type labelRepository struct {
+	db *gorm.DB
+}
+
+// NewLabelRepository LabelRepositoryの新しいインスタンスを作成
+func NewLabelRepository(db *gorm.DB) LabelRepository {
+	return &labelRepository{db: db}
+}
+
+// Create 新しいラベルを作成します
+func (r *labelRepository) Create(label *domain.Label) error {
+	return r.db.Create(label).Error
+}
+
+// GetByID IDでラベルを取得します
+func (r *labelRepository) GetByID(id uint) (*domain.Label, error) {
+	var label domain.Label
+	result := r.db.Where("id = ?", id).First(&label)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // ラベルが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &label, nil
+}
+
+// GetByBoardID ボードIDでラベル一覧を取得します（名前順）
+func (r *labelRepository) GetByBoardID(boardID uint) ([]domain.Label, error) {
+	var labels []domain.Label
+	result := r.db.Where("board_id = ?", boardID).Order("name ASC").Find(&labels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return labels, nil
+}
+
+// Update ラベル情報を更新します
+func (r *labelRepository) Update(label *domain.Label) error {
+	return r.db.Save(label).Error
+}
+
+// Delete ラベルを削除します（ソフトデリート）
+// タスクとの関連付けも合わせて削除します
+func (r *labelRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_labels WHERE label_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Label{}, id).Error
+	})
+}
+
+// AddToTask タスクにラベルを付与します（付与済みの場合は何もしない）
+func (r *labelRepository) AddToTask(taskID, labelID uint) error {
+	return r.db.Exec("INSERT INTO task_labels (task_id, label_id) VALUES (?, ?) ON CONFLICT DO NOTHING", taskID, labelID).Error
+}
+
+// RemoveFromTask タスクからラベルを外します
+func (r *labelRepository) RemoveFromTask(taskID, labelID uint) error {
+	return r.db.Exec("DELETE FROM task_labels WHERE task_id = ? AND label_id = ?", taskID, labelID).Error
+}
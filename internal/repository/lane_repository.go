@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"simple-kanban/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// LaneRepository スイムレーンのデータアクセスを管理するインターフェース
+type LaneRepository interface {
+	Create(lane *domain.Lane) error
+	GetByID(id uint) (*domain.Lane, error)
+	GetByBoardID(boardID uint) ([]domain.Lane, error)
+	Update(lane *domain.Lane) error
+	Delete(id uint) error
+}
+
+// laneRepository LaneRepositoryの実装
+type laneRepository struct {
+	db *gorm.DB
+}
+
+// NewLaneRepository LaneRepositoryの新しいインスタンスを作成
+func NewLaneRepository(db *gorm.DB) LaneRepository {
+	return &laneRepository{db: db}
+}
+
+// Create 新しいレーンを作成します
+func (r *laneRepository) Create(lane *domain.Lane) error {
+	// 順序が未指定の場合はボード内の最後に追加する
+	if lane.Order == 0 {
+		var maxOrder int
+		r.db.Model(&domain.Lane{}).Where("board_id = ?", lane.BoardID).Select("COALESCE(MAX(\"order\"), 0)").Scan(&maxOrder)
+		lane.Order = maxOrder + 1
+	}
+	return r.db.Create(lane).Error
+}
+
+// GetByID IDでレーンを取得します
+func (r *laneRepository) GetByID(id uint) (*domain.Lane, error) {
+	var lane domain.Lane
+	result := r.db.Where("id = ?", id).First(&lane)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // レーンが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &lane, nil
+}
+
+// GetByBoardID ボードIDでレーン一覧を取得します（順序順）
+func (r *laneRepository) GetByBoardID(boardID uint) ([]domain.Lane, error) {
+	var lanes []domain.Lane
+	result := r.db.Where("board_id = ?", boardID).Order("\"order\" ASC").Find(&lanes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return lanes, nil
+}
+
+// Update レーン情報を更新します
+func (r *laneRepository) Update(lane *domain.Lane) error {
+	return r.db.Save(lane).Error
+}
+
+// Delete レーンを削除します（ソフトデリート）
+// レーンのタスクは、各カラムのレーンなしのセルの末尾に移動します
+func (r *laneRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var columnIDs []uint
+		if err := tx.Model(&domain.Task{}).Where("lane_id = ?", id).Distinct("column_id").Pluck("column_id", &columnIDs).Error; err != nil {
+			return err
+		}
+
+		// カラムごとに、レーンなしのセルのタスクの後ろにレーンのタスクを並べてランクを振り直す
+		for _, columnID := range columnIDs {
+			if _, err := lockColumn(tx, columnID); err != nil {
+				return err
+			}
+
+			var ids, laneTaskIDs []uint
+			if err := inCell(tx.Model(&domain.Task{}), columnID, nil).Order(taskRankOrder).Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			if err := inCell(tx.Model(&domain.Task{}), columnID, &id).Order(taskRankOrder).Pluck("id", &laneTaskIDs).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&domain.Task{}).Where("id IN ?", laneTaskIDs).Update("lane_id", nil).Error; err != nil {
+				return err
+			}
+
+			ids = append(ids, laneTaskIDs...)
+			if err := updateRanks(tx, ids, domain.RankSequence(len(ids))); err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&domain.Lane{}, id).Error
+	})
+}
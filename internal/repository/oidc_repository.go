@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OIDCRepository OpenID Connectのログイン状態と外部アカウントの紐付けのデータアクセスを管理するインターフェース
+type OIDCRepository interface {
+	CreateLoginState(state *domain.OIDCLoginState) error
+	ConsumeLoginState(state string) (*domain.OIDCLoginState, error)
+	DeleteExpiredLoginStates(now time.Time) error
+	GetIdentity(issuer, subject string) (*domain.UserIdentity, error)
+	CreateIdentity(identity *domain.UserIdentity) error
+}
+
+// oidcRepository OIDCRepositoryの実装
+type oidcRepository struct {
+	db *gorm.DB
+}
+
+// NewOIDCRepository OIDCRepositoryの新しいインスタンスを作成
+func NewOIDCRepository(db *gorm.DB) OIDCRepository {
+	return &oidcRepository{db: db}
+}
+
+// CreateLoginState ログインの開始時の値を保存します
+func (r *oidcRepository) CreateLoginState(state *domain.OIDCLoginState) error {
+	return r.db.Create(state).Error
+}
+
+// ConsumeLoginState stateに対応する値を取得して削除します（同じstateは1回のみ使用できる）
+// 見つからない場合はnilを返します
+func (r *oidcRepository) ConsumeLoginState(state string) (*domain.OIDCLoginState, error) {
+	var loginStates []domain.OIDCLoginState
+	result := r.db.Clauses(clause.Returning{}).Where("state = ?", state).Delete(&loginStates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(loginStates) == 0 {
+		return nil, nil
+	}
+	return &loginStates[0], nil
+}
+
+// DeleteExpiredLoginStates 有効期限を過ぎたログインの開始時の値を削除します
+func (r *oidcRepository) DeleteExpiredLoginStates(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&domain.OIDCLoginState{}).Error
+}
+
+// GetIdentity IDプロバイダーとユーザーID（sub）で紐付けを取得します
+func (r *oidcRepository) GetIdentity(issuer, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	result := r.db.Where("issuer = ? AND subject = ?", issuer, subject).First(&identity)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // 紐付けが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &identity, nil
+}
+
+// CreateIdentity 外部アカウントとユーザーを紐付けます
+func (r *oidcRepository) CreateIdentity(identity *domain.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BoardResource ボードに属するリソースの種類（トークンのスコープのチェックに使用）
+type BoardResource string
+
+const (
+	BoardResourceBoard          BoardResource = "board"
+	BoardResourceTask           BoardResource = "task"
+	BoardResourceColumn         BoardResource = "column"
+	BoardResourceLabel          BoardResource = "label"
+	BoardResourceLane           BoardResource = "lane"
+	BoardResourceCustomField    BoardResource = "custom_field"
+	BoardResourceWebhook        BoardResource = "webhook"
+	BoardResourceAutomationRule BoardResource = "automation_rule"
+)
+
+// boardResourceQueries リソースの所属するボードIDを取得するSQL（ゴミ箱のデータの復元にも使用するため削除済みも含める）
+var boardResourceQueries = map[BoardResource]string{
+	BoardResourceTask:           `SELECT columns.board_id FROM tasks JOIN columns ON columns.id = tasks.column_id WHERE tasks.id = ?`,
+	BoardResourceColumn:         `SELECT board_id FROM columns WHERE id = ?`,
+	BoardResourceLabel:          `SELECT board_id FROM labels WHERE id = ?`,
+	BoardResourceLane:           `SELECT board_id FROM lanes WHERE id = ?`,
+	BoardResourceCustomField:    `SELECT board_id FROM custom_field_definitions WHERE id = ?`,
+	BoardResourceWebhook:        `SELECT board_id FROM webhooks WHERE id = ?`,
+	BoardResourceAutomationRule: `SELECT board_id FROM automation_rules WHERE id = ?`,
+}
+
+// PersonalAccessTokenRepository パーソナルアクセストークンのデータアクセスを管理するインターフェース
+type PersonalAccessTokenRepository interface {
+	Create(token *domain.PersonalAccessToken) error
+	GetByID(id uint) (*domain.PersonalAccessToken, error)
+	GetByHash(tokenHash string) (*domain.PersonalAccessToken, error)
+	GetByUserID(userID uuid.UUID) ([]domain.PersonalAccessToken, error)
+	UpdateLastUsed(id uint, at time.Time) error
+	Delete(id uint) error
+	GetResourceBoardID(resource BoardResource, id uint) (*uint, error)
+}
+
+// personalAccessTokenRepository PersonalAccessTokenRepositoryの実装
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenRepository PersonalAccessTokenRepositoryの新しいインスタンスを作成
+func NewPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{db: db}
+}
+
+// Create 新しいトークンを作成します
+func (r *personalAccessTokenRepository) Create(token *domain.PersonalAccessToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByID IDでトークンを取得します
+func (r *personalAccessTokenRepository) GetByID(id uint) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+	result := r.db.Where("id = ?", id).First(&token)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // トークンが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+// GetByHash トークンのハッシュでトークンを取得します
+func (r *personalAccessTokenRepository) GetByHash(tokenHash string) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+	result := r.db.Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // トークンが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+// GetByUserID ユーザーのトークン一覧を取得します（作成順）
+func (r *personalAccessTokenRepository) GetByUserID(userID uuid.UUID) ([]domain.PersonalAccessToken, error) {
+	var tokens []domain.PersonalAccessToken
+	result := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tokens, nil
+}
+
+// UpdateLastUsed トークンの最終使用日時を更新します
+func (r *personalAccessTokenRepository) UpdateLastUsed(id uint, at time.Time) error {
+	return r.db.Model(&domain.PersonalAccessToken{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
+
+// Delete トークンを削除します（失効）
+func (r *personalAccessTokenRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.PersonalAccessToken{}, id).Error
+}
+
+// GetResourceBoardID リソースの所属するボードIDを取得します
+// リソースが見つからない場合はnilを返します
+func (r *personalAccessTokenRepository) GetResourceBoardID(resource BoardResource, id uint) (*uint, error) {
+	if resource == BoardResourceBoard {
+		return &id, nil
+	}
+
+	query, ok := boardResourceQueries[resource]
+	if !ok {
+		return nil, fmt.Errorf("不明なリソースです: %s", resource)
+	}
+
+	var boardIDs []uint
+	if err := r.db.Raw(query, id).Scan(&boardIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(boardIDs) == 0 {
+		return nil, nil
+	}
+	return &boardIDs[0], nil
+}
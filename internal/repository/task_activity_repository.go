@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"simple-kanban/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// TaskActivityRepository タスクの履歴のデータアクセスを管理するインターフェース
+type TaskActivityRepository interface {
+	Create(activity *domain.TaskActivity) error
+	GetByTaskID(taskID uint) ([]domain.TaskActivity, error)
+}
+
+// taskActivityRepository TaskActivityRepositoryの実装
+type taskActivityRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskActivityRepository TaskActivityRepositoryの新しいインスタンスを作成
+func NewTaskActivityRepository(db *gorm.DB) TaskActivityRepository {
+	return &taskActivityRepository{db: db}
+}
+
+// Create 新しい履歴を記録します
+func (r *taskActivityRepository) Create(activity *domain.TaskActivity) error {
+	return r.db.Create(activity).Error
+}
+
+// GetByTaskID タスクIDで履歴一覧を取得します（新しい順）
+// 操作したユーザーは、削除（匿名化）したユーザーも含めて読み込みます
+func (r *taskActivityRepository) GetByTaskID(taskID uint) ([]domain.TaskActivity, error) {
+	var activities []domain.TaskActivity
+	result := r.db.Preload("User", unscoped).Where("task_id = ?", taskID).Order("created_at DESC").Order("id DESC").Find(&activities)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return activities, nil
+}
@@ -1,25 +1,107 @@
 package repository
 
 import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
 	"simple-kanban/internal/domain"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TaskRepository タスクのデータアクセスを管理するインターフェース
 type TaskRepository interface {
 	Create(task *domain.Task) error
+	CreateWithinWIPLimit(task *domain.Task) error
 	GetByID(id uint) (*domain.Task, error)
 	GetByColumnID(columnID uint) ([]domain.Task, error)
 	GetTasksByUserID(userID uuid.UUID) ([]domain.Task, error)
 	Update(task *domain.Task) error
-	Delete(id uint) error
+	Delete(id uint, version int) error
 	UpdateOrder(id uint, newOrder int) error
-	MoveToColumn(taskID uint, newColumnID uint, newOrder int) error
-	ReorderTasksInColumn(columnID uint, taskIDs []uint) error
+	MoveToColumn(taskID uint, newColumnID uint, newLaneID *uint, newOrder int) error
+	ReorderTasksInCell(columnID uint, laneID *uint, taskIDs []uint) error
+	RebalanceRanks(maxLength int) (int, error)
+	Search(query TaskQuery) ([]domain.Task, error)
+}
+
+// TaskSortKey タスク検索の並び替えキー
+type TaskSortKey string
+
+const (
+	TaskSortOrder       TaskSortKey = "order"
+	TaskSortPriority    TaskSortKey = "priority"
+	TaskSortDueDate     TaskSortKey = "due_date"
+	TaskSortCreatedAt   TaskSortKey = "created_at"
+	TaskSortUpdatedAt   TaskSortKey = "updated_at"
+	TaskSortCustomField TaskSortKey = "custom_field"
+)
+
+// CustomFieldFilter カスタムフィールド値による絞り込み条件
+// Valueはフィールド型に応じて string / float64 / time.Time のいずれかを指定します
+type CustomFieldFilter struct {
+	FieldID uint
+	Type    domain.CustomFieldType
+	Value   interface{}
+}
+
+// TaskQuery タスク検索の条件
+type TaskQuery struct {
+	BoardIDs     []uint
+	ColumnIDs    []uint
+	LabelIDs     []uint // いずれかのラベルが付与されたタスク
+	AssigneeIDs  []uuid.UUID
+	Priorities   []domain.TaskPriority
+	CustomFields []CustomFieldFilter
+	Text         string     // タイトルと説明の全文検索
+	DueFrom      *time.Time // 期限日の下限（この日時を含む）
+	DueTo        *time.Time // 期限日の上限（この日時を含まない）
+	IsCompleted  *bool
+	Overdue      bool // 期限切れの未完了タスクのみ
+
+	SortBy        TaskSortKey
+	SortFieldID   uint                   // SortByがcustom_fieldの場合の対象フィールド
+	SortFieldType domain.CustomFieldType // SortByがcustom_fieldの場合のフィールド型
+	SortDesc      bool
+
+	// Limitが指定された場合はキーセットページネーションで取得します
+	// 並び替えキーは created_at / updated_at / due_date / priority のいずれかに限ります
+	Limit int
+	After *TaskCursor
+}
+
+// TaskCursor キーセットページネーションの位置（直前のページの最後のタスク）
+type TaskCursor struct {
+	ID   uint       `json:"id"`
+	Time *time.Time `json:"t,omitempty"` // created_at / updated_at / due_date で並び替える場合の値
+	Rank int        `json:"r,omitempty"` // priority で並び替える場合の値
 }
 
+// NewTaskCursor 並び替えキーに応じてタスクのカーソルを作成します
+func NewTaskCursor(task *domain.Task, sortBy TaskSortKey) *TaskCursor {
+	cursor := &TaskCursor{ID: task.ID}
+	switch sortBy {
+	case TaskSortUpdatedAt:
+		t := task.UpdatedAt
+		cursor.Time = &t
+	case TaskSortDueDate:
+		cursor.Time = task.DueDate
+	case TaskSortPriority:
+		cursor.Rank = task.Priority.Rank()
+	default:
+		t := task.CreatedAt
+		cursor.Time = &t
+	}
+	return cursor
+}
+
+// taskSearchVector 全文検索の対象とするtsvector式（インデックスと同じ式を使用）
+const taskSearchVector = "to_tsvector('simple', coalesce(tasks.title, '') || ' ' || coalesce(tasks.description, ''))"
+
 // taskRepository TaskRepositoryの実装
 type taskRepository struct {
 	db *gorm.DB
@@ -31,160 +113,538 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 }
 
 // Create 新しいタスクを作成します
+// Orderが指定されている場合はセル内のその位置に、指定がない場合はセルの末尾に追加します
 func (r *taskRepository) Create(task *domain.Task) error {
-	// 新しいタスクを作成する際は、そのカラムの最後の順序番号を取得して+1する
-	if task.Order == 0 {
-		var maxOrder int
-		r.db.Model(&domain.Task{}).Where("column_id = ?", task.ColumnID).Select("COALESCE(MAX(\"order\"), 0)").Scan(&maxOrder)
-		task.Order = maxOrder + 1
-	}
+	return r.create(task, false)
+}
 
-	result := r.db.Create(task)
-	if result.Error != nil {
-		return result.Error
-	}
-	return nil
+// CreateWithinWIPLimit カラムのWIP制限（hard）をチェックしてタスクを作成します
+// カラムの行をロックしてから数えるため、同時に作成されても上限を超えません。超える場合は*domain.WIPLimitErrorを返します
+func (r *taskRepository) CreateWithinWIPLimit(task *domain.Task) error {
+	return r.create(task, true)
+}
+
+// create カラムの行をロックしてタスクを作成します（checkWIPがtrueの場合はWIP制限もチェック）
+func (r *taskRepository) create(task *domain.Task, checkWIP bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		column, err := lockColumn(tx, task.ColumnID)
+		if err != nil {
+			return err
+		}
+		if checkWIP {
+			if err := checkHardWIPLimit(tx, column); err != nil {
+				return err
+			}
+		}
+
+		if task.Rank == "" {
+			position := task.Order
+			if position < 1 {
+				position = math.MaxInt32
+			}
+			rank, err := rankAt(tx, task.ColumnID, task.LaneID, position, 0)
+			if err != nil {
+				return err
+			}
+			task.Rank = rank
+		}
+
+		return tx.Create(task).Error
+	})
 }
 
 // GetByID IDでタスクを取得します
 func (r *taskRepository) GetByID(id uint) (*domain.Task, error) {
 	var task domain.Task
-	result := r.db.Preload("Column").Preload("Assignee").Where("id = ?", id).First(&task)
+	result := r.db.Preload("Column").Preload("Assignee").Preload("Labels").Preload("CustomFieldValues").Where("id = ?", id).First(&task)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil // タスクが見つからない場合はnilを返す
 		}
 		return nil, result.Error
 	}
-	return &task, nil
+
+	tasks := []domain.Task{task}
+	if err := fillTaskOrders(r.db, tasks); err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
 }
 
 // GetByColumnID カラムIDでタスク一覧を取得します（順序順）
 func (r *taskRepository) GetByColumnID(columnID uint) ([]domain.Task, error) {
 	var tasks []domain.Task
-	result := r.db.Preload("Assignee").Where("column_id = ?", columnID).Order("\"order\" ASC").Find(&tasks)
+	result := r.db.Preload("Assignee").Preload("Labels").Preload("CustomFieldValues").Where("column_id = ?", columnID).Order(taskRankOrder).Find(&tasks)
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	domain.AssignCellOrders(tasks)
 	return tasks, nil
 }
 
-// GetTasksByUserID ユーザーIDでタスク一覧を取得します
+// GetTasksByUserID ユーザーIDで担当タスク一覧を取得します（ボード・カラム情報を含む）
 func (r *taskRepository) GetTasksByUserID(userID uuid.UUID) ([]domain.Task, error) {
 	var tasks []domain.Task
-	result := r.db.Preload("Column").Preload("Assignee").Where("assignee_id = ?", userID).Order("\"order\" ASC").Find(&tasks)
+	result := r.db.Preload("Column.Board").Preload("Assignee").Preload("Labels").
+		Where("assignee_id = ?", userID).
+		Order("due_date ASC NULLS LAST").Order(taskRankOrder).
+		Find(&tasks)
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	if err := fillTaskOrders(r.db, tasks); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
 // Update タスク情報を更新します
+// 読み込んだ時点から他の操作で更新されていた場合はdomain.ErrVersionConflictを返します
+// 配置（カラム・レーン・ランク）はMoveToColumnなどで変更するため、ここでは保存しません
 func (r *taskRepository) Update(task *domain.Task) error {
-	result := r.db.Save(task)
+	return saveVersioned(r.db, task, &task.Version, "column_id", "lane_id", "rank")
+}
+
+// Delete タスクを削除します（ソフトデリート）
+// versionがdomain.AnyVersion以外の場合は、バージョンが一致するときのみ削除します
+// 順序はランクキーで管理しているため、他のタスクの更新は不要です
+func (r *taskRepository) Delete(id uint, version int) error {
+	result := r.db.Scopes(withVersion(version)).Delete(&domain.Task{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		if version != domain.AnyVersion {
+			return domain.ErrVersionConflict
+		}
+		return gorm.ErrRecordNotFound
+	}
 	return nil
 }
 
-// Delete タスクを削除します（ソフトデリート）
-func (r *taskRepository) Delete(id uint) error {
-	// タスクを削除する前に、同じカラム内の他のタスクの順序を調整
+// UpdateOrder タスクのセル内での順序を更新します
+func (r *taskRepository) UpdateOrder(id uint, newOrder int) error {
 	var task domain.Task
 	if err := r.db.First(&task, id).Error; err != nil {
 		return err
 	}
+	return r.MoveToColumn(id, task.ColumnID, task.LaneID, newOrder)
+}
 
-	// トランザクション内で削除と順序調整を実行
+// MoveToColumn タスクを別のセル（カラムとレーンの組）の指定した位置に移動します
+// newLaneIDがnilの場合はレーンなしのセルに移動します
+// 移動先の前後のタスクのランクの間に新しいランクを割り当てるため、更新するのは移動するタスクの1行だけです
+func (r *taskRepository) MoveToColumn(taskID uint, newColumnID uint, newLaneID *uint, newOrder int) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// タスクを削除
-		if err := tx.Delete(&domain.Task{}, id).Error; err != nil {
+		// 同時に同じカラムへ移動されても前後のランクを正しく読めるよう、移動先カラムの行をロックする
+		column, err := lockColumn(tx, newColumnID)
+		if err != nil {
 			return err
 		}
 
-		// 削除されたタスクより後の順序のタスクをすべて-1する
-		return tx.Model(&domain.Task{}).
-			Where("column_id = ? AND \"order\" > ?", task.ColumnID, task.Order).
-			Update("order", gorm.Expr("\"order\" - 1")).Error
+		var task domain.Task
+		if err := tx.First(&task, taskID).Error; err != nil {
+			return err
+		}
+
+		// 別のカラムへ移動する場合はWIP制限（hard）をチェック
+		if newColumnID != task.ColumnID {
+			if err := checkHardWIPLimit(tx, column); err != nil {
+				return err
+			}
+		}
+
+		rank, err := rankAt(tx, newColumnID, newLaneID, newOrder, task.ID)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&task).Updates(map[string]interface{}{
+			"column_id": newColumnID,
+			"lane_id":   newLaneID,
+			"rank":      rank,
+			"version":   bumpVersion,
+		}).Error
 	})
 }
 
-// UpdateOrder タスクの順序を更新します
-func (r *taskRepository) UpdateOrder(id uint, newOrder int) error {
-	var task domain.Task
-	if err := r.db.First(&task, id).Error; err != nil {
-		return err
-	}
+// ReorderTasksInCell セル（カラムとレーンの組）内のタスクの順序を一括更新します
+// laneIDがnilの場合はレーンなしのセルが対象です
+// 指定されたタスクを先頭から順に並べ、指定されなかったタスクはその後ろに現在の順序のまま並べます
+func (r *taskRepository) ReorderTasksInCell(columnID uint, laneID *uint, taskIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockColumn(tx, columnID); err != nil {
+			return err
+		}
 
-	oldOrder := task.Order
-	columnID := task.ColumnID
+		var current []uint
+		if err := inCell(tx.Model(&domain.Task{}), columnID, laneID).Order(taskRankOrder).Pluck("id", &current).Error; err != nil {
+			return err
+		}
 
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		if newOrder > oldOrder {
-			// 下に移動する場合：間のタスクを上にシフト
-			if err := tx.Model(&domain.Task{}).
-				Where("column_id = ? AND \"order\" > ? AND \"order\" <= ?", columnID, oldOrder, newOrder).
-				Update("order", gorm.Expr("\"order\" - 1")).Error; err != nil {
-				return err
+		inCurrent := make(map[uint]bool, len(current))
+		for _, id := range current {
+			inCurrent[id] = true
+		}
+		ordered := make([]uint, 0, len(current))
+		placed := make(map[uint]bool, len(current))
+		for _, id := range taskIDs {
+			if inCurrent[id] && !placed[id] {
+				ordered = append(ordered, id)
+				placed[id] = true
 			}
-		} else if newOrder < oldOrder {
-			// 上に移動する場合：間のタスクを下にシフト
-			if err := tx.Model(&domain.Task{}).
-				Where("column_id = ? AND \"order\" >= ? AND \"order\" < ?", columnID, newOrder, oldOrder).
-				Update("order", gorm.Expr("\"order\" + 1")).Error; err != nil {
-				return err
+		}
+		for _, id := range current {
+			if !placed[id] {
+				ordered = append(ordered, id)
 			}
 		}
 
-		// 対象タスクの順序を更新
-		return tx.Model(&task).Update("order", newOrder).Error
+		return updateRanks(tx, ordered, domain.RankSequence(len(ordered)))
 	})
 }
 
-// MoveToColumn タスクを別のカラムに移動します
-func (r *taskRepository) MoveToColumn(taskID uint, newColumnID uint, newOrder int) error {
-	var task domain.Task
-	if err := r.db.First(&task, taskID).Error; err != nil {
+// RebalanceRanks ランクキーが長くなりすぎたセル、またはランクが重複しているセルのランクを等間隔に振り直します
+// 振り直したセルの数を返します
+func (r *taskRepository) RebalanceRanks(maxLength int) (int, error) {
+	var cells []struct {
+		ColumnID uint
+		LaneID   *uint
+	}
+	if err := r.db.Model(&domain.Task{}).
+		Select("column_id, lane_id").
+		Group("column_id, lane_id").
+		Having("MAX(LENGTH(rank)) > ? OR COUNT(DISTINCT rank) < COUNT(*)", maxLength).
+		Scan(&cells).Error; err != nil {
+		return 0, err
+	}
+
+	for _, cell := range cells {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			if _, err := lockColumn(tx, cell.ColumnID); err != nil {
+				return err
+			}
+			return rebalanceCell(tx, cell.ColumnID, cell.LaneID)
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(cells), nil
+}
+
+// taskRankOrder セル内の表示順の並び替え条件
+// ランクキーはバイト順で比較し、同じランクの場合はIDで順序を確定させます
+const taskRankOrder = "tasks.rank COLLATE \"C\" ASC, tasks.id ASC"
+
+// lockColumn カラムの行をロックして取得します
+// 同じカラムへのタスクの追加・移動を直列化するために使用します
+func lockColumn(tx *gorm.DB, columnID uint) (*domain.Column, error) {
+	var column domain.Column
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&column, columnID).Error; err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+// checkHardWIPLimit ロックしたカラムにタスクを1件追加するとWIP制限（hard）を超える場合に*domain.WIPLimitErrorを返します
+func checkHardWIPLimit(tx *gorm.DB, column *domain.Column) error {
+	if column.WIPLimitMode != domain.WIPLimitModeHard {
+		return nil
+	}
+	var count int64
+	if err := tx.Model(&domain.Task{}).Where("column_id = ?", column.ID).Count(&count).Error; err != nil {
 		return err
 	}
+	if wipErr := column.CheckWIPLimit(int(count) + 1); wipErr != nil {
+		return wipErr
+	}
+	return nil
+}
 
-	oldColumnID := task.ColumnID
-	oldOrder := task.Order
+// rankAt セル内の指定した位置（1始まり）に置くためのランクキーを返します
+// excludeIDのタスク（移動するタスク自身）は数えません。位置がセルの範囲外の場合は末尾になります
+// 前後のランクが重複していて間にキーを作れない場合は、セルのランクを振り直してから求めます
+func rankAt(tx *gorm.DB, columnID uint, laneID *uint, position int, excludeID uint) (string, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		cell := func() *gorm.DB {
+			return inCell(tx.Model(&domain.Task{}), columnID, laneID).Where("id <> ?", excludeID)
+		}
 
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		// 元のカラムで、移動したタスクより後のタスクを前にシフト
-		if err := tx.Model(&domain.Task{}).
-			Where("column_id = ? AND \"order\" > ?", oldColumnID, oldOrder).
-			Update("order", gorm.Expr("\"order\" - 1")).Error; err != nil {
-			return err
+		var count int64
+		if err := cell().Count(&count).Error; err != nil {
+			return "", err
+		}
+		if position < 1 {
+			position = 1
+		}
+		if position > int(count)+1 {
+			position = int(count) + 1
 		}
 
-		// 新しいカラムで、挿入位置以降のタスクを後ろにシフト
-		if err := tx.Model(&domain.Task{}).
-			Where("column_id = ? AND \"order\" >= ?", newColumnID, newOrder).
-			Update("order", gorm.Expr("\"order\" + 1")).Error; err != nil {
-			return err
+		// 挿入位置の直前と直後のタスクのランクを取得
+		offset, limit := position-2, 2
+		if offset < 0 {
+			offset, limit = 0, 1
+		}
+		var ranks []string
+		if err := cell().Order(taskRankOrder).Offset(offset).Limit(limit).Pluck("rank", &ranks).Error; err != nil {
+			return "", err
 		}
 
-		// タスクを新しいカラムに移動
-		return tx.Model(&task).Updates(map[string]interface{}{
-			"column_id": newColumnID,
-			"order":     newOrder,
-		}).Error
-	})
+		var prev, next string
+		if position > 1 {
+			prev = ranks[0]
+			ranks = ranks[1:]
+		}
+		if len(ranks) > 0 {
+			next = ranks[0]
+		}
+		if next == "" || prev < next {
+			return domain.RankBetween(prev, next), nil
+		}
+
+		if err := rebalanceCell(tx, columnID, laneID); err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("ランクキーを割り当てられません（カラム%d）", columnID)
 }
 
-// ReorderTasksInColumn カラム内のタスクの順序を一括更新します
-func (r *taskRepository) ReorderTasksInColumn(columnID uint, taskIDs []uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		for i, taskID := range taskIDs {
-			if err := tx.Model(&domain.Task{}).
-				Where("id = ? AND column_id = ?", taskID, columnID).
-				Update("order", i+1).Error; err != nil {
-				return err
+// rebalanceCell セル内のタスクのランクを現在の順序のまま等間隔に振り直します
+func rebalanceCell(tx *gorm.DB, columnID uint, laneID *uint) error {
+	var ids []uint
+	if err := inCell(tx.Model(&domain.Task{}), columnID, laneID).Order(taskRankOrder).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	return updateRanks(tx, ids, domain.RankSequence(len(ids)))
+}
+
+// updateRanks 複数のタスクのランクを1つのUPDATE文で更新します
+func updateRanks(tx *gorm.DB, ids []uint, ranks []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(ids))
+	args := make([]interface{}, 0, len(ids)*2)
+	for i, id := range ids {
+		values[i] = "(?::bigint, ?)"
+		args = append(args, id, ranks[i])
+	}
+	return tx.Exec("UPDATE tasks SET rank = v.rank FROM (VALUES "+strings.Join(values, ", ")+") AS v(id, rank) WHERE tasks.id = v.id", args...).Error
+}
+
+// fillTaskOrders タスクのセル内の表示順序（1始まり）をデータベースから求めて設定します
+func fillTaskOrders(db *gorm.DB, tasks []domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(tasks))
+	columnIDs := make([]uint, 0, len(tasks))
+	seen := make(map[uint]bool)
+	for i, task := range tasks {
+		ids[i] = task.ID
+		if !seen[task.ColumnID] {
+			seen[task.ColumnID] = true
+			columnIDs = append(columnIDs, task.ColumnID)
+		}
+	}
+
+	var rows []struct {
+		ID       uint
+		Position int
+	}
+	if err := db.Raw(`SELECT id, position FROM (
+		SELECT id, ROW_NUMBER() OVER (PARTITION BY column_id, lane_id ORDER BY rank COLLATE "C", id) AS position
+		FROM tasks WHERE deleted_at IS NULL AND column_id IN ?
+	) AS cells WHERE id IN ?`, columnIDs, ids).Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	positions := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		positions[row.ID] = row.Position
+	}
+	for i := range tasks {
+		tasks[i].Order = positions[tasks[i].ID]
+	}
+	return nil
+}
+
+// inCell カラムとレーンの組（セル）で絞り込む条件を追加します
+// laneIDがnilの場合はレーンなしのタスクが対象です
+func inCell(db *gorm.DB, columnID uint, laneID *uint) *gorm.DB {
+	if laneID == nil {
+		return db.Where("column_id = ? AND lane_id IS NULL", columnID)
+	}
+	return db.Where("column_id = ? AND lane_id = ?", columnID, *laneID)
+}
+
+// Search 条件に一致するタスク一覧を取得します
+func (r *taskRepository) Search(query TaskQuery) ([]domain.Task, error) {
+	db := r.db.Model(&domain.Task{}).
+		Select("tasks.*").
+		Joins("JOIN columns ON columns.id = tasks.column_id AND columns.deleted_at IS NULL")
+
+	if len(query.BoardIDs) > 0 {
+		db = db.Where("columns.board_id IN ?", query.BoardIDs)
+	}
+	if len(query.ColumnIDs) > 0 {
+		db = db.Where("tasks.column_id IN ?", query.ColumnIDs)
+	}
+	if len(query.LabelIDs) > 0 {
+		db = db.Where("tasks.id IN (SELECT task_id FROM task_labels WHERE label_id IN ?)", query.LabelIDs)
+	}
+	if len(query.AssigneeIDs) > 0 {
+		db = db.Where("tasks.assignee_id IN ?", query.AssigneeIDs)
+	}
+	if len(query.Priorities) > 0 {
+		db = db.Where("tasks.priority IN ?", query.Priorities)
+	}
+	if query.Text != "" {
+		db = db.Where(taskSearchVector+" @@ plainto_tsquery('simple', ?)", query.Text)
+	}
+	if query.DueFrom != nil {
+		db = db.Where("tasks.due_date >= ?", *query.DueFrom)
+	}
+	if query.DueTo != nil {
+		db = db.Where("tasks.due_date < ?", *query.DueTo)
+	}
+	if query.IsCompleted != nil {
+		db = db.Where("tasks.is_completed = ?", *query.IsCompleted)
+	}
+	if query.Overdue {
+		db = db.Where("tasks.due_date < ? AND tasks.is_completed = ?", time.Now(), false)
+	}
+
+	// カスタムフィールドの条件ごとに値テーブルを結合して絞り込む
+	for i, filter := range query.CustomFields {
+		alias := fmt.Sprintf("cf%d", i)
+		db = db.Joins(fmt.Sprintf("JOIN task_custom_field_values %s ON %s.task_id = tasks.id AND %s.field_id = ?", alias, alias, alias), filter.FieldID)
+		switch filter.Type {
+		case domain.CustomFieldTypeSingleSelect, domain.CustomFieldTypeMultiSelect:
+			option, _ := filter.Value.(string)
+			selected, err := domain.StringList{option}.Value()
+			if err != nil {
+				return nil, err
 			}
+			db = db.Where(fmt.Sprintf("%s.option_values @> ?::jsonb", alias), selected)
+		default:
+			db = db.Where(fmt.Sprintf("%s.%s = ?", alias, customFieldValueColumn(filter.Type)), filter.Value)
 		}
-		return nil
-	})
+	}
+
+	if query.Limit > 0 {
+		db = r.applyTaskKeyset(db, query).Limit(query.Limit)
+	} else {
+		db = r.applyTaskSort(db, query)
+	}
+
+	var tasks []domain.Task
+	result := db.Preload("Column").Preload("Assignee").Preload("Labels").Preload("CustomFieldValues").Find(&tasks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if err := fillTaskOrders(r.db, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// applyTaskSort 検索条件に応じた並び替えを設定します
+func (r *taskRepository) applyTaskSort(db *gorm.DB, query TaskQuery) *gorm.DB {
+	direction := "ASC"
+	if query.SortDesc {
+		direction = "DESC"
+	}
+
+	switch query.SortBy {
+	case TaskSortPriority:
+		db = db.Order(priorityRankExpr() + " " + direction)
+	case TaskSortDueDate:
+		db = db.Order("tasks.due_date " + direction + " NULLS LAST")
+	case TaskSortCreatedAt:
+		db = db.Order("tasks.created_at " + direction)
+	case TaskSortUpdatedAt:
+		db = db.Order("tasks.updated_at " + direction)
+	case TaskSortCustomField:
+		db = db.Joins("LEFT JOIN task_custom_field_values sort_cf ON sort_cf.task_id = tasks.id AND sort_cf.field_id = ?", query.SortFieldID).
+			Order("sort_cf." + customFieldValueColumn(query.SortFieldType) + " " + direction + " NULLS LAST")
+	}
+
+	// 同順位の場合はボード上の表示順で並べる
+	return db.Order("columns.\"order\" ASC").Order(taskRankOrder)
+}
+
+// applyTaskKeyset キーセットページネーション用の並び替えとカーソル条件を設定します
+// 並び替えキーが同じ値の場合はタスクIDで順序を確定させます
+func (r *taskRepository) applyTaskKeyset(db *gorm.DB, query TaskQuery) *gorm.DB {
+	direction, op := "ASC", ">"
+	if query.SortDesc {
+		direction, op = "DESC", "<"
+	}
+
+	var expr string
+	switch query.SortBy {
+	case TaskSortUpdatedAt:
+		expr = "tasks.updated_at"
+	case TaskSortDueDate:
+		expr = "tasks.due_date"
+	case TaskSortPriority:
+		expr = priorityRankExpr()
+	default:
+		expr = "tasks.created_at"
+	}
+
+	if cursor := query.After; cursor != nil {
+		switch query.SortBy {
+		case TaskSortDueDate:
+			// 期限日なしのタスクは常に最後に並ぶ
+			if cursor.Time == nil {
+				db = db.Where(fmt.Sprintf("tasks.due_date IS NULL AND tasks.id %s ?", op), cursor.ID)
+			} else {
+				db = db.Where(fmt.Sprintf("((%s %s ?) OR (%s = ? AND tasks.id %s ?) OR tasks.due_date IS NULL)", expr, op, expr, op),
+					*cursor.Time, *cursor.Time, cursor.ID)
+			}
+		case TaskSortPriority:
+			db = db.Where(fmt.Sprintf("((%s %s ?) OR (%s = ? AND tasks.id %s ?))", expr, op, expr, op),
+				cursor.Rank, cursor.Rank, cursor.ID)
+		default:
+			var t time.Time
+			if cursor.Time != nil {
+				t = *cursor.Time
+			}
+			db = db.Where(fmt.Sprintf("((%s %s ?) OR (%s = ? AND tasks.id %s ?))", expr, op, expr, op),
+				t, t, cursor.ID)
+		}
+	}
+
+	return db.Order(expr + " " + direction + " NULLS LAST").Order("tasks.id " + direction)
+}
+
+// customFieldValueColumn フィールド型に対応する値カラム名を返します
+func customFieldValueColumn(t domain.CustomFieldType) string {
+	switch t {
+	case domain.CustomFieldTypeNumber:
+		return "number_value"
+	case domain.CustomFieldTypeDate:
+		return "date_value"
+	case domain.CustomFieldTypeSingleSelect, domain.CustomFieldTypeMultiSelect:
+		return "option_values"
+	default:
+		return "text_value"
+	}
+}
+
+// priorityRankExpr 優先度を数値に変換するSQL式を返します
+func priorityRankExpr() string {
+	var b strings.Builder
+	b.WriteString("CASE tasks.priority")
+	for _, p := range domain.TaskPriorities {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", p, p.Rank())
+	}
+	b.WriteString(" ELSE 0 END")
+	return b.String()
 }
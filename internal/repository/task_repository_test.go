@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB テスト用のデータベースに接続します
+// TEST_DATABASE_URL が設定されていない場合はテストをスキップします
+func openTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL が設定されていないためスキップします")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, Migrate(db))
+	return db
+}
+
+// 並行して移動・並び替えを行っても、セル内の順序が重複なく一貫していることのテスト
+func TestMoveToColumn_ConcurrentMoves(t *testing.T) {
+	db := openTestDB(t)
+
+	user := &domain.User{Email: fmt.Sprintf("rank-%s@example.com", uuid.NewString()), PasswordHash: "x"}
+	require.NoError(t, db.Create(user).Error)
+	board := &domain.Board{Name: "並行移動テスト", OwnerID: user.ID}
+	require.NoError(t, db.Create(board).Error)
+	source := &domain.Column{BoardID: board.ID, Title: "To Do", Order: 1}
+	target := &domain.Column{BoardID: board.ID, Title: "Done", Order: 2}
+	require.NoError(t, db.Create(source).Error)
+	require.NoError(t, db.Create(target).Error)
+	t.Cleanup(func() {
+		db.Unscoped().Where("column_id IN ?", []uint{source.ID, target.ID}).Delete(&domain.Task{})
+		db.Unscoped().Delete(&domain.Column{}, []uint{source.ID, target.ID})
+		db.Unscoped().Delete(board)
+		db.Unscoped().Delete(user)
+	})
+
+	repo := NewTaskRepository(db)
+	const taskCount = 40
+	tasks := make([]*domain.Task, taskCount)
+	for i := range tasks {
+		tasks[i] = &domain.Task{ColumnID: source.ID, Title: fmt.Sprintf("タスク%d", i+1), Priority: domain.TaskPriorityNone}
+		require.NoError(t, repo.Create(tasks[i]))
+	}
+
+	// すべてのタスクを同時に移動先カラムの先頭付近へ移動し、並行して移動先の並び替えも行う
+	var wg sync.WaitGroup
+	errs := make(chan error, taskCount*2)
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(i int, taskID uint) {
+			defer wg.Done()
+			errs <- repo.MoveToColumn(taskID, target.ID, nil, i%3+1)
+			errs <- repo.UpdateOrder(taskID, 1)
+		}(i, task.ID)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	moved, err := repo.GetByColumnID(target.ID)
+	require.NoError(t, err)
+	require.Len(t, moved, taskCount)
+
+	// ランクは重複せず昇順に並び、表示順序は1から連続している
+	ranks := make(map[string]bool)
+	for i, task := range moved {
+		assert.False(t, ranks[task.Rank], "ランクが重複しています: %s", task.Rank)
+		ranks[task.Rank] = true
+		if i > 0 {
+			assert.Less(t, moved[i-1].Rank, task.Rank)
+		}
+		assert.Equal(t, i+1, task.Order)
+	}
+
+	remaining, err := repo.GetByColumnID(source.ID)
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+}
+
+// 並行してタスクを作成・移動しても、WIP制限（hard）を超えないことのテスト
+func TestCreateWithinWIPLimit_Concurrent(t *testing.T) {
+	db := openTestDB(t)
+
+	user := &domain.User{Email: fmt.Sprintf("wip-%s@example.com", uuid.NewString()), PasswordHash: "x"}
+	require.NoError(t, db.Create(user).Error)
+	board := &domain.Board{Name: "WIP制限テスト", OwnerID: user.ID}
+	require.NoError(t, db.Create(board).Error)
+	limit := 3
+	source := &domain.Column{BoardID: board.ID, Title: "To Do", Order: 1}
+	target := &domain.Column{BoardID: board.ID, Title: "Doing", Order: 2, WIPLimit: &limit, WIPLimitMode: domain.WIPLimitModeHard}
+	require.NoError(t, db.Create(source).Error)
+	require.NoError(t, db.Create(target).Error)
+	t.Cleanup(func() {
+		db.Unscoped().Where("column_id IN ?", []uint{source.ID, target.ID}).Delete(&domain.Task{})
+		db.Unscoped().Delete(&domain.Column{}, []uint{source.ID, target.ID})
+		db.Unscoped().Delete(board)
+		db.Unscoped().Delete(user)
+	})
+
+	repo := NewTaskRepository(db)
+	const taskCount = 10
+	tasks := make([]*domain.Task, taskCount)
+	for i := range tasks {
+		tasks[i] = &domain.Task{ColumnID: source.ID, Title: fmt.Sprintf("移動%d", i+1), Priority: domain.TaskPriorityNone}
+		require.NoError(t, repo.Create(tasks[i]))
+	}
+
+	// 作成と移動を同時に行い、成功した数が上限と一致することを確認する
+	var wg sync.WaitGroup
+	errs := make(chan error, taskCount*2)
+	for i, task := range tasks {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			errs <- repo.CreateWithinWIPLimit(&domain.Task{ColumnID: target.ID, Title: fmt.Sprintf("作成%d", i+1), Priority: domain.TaskPriorityNone})
+		}(i)
+		go func(taskID uint) {
+			defer wg.Done()
+			errs <- repo.MoveToColumn(taskID, target.ID, nil, 1)
+		}(task.ID)
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var wipErr *domain.WIPLimitError
+		assert.ErrorAs(t, err, &wipErr)
+	}
+	assert.Equal(t, limit, succeeded)
+
+	var count int64
+	require.NoError(t, db.Model(&domain.Task{}).Where("column_id = ?", target.ID).Count(&count).Error)
+	assert.Equal(t, int64(limit), count)
+}
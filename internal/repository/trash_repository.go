@@ -0,0 +1,355 @@
+package repository
+
+import (
+	"math"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TrashPurgeResult 完全削除した件数
+type TrashPurgeResult struct {
+	Boards  int64
+	Columns int64
+	Tasks   int64
+}
+
+// TrashRepository ソフトデリートされたデータ（ゴミ箱）のアクセスを管理するインターフェース
+type TrashRepository interface {
+	GetDeletedBoards(userID uuid.UUID) ([]domain.Board, error)
+	GetDeletedColumns(boardIDs []uint) ([]domain.Column, error)
+	GetDeletedTasks(boardIDs []uint) ([]domain.Task, error)
+	GetDeletedBoard(id uint) (*domain.Board, error)
+	GetDeletedColumn(id uint) (*domain.Column, error)
+	GetDeletedTask(id uint) (*domain.Task, error)
+	RestoreBoard(id uint) error
+	RestoreColumn(id uint) error
+	RestoreTask(id uint, columnID uint) error
+	PurgeDeletedBefore(cutoff time.Time) (*TrashPurgeResult, error)
+	PurgeBoards(boardIDs []uint) (*TrashPurgeResult, error)
+}
+
+// trashRepository TrashRepositoryの実装
+type trashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository TrashRepositoryの新しいインスタンスを作成
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// unscoped 削除済みのレコードも含めて読み込むためのPreload条件
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// GetDeletedBoards ユーザーがメンバーのワークスペースの削除済みボード一覧を取得します（削除日時の新しい順）
+func (r *trashRepository) GetDeletedBoards(userID uuid.UUID) ([]domain.Board, error) {
+	var boards []domain.Board
+	result := r.db.Unscoped().
+		Where("workspace_id IN (?) AND deleted_at IS NOT NULL", memberWorkspaceIDs(r.db, userID)).
+		Order("deleted_at DESC").
+		Find(&boards)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return boards, nil
+}
+
+// GetDeletedColumns ボードの削除済みカラム一覧を取得します（削除日時の新しい順）
+func (r *trashRepository) GetDeletedColumns(boardIDs []uint) ([]domain.Column, error) {
+	var columns []domain.Column
+	if len(boardIDs) == 0 {
+		return columns, nil
+	}
+	result := r.db.Unscoped().
+		Where("board_id IN ? AND deleted_at IS NOT NULL", boardIDs).
+		Order("deleted_at DESC").
+		Find(&columns)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return columns, nil
+}
+
+// GetDeletedTasks ボードの削除済みタスク一覧を取得します（削除日時の新しい順）
+// 削除済みのカラムに属するタスクも含みます
+func (r *trashRepository) GetDeletedTasks(boardIDs []uint) ([]domain.Task, error) {
+	var tasks []domain.Task
+	if len(boardIDs) == 0 {
+		return tasks, nil
+	}
+	result := r.db.Unscoped().Preload("Column", unscoped).
+		Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id IN ? AND tasks.deleted_at IS NOT NULL", boardIDs).
+		Order("tasks.deleted_at DESC").
+		Find(&tasks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tasks, nil
+}
+
+// GetDeletedBoard IDで削除済みのボードを取得します
+func (r *trashRepository) GetDeletedBoard(id uint) (*domain.Board, error) {
+	var board domain.Board
+	result := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&board)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // 削除済みのボードが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &board, nil
+}
+
+// GetDeletedColumn IDで削除済みのカラムを取得します
+func (r *trashRepository) GetDeletedColumn(id uint) (*domain.Column, error) {
+	var column domain.Column
+	result := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&column)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // 削除済みのカラムが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &column, nil
+}
+
+// GetDeletedTask IDで削除済みのタスクを取得します（削除済みのカラムも含めて読み込みます）
+func (r *trashRepository) GetDeletedTask(id uint) (*domain.Task, error) {
+	var task domain.Task
+	result := r.db.Unscoped().Preload("Column", unscoped).Where("id = ? AND deleted_at IS NOT NULL", id).First(&task)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // 削除済みのタスクが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &task, nil
+}
+
+// RestoreBoard 削除済みのボードを復元します
+func (r *trashRepository) RestoreBoard(id uint) error {
+	return r.db.Unscoped().Model(&domain.Board{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// RestoreColumn 削除済みのカラムを復元します
+// 削除前の順序がボード内で有効であればその位置に、そうでなければ末尾に戻します
+// 並び替えと競合しないようボードのカラムをロックし、順序を変えたカラムと復元したカラムのバージョンを進めます
+func (r *trashRepository) RestoreColumn(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var column domain.Column
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&column, id).Error; err != nil {
+			return err
+		}
+		if !column.DeletedAt.Valid {
+			// 他のリクエストで復元済み
+			return nil
+		}
+		columns, err := lockBoardColumns(tx, column.BoardID)
+		if err != nil {
+			return err
+		}
+
+		maxOrder := 0
+		for _, c := range columns {
+			maxOrder = max(maxOrder, c.Order)
+		}
+		order := restoreOrder(column.Order, maxOrder)
+
+		// 挿入位置以降のカラムを後ろにシフト
+		if err := tx.Model(&domain.Column{}).
+			Where("board_id = ? AND \"order\" >= ?", column.BoardID, order).
+			Updates(map[string]interface{}{"order": gorm.Expr("\"order\" + 1"), "version": bumpVersion}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&domain.Column{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"deleted_at": nil, "order": order, "version": bumpVersion}).Error
+	})
+}
+
+// lockBoardColumns ボードの（削除されていない）カラムをID順に行ロックして取得します
+func lockBoardColumns(tx *gorm.DB, boardID uint) ([]domain.Column, error) {
+	var columns []domain.Column
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("board_id = ?", boardID).Order("id").Find(&columns).Error; err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// RestoreTask 削除済みのタスクを指定したカラムに復元します
+// 元のセルに戻す場合は削除前のランクのままにして元の位置付近に、それ以外は末尾に追加します
+// 元のレーンが削除されている場合はレーンなしのセルに戻します
+func (r *trashRepository) RestoreTask(id uint, columnID uint) error {
+	var task domain.Task
+	if err := r.db.Unscoped().First(&task, id).Error; err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockColumn(tx, columnID); err != nil {
+			return err
+		}
+
+		laneID, laneKept := task.LaneID, true
+		if laneID != nil {
+			var count int64
+			if err := tx.Model(&domain.Lane{}).Where("id = ?", *laneID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				laneID, laneKept = nil, false
+			}
+		}
+
+		rank := task.Rank
+		if task.ColumnID != columnID || !laneKept || rank == "" {
+			var err error
+			if rank, err = rankAt(tx, columnID, laneID, math.MaxInt32, task.ID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Model(&domain.Task{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"deleted_at": nil, "column_id": columnID, "lane_id": laneID, "rank": rank}).Error
+	})
+}
+
+// restoreOrder 削除前の順序が有効な範囲（1〜末尾+1）であればそれを、そうでなければ末尾+1を返します
+func restoreOrder(order, maxOrder int) int {
+	if order < 1 || order > maxOrder+1 {
+		return maxOrder + 1
+	}
+	return order
+}
+
+// PurgeDeletedBefore cutoffより前に削除されたボード・カラム・タスクを完全に削除します
+// 完全削除するボード・カラムに属するデータ（タスク、タイマー、予定、履歴、ラベル等）も合わせて削除します
+func (r *trashRepository) PurgeDeletedBefore(cutoff time.Time) (*TrashPurgeResult, error) {
+	result := &TrashPurgeResult{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var boardIDs, columnIDs, taskIDs []uint
+
+		if err := tx.Unscoped().Model(&domain.Board{}).
+			Where("deleted_at < ?", cutoff).
+			Pluck("id", &boardIDs).Error; err != nil {
+			return err
+		}
+
+		columns := tx.Unscoped().Model(&domain.Column{}).Where("deleted_at < ?", cutoff)
+		if len(boardIDs) > 0 {
+			columns = columns.Or("board_id IN ?", boardIDs)
+		}
+		if err := columns.Pluck("id", &columnIDs).Error; err != nil {
+			return err
+		}
+
+		tasks := tx.Unscoped().Model(&domain.Task{}).Where("deleted_at < ?", cutoff)
+		if len(columnIDs) > 0 {
+			tasks = tasks.Or("column_id IN ?", columnIDs)
+		}
+		if err := tasks.Pluck("id", &taskIDs).Error; err != nil {
+			return err
+		}
+
+		return purge(tx, boardIDs, columnIDs, taskIDs, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// PurgeBoards 削除済みかどうかにかかわらず、ボードとボードに属するデータを完全に削除します
+func (r *trashRepository) PurgeBoards(boardIDs []uint) (*TrashPurgeResult, error) {
+	result := &TrashPurgeResult{}
+	if len(boardIDs) == 0 {
+		return result, nil
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var columnIDs, taskIDs []uint
+		if err := tx.Unscoped().Model(&domain.Column{}).
+			Where("board_id IN ?", boardIDs).
+			Pluck("id", &columnIDs).Error; err != nil {
+			return err
+		}
+		if len(columnIDs) > 0 {
+			if err := tx.Unscoped().Model(&domain.Task{}).
+				Where("column_id IN ?", columnIDs).
+				Pluck("id", &taskIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		return purge(tx, boardIDs, columnIDs, taskIDs, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// purge ボード・カラム・タスクと、それぞれに紐づくデータ（タイマー、予定、履歴、ラベル、Webhook、自動化ルール等）を完全に削除します
+func purge(tx *gorm.DB, boardIDs, columnIDs, taskIDs []uint, result *TrashPurgeResult) error {
+	// タスクに紐づくデータを削除
+	if len(taskIDs) > 0 {
+		if err := tx.Exec("DELETE FROM task_labels WHERE task_id IN ?", taskIDs).Error; err != nil {
+			return err
+		}
+		for _, model := range []interface{}{&domain.TaskCustomFieldValue{}, &domain.TimerSession{}, &domain.CalendarEvent{}, &domain.TaskActivity{}, &domain.AutomationExecution{}} {
+			if err := tx.Unscoped().Where("task_id IN ?", taskIDs).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		deleted := tx.Unscoped().Where("id IN ?", taskIDs).Delete(&domain.Task{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Tasks = deleted.RowsAffected
+	}
+
+	if len(columnIDs) > 0 {
+		deleted := tx.Unscoped().Where("id IN ?", columnIDs).Delete(&domain.Column{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Columns = deleted.RowsAffected
+	}
+
+	// ボードに紐づくラベル・カスタムフィールド・レーン・Webhook・自動化ルールを削除
+	if len(boardIDs) > 0 {
+		// 配信待ちのWebhookも送信されないよう、配信ログごと削除する
+		if err := tx.Exec("DELETE FROM webhook_deliveries WHERE webhook_id IN (SELECT id FROM webhooks WHERE board_id IN ?)", boardIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_labels WHERE label_id IN (SELECT id FROM labels WHERE board_id IN ?)", boardIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_custom_field_values WHERE field_id IN (SELECT id FROM custom_field_definitions WHERE board_id IN ?)", boardIDs).Error; err != nil {
+			return err
+		}
+		for _, model := range []interface{}{&domain.Label{}, &domain.CustomFieldDefinition{}, &domain.Lane{}, &domain.Webhook{}, &domain.AutomationExecution{}, &domain.AutomationRule{}} {
+			if err := tx.Unscoped().Where("board_id IN ?", boardIDs).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		deleted := tx.Unscoped().Where("id IN ?", boardIDs).Delete(&domain.Board{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Boards = deleted.RowsAffected
+	}
+
+	return nil
+}
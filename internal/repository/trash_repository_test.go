@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ボードの完全削除で、ボードのWebhook（配信待ちを含む）と自動化ルール・実行ログも削除されることのテスト
+func TestPurgeBoards_DeletesWebhooksAndAutomation(t *testing.T) {
+	db := openTestDB(t)
+
+	user := &domain.User{Email: fmt.Sprintf("purge-%s@example.com", uuid.NewString()), PasswordHash: "x"}
+	require.NoError(t, db.Create(user).Error)
+	t.Cleanup(func() { db.Unscoped().Delete(user) })
+	board := &domain.Board{Name: "完全削除テスト", OwnerID: user.ID}
+	require.NoError(t, db.Create(board).Error)
+	column := &domain.Column{BoardID: board.ID, Title: "To Do", Order: 1}
+	require.NoError(t, db.Create(column).Error)
+	task := &domain.Task{ColumnID: column.ID, Title: "タスク", Priority: domain.TaskPriorityNone}
+	require.NoError(t, NewTaskRepository(db).Create(task))
+
+	webhook := &domain.Webhook{BoardID: board.ID, URL: "https://example.com/hook", Secret: "s3cret", Events: domain.StringList{}, Active: true}
+	require.NoError(t, db.Create(webhook).Error)
+	delivery := &domain.WebhookDelivery{WebhookID: webhook.ID, Event: domain.WebhookEventTaskCreated, Payload: "{}", Status: domain.WebhookDeliveryPending, NextAttemptAt: time.Now()}
+	require.NoError(t, db.Create(delivery).Error)
+	rule := &domain.AutomationRule{
+		BoardID:    board.ID,
+		Name:       "完了にする",
+		Enabled:    true,
+		Trigger:    domain.AutomationTriggerTaskCreated,
+		Conditions: domain.AutomationConditions{},
+		Actions:    domain.AutomationActions{},
+	}
+	require.NoError(t, db.Create(rule).Error)
+	execution := &domain.AutomationExecution{RuleID: rule.ID, BoardID: board.ID, TaskID: task.ID, Trigger: rule.Trigger, Status: domain.AutomationExecutionSucceeded}
+	require.NoError(t, db.Create(execution).Error)
+
+	result, err := NewTrashRepository(db).PurgeBoards([]uint{board.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Boards)
+	assert.Equal(t, int64(1), result.Tasks)
+
+	for name, query := range map[string]*gorm.DB{
+		"webhooks":              db.Model(&domain.Webhook{}).Where("id = ?", webhook.ID),
+		"webhook_deliveries":    db.Model(&domain.WebhookDelivery{}).Where("id = ?", delivery.ID),
+		"automation_rules":      db.Model(&domain.AutomationRule{}).Where("id = ?", rule.ID),
+		"automation_executions": db.Model(&domain.AutomationExecution{}).Where("id = ?", execution.ID),
+	} {
+		var count int64
+		require.NoError(t, query.Count(&count).Error)
+		assert.Zero(t, count, name)
+	}
+}
+
+// カラムの復元で、元の位置以降のカラムをずらしてバージョンを進めることのテスト
+func TestRestoreColumn_ShiftsOrderAndBumpsVersions(t *testing.T) {
+	db := openTestDB(t)
+
+	user := &domain.User{Email: fmt.Sprintf("restore-%s@example.com", uuid.NewString()), PasswordHash: "x"}
+	require.NoError(t, db.Create(user).Error)
+	board := &domain.Board{Name: "カラム復元テスト", OwnerID: user.ID}
+	require.NoError(t, db.Create(board).Error)
+	t.Cleanup(func() {
+		db.Unscoped().Where("board_id = ?", board.ID).Delete(&domain.Column{})
+		db.Unscoped().Delete(board)
+		db.Unscoped().Delete(user)
+	})
+	var columns []*domain.Column
+	for i, title := range []string{"To Do", "Doing", "Done"} {
+		column := &domain.Column{BoardID: board.ID, Title: title, Order: i + 1}
+		require.NoError(t, db.Create(column).Error)
+		columns = append(columns, column)
+	}
+
+	columnRepo := NewColumnRepository(db)
+	require.NoError(t, columnRepo.Delete(columns[1].ID))
+	var before []domain.Column
+	require.NoError(t, db.Unscoped().Where("board_id = ?", board.ID).Order("id").Find(&before).Error)
+	require.Equal(t, 2, before[2].Order)
+
+	trashRepo := NewTrashRepository(db)
+	require.NoError(t, trashRepo.RestoreColumn(columns[1].ID))
+	// 復元済みのカラムをもう一度復元しても順序は変わらない
+	require.NoError(t, trashRepo.RestoreColumn(columns[1].ID))
+
+	restored, err := columnRepo.GetByBoardID(board.ID)
+	require.NoError(t, err)
+	require.Len(t, restored, 3)
+	for i, column := range restored {
+		assert.Equal(t, columns[i].ID, column.ID)
+		assert.Equal(t, i+1, column.Order)
+	}
+	assert.Equal(t, before[0].Version, restored[0].Version, "前のカラムは変更しない")
+	assert.Greater(t, restored[1].Version, before[1].Version)
+	assert.Greater(t, restored[2].Version, before[2].Version, "ずらしたカラムのバージョンを進める")
+}
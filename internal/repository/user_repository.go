@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"time"
+
 	"simple-kanban/internal/domain"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserRepository ユーザーのデータアクセスを管理するインターフェース
@@ -15,6 +18,9 @@ type UserRepository interface {
 	Update(user *domain.User) error
 	Delete(id uuid.UUID) error
 	List(limit, offset int) ([]domain.User, error)
+	IncrementFailedLogins(id uuid.UUID) (int, error)
+	LockUntil(id uuid.UUID, until time.Time) error
+	ResetFailedLogins(id uuid.UUID) error
 }
 
 // userRepository UserRepositoryの実装
@@ -27,18 +33,19 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
-// Create 新しいユーザーを作成します
+// Create 新しいユーザーを個人用ワークスペースとともに作成します
 func (r *userRepository) Create(user *domain.User) error {
 	// UUIDを生成（データベースで自動生成されない場合）
 	if user.ID == uuid.Nil {
 		user.ID = uuid.New()
 	}
 
-	result := r.db.Create(user)
-	if result.Error != nil {
-		return result.Error
-	}
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Create(domain.NewPersonalWorkspace(user.ID)).Error
+	})
 }
 
 // GetByID IDでユーザーを取得します
@@ -94,3 +101,29 @@ func (r *userRepository) List(limit, offset int) ([]domain.User, error) {
 	}
 	return users, nil
 }
+
+// IncrementFailedLogins 連続したログイン失敗の回数を1増やし、増やした後の回数を返します
+// 同時に失敗しても数え漏れがないよう、データベース上で加算します
+func (r *userRepository) IncrementFailedLogins(id uuid.UUID) (int, error) {
+	user := domain.User{ID: id}
+	result := r.db.Model(&user).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "failed_logins"}}}).
+		UpdateColumn("failed_logins", gorm.Expr("failed_logins + 1"))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return user.FailedLogins, nil
+}
+
+// LockUntil 期限までログインをロックします
+func (r *userRepository) LockUntil(id uuid.UUID, until time.Time) error {
+	return r.db.Model(&domain.User{}).Where("id = ?", id).UpdateColumn("locked_until", until).Error
+}
+
+// ResetFailedLogins ログイン失敗の回数とロックを解除します
+func (r *userRepository) ResetFailedLogins(id uuid.UUID) error {
+	return r.db.Model(&domain.User{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"failed_logins": 0,
+		"locked_until":  nil,
+	}).Error
+}
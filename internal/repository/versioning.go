@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"simple-kanban/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// saveVersioned 読み込んだ時点からバージョンが変わっていない場合のみ全項目を保存し、バージョンを1つ進めます
+// 他の操作で既に更新されていた場合はdomain.ErrVersionConflictを返します
+// omitに指定したカラムとリレーションは保存しません
+func saveVersioned(db *gorm.DB, value interface{}, version *int, omit ...string) error {
+	expected := *version
+	*version = expected + 1
+
+	result := db.Model(value).
+		Select("*").
+		Omit(append([]string{clause.Associations, "created_at"}, omit...)...).
+		Where("version = ?", expected).
+		Updates(value)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = domain.ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = expected
+		return result.Error
+	}
+	return nil
+}
+
+// withVersion バージョンが一致するレコードに絞り込む条件を追加します
+// versionがdomain.AnyVersionの場合は絞り込みません
+func withVersion(version int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if version == domain.AnyVersion {
+			return db
+		}
+		return db.Where("version = ?", version)
+	}
+}
+
+// bumpVersion 更新時にバージョンを1つ進めるための値
+var bumpVersion = gorm.Expr("version + 1")
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository Webhookと配信のデータアクセスを管理するインターフェース
+type WebhookRepository interface {
+	Create(webhook *domain.Webhook) error
+	GetByID(id uint) (*domain.Webhook, error)
+	GetByBoardID(boardID uint) ([]domain.Webhook, error)
+	Update(webhook *domain.Webhook) error
+	Delete(id uint) error
+	CreateDeliveries(deliveries []domain.WebhookDelivery) error
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	GetDeliveriesByWebhookID(webhookID uint, limit int) ([]domain.WebhookDelivery, error)
+	UpdateDelivery(delivery *domain.WebhookDelivery) error
+}
+
+// webhookRepository WebhookRepositoryの実装
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository WebhookRepositoryの新しいインスタンスを作成
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// Create 新しいWebhookを作成します
+func (r *webhookRepository) Create(webhook *domain.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+// GetByID IDでWebhookを取得します
+func (r *webhookRepository) GetByID(id uint) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	result := r.db.Where("id = ?", id).First(&webhook)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // Webhookが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &webhook, nil
+}
+
+// GetByBoardID ボードのWebhook一覧を取得します（作成順）
+func (r *webhookRepository) GetByBoardID(boardID uint) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	result := r.db.Where("board_id = ?", boardID).Order("id ASC").Find(&webhooks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return webhooks, nil
+}
+
+// Update Webhookを更新します
+func (r *webhookRepository) Update(webhook *domain.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+// Delete Webhookと配信の記録を削除します
+func (r *webhookRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Webhook{}, id).Error
+	})
+}
+
+// CreateDeliveries 配信をキューに追加します
+func (r *webhookRepository) CreateDeliveries(deliveries []domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Create(&deliveries).Error
+}
+
+// ClaimDueDeliveries 配信予定時刻を過ぎた配信待ちの配信を古い順に取得し、処理中として確保します
+// 複数のインスタンスで同じ配信を送信しないよう、行をロックして（ロック中の行は読み飛ばす）配信予定時刻をleaseだけ先に延ばします
+// 送信後に結果を更新しないまま停止した場合は、leaseが過ぎると再び配信の対象になります
+func (r *webhookRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&deliveries)
+		if result.Error != nil || len(deliveries) == 0 {
+			return result.Error
+		}
+
+		ids := make([]uint, len(deliveries))
+		leaseUntil := now.Add(lease)
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+			deliveries[i].NextAttemptAt = leaseUntil
+		}
+		return tx.Model(&domain.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetDeliveriesByWebhookID Webhookの配信ログを新しい順に取得します
+func (r *webhookRepository) GetDeliveriesByWebhookID(webhookID uint, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	result := r.db.Where("webhook_id = ?", webhookID).Order("id DESC").Limit(limit).Find(&deliveries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery 配信の結果を更新します
+func (r *webhookRepository) UpdateDelivery(delivery *domain.WebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 複数のインスタンスが同時に配信を確保しても、同じ配信を重複して確保しないことのテスト
+func TestClaimDueDeliveries_Concurrent(t *testing.T) {
+	db := openTestDB(t)
+
+	user := &domain.User{Email: fmt.Sprintf("webhook-%s@example.com", uuid.NewString()), PasswordHash: "x"}
+	require.NoError(t, db.Create(user).Error)
+	board := &domain.Board{Name: "配信テスト", OwnerID: user.ID}
+	require.NoError(t, db.Create(board).Error)
+	webhook := &domain.Webhook{BoardID: board.ID, URL: "https://example.com/hook", Secret: "secret", Active: true}
+	require.NoError(t, db.Create(webhook).Error)
+	t.Cleanup(func() {
+		db.Where("webhook_id = ?", webhook.ID).Delete(&domain.WebhookDelivery{})
+		db.Delete(webhook)
+		db.Unscoped().Delete(board)
+		db.Unscoped().Delete(user)
+	})
+
+	now := time.Now()
+	const deliveryCount = 30
+	deliveries := make([]domain.WebhookDelivery, deliveryCount)
+	for i := range deliveries {
+		deliveries[i] = domain.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			Event:         domain.WebhookEventTaskCreated,
+			Payload:       "{}",
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now.Add(-time.Minute),
+		}
+	}
+	repo := NewWebhookRepository(db)
+	require.NoError(t, repo.CreateDeliveries(deliveries))
+
+	var mu sync.Mutex
+	claimed := make(map[uint]int)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 確保できる配信がなくなるまで繰り返す
+			for {
+				due, err := repo.ClaimDueDeliveries(now, time.Minute, 10)
+				if !assert.NoError(t, err) || len(due) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, delivery := range due {
+					if delivery.WebhookID == webhook.ID {
+						claimed[delivery.ID]++
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, claimed, deliveryCount)
+	for id, count := range claimed {
+		assert.Equal(t, 1, count, "配信 %d が重複して確保されました", id)
+	}
+
+	// 確保した配信は、leaseが過ぎるまで再び確保されない
+	due, err := repo.ClaimDueDeliveries(now, time.Minute, deliveryCount)
+	require.NoError(t, err)
+	for _, delivery := range due {
+		assert.NotEqual(t, webhook.ID, delivery.WebhookID)
+	}
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkspaceRepository ワークスペースとメンバーのデータアクセスを管理するインターフェース
+type WorkspaceRepository interface {
+	Create(workspace *domain.Workspace) error
+	GetByID(id uint) (*domain.Workspace, error)
+	GetByUserID(userID uuid.UUID) ([]domain.Workspace, error)
+	GetPersonal(userID uuid.UUID) (*domain.Workspace, error)
+	Update(workspace *domain.Workspace) error
+	Delete(id uint) error
+	CountBoards(id uint) (int64, error)
+	GetMember(workspaceID uint, userID uuid.UUID) (*domain.WorkspaceMember, error)
+	GetMembers(workspaceID uint) ([]domain.WorkspaceMember, error)
+	AddMember(member *domain.WorkspaceMember) error
+	UpdateMemberRole(workspaceID uint, userID uuid.UUID, role domain.WorkspaceRole) error
+	RemoveMember(workspaceID uint, userID uuid.UUID) error
+	CountAdmins(workspaceID uint) (int64, error)
+}
+
+// workspaceRepository WorkspaceRepositoryの実装
+type workspaceRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkspaceRepository WorkspaceRepositoryの新しいインスタンスを作成
+func NewWorkspaceRepository(db *gorm.DB) WorkspaceRepository {
+	return &workspaceRepository{db: db}
+}
+
+// Create 新しいワークスペースをメンバーとともに作成します
+func (r *workspaceRepository) Create(workspace *domain.Workspace) error {
+	return r.db.Create(workspace).Error
+}
+
+// GetByID IDでワークスペースを取得します
+func (r *workspaceRepository) GetByID(id uint) (*domain.Workspace, error) {
+	var workspace domain.Workspace
+	result := r.db.Where("id = ?", id).First(&workspace)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // ワークスペースが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &workspace, nil
+}
+
+// GetByUserID ユーザーがメンバーのワークスペース一覧を取得します（個人用、名前の順）
+// Membersにはそのユーザーのメンバー情報（役割）のみを読み込みます
+func (r *workspaceRepository) GetByUserID(userID uuid.UUID) ([]domain.Workspace, error) {
+	var workspaces []domain.Workspace
+	result := r.db.Preload("Members", "user_id = ?", userID).
+		Where("id IN (?)", memberWorkspaceIDs(r.db, userID)).
+		Order("personal DESC").Order("name ASC").Order("id ASC").
+		Find(&workspaces)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return workspaces, nil
+}
+
+// GetPersonal ユーザーの個人用ワークスペースを取得します
+func (r *workspaceRepository) GetPersonal(userID uuid.UUID) (*domain.Workspace, error) {
+	var workspace domain.Workspace
+	result := r.db.Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspaces.personal = ? AND workspace_members.user_id = ?", true, userID).
+		First(&workspace)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // 個人用ワークスペースが見つからない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &workspace, nil
+}
+
+// Update ワークスペースを更新します
+func (r *workspaceRepository) Update(workspace *domain.Workspace) error {
+	return r.db.Omit("Members").Save(workspace).Error
+}
+
+// Delete ワークスペースをメンバーとともに削除します
+func (r *workspaceRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ?", id).Delete(&domain.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Workspace{}, id).Error
+	})
+}
+
+// CountBoards ワークスペースのボード数を取得します（ゴミ箱のボードも含みます）
+func (r *workspaceRepository) CountBoards(id uint) (int64, error) {
+	var count int64
+	result := r.db.Unscoped().Model(&domain.Board{}).Where("workspace_id = ?", id).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// GetMember ワークスペースのメンバー情報を取得します
+func (r *workspaceRepository) GetMember(workspaceID uint, userID uuid.UUID) (*domain.WorkspaceMember, error) {
+	var member domain.WorkspaceMember
+	result := r.db.Preload("User").Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // メンバーでない場合はnilを返す
+		}
+		return nil, result.Error
+	}
+	return &member, nil
+}
+
+// GetMembers ワークスペースのメンバー一覧を取得します（参加した順）
+func (r *workspaceRepository) GetMembers(workspaceID uint) ([]domain.WorkspaceMember, error) {
+	var members []domain.WorkspaceMember
+	result := r.db.Preload("User").Where("workspace_id = ?", workspaceID).
+		Order("created_at ASC").Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+// AddMember ワークスペースにメンバーを追加します
+func (r *workspaceRepository) AddMember(member *domain.WorkspaceMember) error {
+	return r.db.Omit("User").Create(member).Error
+}
+
+// UpdateMemberRole メンバーの役割を変更します
+func (r *workspaceRepository) UpdateMemberRole(workspaceID uint, userID uuid.UUID, role domain.WorkspaceRole) error {
+	return r.db.Model(&domain.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role).Error
+}
+
+// RemoveMember ワークスペースからメンバーを削除します
+func (r *workspaceRepository) RemoveMember(workspaceID uint, userID uuid.UUID) error {
+	return r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Delete(&domain.WorkspaceMember{}).Error
+}
+
+// CountAdmins ワークスペースの管理者の数を取得します
+func (r *workspaceRepository) CountAdmins(workspaceID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&domain.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ?", workspaceID, domain.WorkspaceRoleAdmin).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidBoardRecipient ボードの移行先に指定できないユーザーの場合のエラー（未登録のメールアドレスの場合も同じ）
+var ErrInvalidBoardRecipient = errors.New("ボードの移行先には、同じワークスペースのメンバーを指定してください")
+
+// AccountService アカウントのデータのエクスポートと削除を管理するインターフェース
+type AccountService interface {
+	ExportAccount(userID uuid.UUID) (*domain.AccountExport, error)
+	DeleteAccount(userID uuid.UUID, password, transferTo string) error
+}
+
+// accountService AccountServiceの実装
+type accountService struct {
+	db                   *gorm.DB // 削除用のデータベース接続
+	accountRepo          repository.AccountRepository
+	userRepo             repository.UserRepository
+	boardRepo            repository.BoardRepository
+	calendarSettingsRepo repository.CalendarSettingsRepository
+	calendarEventRepo    repository.CalendarEventRepository
+	timerSessionRepo     repository.TimerSessionRepository
+	boardExportService   BoardExportService
+}
+
+// NewAccountService AccountServiceの新しいインスタンスを作成
+func NewAccountService(db *gorm.DB, accountRepo repository.AccountRepository, userRepo repository.UserRepository, boardRepo repository.BoardRepository, calendarSettingsRepo repository.CalendarSettingsRepository, calendarEventRepo repository.CalendarEventRepository, timerSessionRepo repository.TimerSessionRepository, boardExportService BoardExportService) AccountService {
+	return &accountService{
+		db:                   db,
+		accountRepo:          accountRepo,
+		userRepo:             userRepo,
+		boardRepo:            boardRepo,
+		calendarSettingsRepo: calendarSettingsRepo,
+		calendarEventRepo:    calendarEventRepo,
+		timerSessionRepo:     timerSessionRepo,
+		boardExportService:   boardExportService,
+	}
+}
+
+// ExportAccount ユーザーが所有するデータ（プロフィール、ボード、タイマー、予定、設定）をエクスポートします
+// ボードは自分だけがメンバーのワークスペースのボードと、共有のワークスペースで自分が作成したボードです
+func (s *accountService) ExportAccount(userID uuid.UUID) (*domain.AccountExport, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("ユーザーが見つかりません")
+	}
+
+	export := &domain.AccountExport{
+		Format:         domain.AccountExportFormat,
+		Version:        domain.AccountExportVersion,
+		ExportedAt:     time.Now(),
+		Profile:        domain.NewExportedProfile(user),
+		TimerSessions:  []domain.ExportedTimerSession{},
+		CalendarEvents: []domain.ExportedCalendarEvent{},
+		Boards:         []domain.BoardExport{},
+	}
+
+	// 共有のワークスペースのボードは他のメンバーのデータでもあるため、自分が作成したボードに限る
+	workspaceIDs, err := s.accountRepo.GetSoleMemberWorkspaceIDs(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ワークスペース取得エラー: %w", err)
+	}
+	owned := make(map[uint]bool, len(workspaceIDs))
+	for _, id := range workspaceIDs {
+		owned[id] = true
+	}
+	boards, err := s.boardRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	for _, board := range boards {
+		if !owned[board.WorkspaceID] && board.OwnerID != userID {
+			continue
+		}
+		boardExport, err := s.boardExportService.ExportBoard(board.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		export.Boards = append(export.Boards, *boardExport)
+	}
+
+	settings, err := s.calendarSettingsRepo.GetByUserID(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("カレンダー設定取得エラー: %w", err)
+	}
+	if settings != nil {
+		export.CalendarSettings = &domain.ExportedCalendarSettings{
+			WeekdayStartTime: settings.WeekdayStartTime,
+			WeekdayEndTime:   settings.WeekdayEndTime,
+			WeekendStartTime: settings.WeekendStartTime,
+			WeekendEndTime:   settings.WeekendEndTime,
+			TimeSlotDuration: settings.TimeSlotDuration,
+		}
+	}
+
+	sessions, err := s.timerSessionRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("タイマーセッション取得エラー: %w", err)
+	}
+	for _, session := range sessions {
+		export.TimerSessions = append(export.TimerSessions, domain.ExportedTimerSession{
+			TaskID:    session.TaskID,
+			TaskTitle: session.Task.Title,
+			StartTime: session.StartTime,
+			EndTime:   session.EndTime,
+			Duration:  session.Duration,
+		})
+	}
+
+	events, err := s.calendarEventRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("カレンダーイベント取得エラー: %w", err)
+	}
+	for _, event := range events {
+		export.CalendarEvents = append(export.CalendarEvents, domain.ExportedCalendarEvent{
+			Title:       event.Title,
+			Start:       event.Start,
+			End:         event.End,
+			Color:       event.Color,
+			TaskID:      event.TaskID,
+			IsTaskBased: event.IsTaskBased,
+		})
+	}
+
+	return export, nil
+}
+
+// DeleteAccount パスワードを確認してアカウントを削除します
+// 自分だけがメンバーのワークスペースのボードはtransferToのメールアドレスのユーザー（同じワークスペースのメンバーのみ）の個人用ワークスペースに移し、
+// 指定しない場合は完全に削除します。共有のワークスペースのボードは他のメンバーに残ります
+// タスクの履歴の参照先として、ユーザーは個人情報を消去した上でソフトデリートします
+func (s *accountService) DeleteAccount(userID uuid.UUID, password, transferTo string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil {
+		return errors.New("ユーザーが見つかりません")
+	}
+	if err := confirmPassword(user, password); err != nil {
+		return err
+	}
+
+	// 唯一の管理者が抜けると、共有のワークスペースを誰も管理できなくなる
+	blocking, err := s.accountRepo.GetSoleAdminWorkspaces(user.ID)
+	if err != nil {
+		return fmt.Errorf("ワークスペース取得エラー: %w", err)
+	}
+	if len(blocking) > 0 {
+		names := make([]string, 0, len(blocking))
+		for _, workspace := range blocking {
+			names = append(names, workspace.Name)
+		}
+		return fmt.Errorf("唯一の管理者になっているワークスペースがあります。先に他のメンバーを管理者にしてください: %s", strings.Join(names, ", "))
+	}
+
+	var recipient *domain.User
+	if transferTo != "" {
+		if strings.EqualFold(transferTo, user.Email) {
+			return errors.New("ボードの移行先に自分は指定できません")
+		}
+		recipient, err = s.userRepo.GetByEmail(transferTo)
+		if err != nil {
+			return fmt.Errorf("ユーザー取得エラー: %w", err)
+		}
+		shared := false
+		if recipient != nil {
+			if shared, err = s.accountRepo.SharesWorkspace(user.ID, recipient.ID); err != nil {
+				return fmt.Errorf("ワークスペース取得エラー: %w", err)
+			}
+		}
+		if !shared {
+			// 登録されているメールアドレスかどうかは明かさない
+			return ErrInvalidBoardRecipient
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		accountRepo := repository.NewAccountRepository(tx)
+		userRepo := repository.NewUserRepository(tx)
+
+		workspaceIDs, err := accountRepo.GetSoleMemberWorkspaceIDs(user.ID)
+		if err != nil {
+			return fmt.Errorf("ワークスペース取得エラー: %w", err)
+		}
+		boardIDs, err := accountRepo.GetBoardIDs(workspaceIDs)
+		if err != nil {
+			return fmt.Errorf("ボード取得エラー: %w", err)
+		}
+
+		if recipient != nil {
+			workspaceID, err := resolveWorkspace(repository.NewWorkspaceRepository(tx), recipient.ID, 0)
+			if err != nil {
+				return err
+			}
+			if _, err := accountRepo.MoveBoards(boardIDs, workspaceID); err != nil {
+				return fmt.Errorf("ボード移行エラー: %w", err)
+			}
+		} else {
+			if _, err := repository.NewTrashRepository(tx).PurgeBoards(boardIDs); err != nil {
+				return fmt.Errorf("ボード削除エラー: %w", err)
+			}
+		}
+
+		if err := accountRepo.DeleteWorkspaces(workspaceIDs); err != nil {
+			return fmt.Errorf("ワークスペース削除エラー: %w", err)
+		}
+		if err := accountRepo.DeleteUserData(user.ID); err != nil {
+			return fmt.Errorf("ユーザーデータ削除エラー: %w", err)
+		}
+
+		// 削除したユーザーのJWTはValidateSessionで使用できなくなる
+		user.Anonymize()
+		if err := userRepo.Update(user); err != nil {
+			return fmt.Errorf("ユーザー更新エラー: %w", err)
+		}
+		if err := userRepo.Delete(user.ID); err != nil {
+			return fmt.Errorf("ユーザー削除エラー: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"sort"
+	"testing"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// memoryAccountRepository テスト用のAccountRepository（使用するメソッドのみ実装）
+type memoryAccountRepository struct {
+	repository.AccountRepository
+	soleMemberWorkspaceIDs []uint
+	coworkers              map[uuid.UUID]bool // 同じワークスペースのメンバーのユーザー
+}
+
+func (r *memoryAccountRepository) GetSoleMemberWorkspaceIDs(userID uuid.UUID) ([]uint, error) {
+	return r.soleMemberWorkspaceIDs, nil
+}
+
+func (r *memoryAccountRepository) GetSoleAdminWorkspaces(userID uuid.UUID) ([]domain.Workspace, error) {
+	return nil, nil
+}
+
+func (r *memoryAccountRepository) SharesWorkspace(userID, otherID uuid.UUID) (bool, error) {
+	return r.coworkers[otherID], nil
+}
+
+// emptyCalendarSettingsRepository 記録のないユーザーのCalendarSettingsRepository（使用するメソッドのみ実装）
+type emptyCalendarSettingsRepository struct {
+	repository.CalendarSettingsRepository
+}
+
+func (r *emptyCalendarSettingsRepository) GetByUserID(userID uuid.UUID) (*domain.CalendarSettings, error) {
+	return nil, nil
+}
+
+// emptyCalendarEventRepository 記録のないユーザーのCalendarEventRepository（使用するメソッドのみ実装）
+type emptyCalendarEventRepository struct {
+	repository.CalendarEventRepository
+}
+
+func (r *emptyCalendarEventRepository) GetByUserID(userID uuid.UUID) ([]*domain.CalendarEvent, error) {
+	return nil, nil
+}
+
+// emptyTimerSessionRepository 記録のないユーザーのTimerSessionRepository（使用するメソッドのみ実装）
+type emptyTimerSessionRepository struct {
+	repository.TimerSessionRepository
+}
+
+func (r *emptyTimerSessionRepository) GetByUserID(userID uuid.UUID) ([]*domain.TimerSession, error) {
+	return nil, nil
+}
+
+// memoryBoardExportService ボード名のみをエクスポートするBoardExportService
+type memoryBoardExportService struct {
+	BoardExportService
+	boards *memoryBoardRepository
+}
+
+func (s *memoryBoardExportService) ExportBoard(boardID uint, userID uuid.UUID) (*domain.BoardExport, error) {
+	return &domain.BoardExport{Board: domain.ExportedBoard{Name: s.boards.boards[boardID].Name}}, nil
+}
+
+// GetByUserID すべてのボードをID順に返します（ユーザーはすべてのワークスペースのメンバーとみなす）
+func (r *memoryBoardRepository) GetByUserID(userID uuid.UUID) ([]domain.Board, error) {
+	boards := make([]domain.Board, 0, len(r.boards))
+	for _, board := range r.boards {
+		boards = append(boards, *board)
+	}
+	sort.Slice(boards, func(i, j int) bool { return boards[i].ID < boards[j].ID })
+	return boards, nil
+}
+
+// 自分だけのワークスペースのボードと、共有のワークスペースで自分が作成したボードをエクスポートすることのテスト
+func TestAccountService_ExportAccountBoards(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	userRepo := &memoryUserRepository{users: map[uuid.UUID]*domain.User{alice: {ID: alice, Email: "alice@example.com"}}}
+	boards := &memoryBoardRepository{boards: map[uint]*domain.Board{
+		1: {ID: 1, Name: "個人のボード", WorkspaceID: 1, OwnerID: alice},
+		2: {ID: 2, Name: "共有で作成したボード", WorkspaceID: 2, OwnerID: alice},
+		3: {ID: 3, Name: "共有の他のメンバーのボード", WorkspaceID: 2, OwnerID: bob},
+	}}
+	svc := NewAccountService(nil, &memoryAccountRepository{soleMemberWorkspaceIDs: []uint{1}}, userRepo, boards,
+		&emptyCalendarSettingsRepository{}, &emptyCalendarEventRepository{}, &emptyTimerSessionRepository{}, &memoryBoardExportService{boards: boards})
+
+	export, err := svc.ExportAccount(alice)
+	require.NoError(t, err)
+	names := make([]string, 0, len(export.Boards))
+	for _, board := range export.Boards {
+		names = append(names, board.Board.Name)
+	}
+	assert.Equal(t, []string{"個人のボード", "共有で作成したボード"}, names)
+}
+
+// ボードの移行先には同じワークスペースのメンバーのみを指定でき、未登録のメールアドレスと区別できないことのテスト
+func TestAccountService_DeleteAccountRecipient(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	alice := &domain.User{ID: uuid.New(), Email: "alice@example.com", PasswordHash: string(hash)}
+	stranger := &domain.User{ID: uuid.New(), Email: "stranger@example.com"}
+	userRepo := &memoryUserRepository{users: map[uuid.UUID]*domain.User{alice.ID: alice, stranger.ID: stranger}}
+	svc := NewAccountService(nil, &memoryAccountRepository{coworkers: map[uuid.UUID]bool{}}, userRepo, &memoryBoardRepository{},
+		&emptyCalendarSettingsRepository{}, &emptyCalendarEventRepository{}, &emptyTimerSessionRepository{}, nil)
+
+	err = svc.DeleteAccount(alice.ID, "password123", stranger.Email)
+	assert.ErrorIs(t, err, ErrInvalidBoardRecipient, "ワークスペースを共有していないユーザーには移行できない")
+	err = svc.DeleteAccount(alice.ID, "password123", "nobody@example.com")
+	assert.ErrorIs(t, err, ErrInvalidBoardRecipient, "未登録のメールアドレスも同じエラーにする")
+	assert.Equal(t, "alice@example.com", userRepo.users[alice.ID].Email, "アカウントは削除されない")
+}
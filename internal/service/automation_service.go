@@ -0,0 +1,582 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// 自動化ルールの連鎖の上限（ルールのアクションが別のルールを実行する段数）
+const MaxAutomationDepth = 5
+
+// 実行ログの取得件数
+const automationExecutionLimit = 100
+
+// AutomationRunner タスクの操作をきっかけに自動化ルールを実行するインターフェース
+// 戻り値はルールのアクションでタスクが変更されたかどうかです（呼び出し側はタスクを取得し直します）
+// ルールの実行に失敗しても元の操作は失敗させないため、エラーは実行ログとログ出力に記録するのみです
+type AutomationRunner interface {
+	TaskCreated(task *domain.Task) bool
+	TaskMoved(task *domain.Task, fromColumnID uint) bool
+	TimerStopped(task *domain.Task) bool
+}
+
+// AutomationService 自動化ルールの管理と実行を行うインターフェース
+type AutomationService interface {
+	AutomationRunner
+	CreateRule(boardID uint, userID uuid.UUID, rule *domain.AutomationRule) (*domain.AutomationRule, error)
+	GetBoardRules(boardID uint, userID uuid.UUID) ([]domain.AutomationRule, error)
+	UpdateRule(ruleID uint, userID uuid.UUID, rule *domain.AutomationRule) (*domain.AutomationRule, error)
+	DeleteRule(ruleID uint, userID uuid.UUID) error
+	GetExecutions(boardID uint, userID uuid.UUID) ([]domain.AutomationExecution, error)
+	RunDueDateRules(now time.Time) (int, error)
+	StartDueDateJob(interval time.Duration) func()
+}
+
+// automationService AutomationServiceの実装
+// タスクサービスから呼び出されるため、タスクの変更はリポジトリを直接使用します
+type automationService struct {
+	automationRepo    repository.AutomationRepository
+	taskRepo          repository.TaskRepository
+	boardRepo         repository.BoardRepository
+	columnRepo        repository.ColumnRepository
+	labelRepo         repository.LabelRepository
+	calendarEventRepo repository.CalendarEventRepository
+	webhooks          WebhookPublisher
+}
+
+// NewAutomationService AutomationServiceの新しいインスタンスを作成
+func NewAutomationService(
+	automationRepo repository.AutomationRepository,
+	taskRepo repository.TaskRepository,
+	boardRepo repository.BoardRepository,
+	columnRepo repository.ColumnRepository,
+	labelRepo repository.LabelRepository,
+	calendarEventRepo repository.CalendarEventRepository,
+	webhooks WebhookPublisher,
+) AutomationService {
+	return &automationService{
+		automationRepo:    automationRepo,
+		taskRepo:          taskRepo,
+		boardRepo:         boardRepo,
+		columnRepo:        columnRepo,
+		labelRepo:         labelRepo,
+		calendarEventRepo: calendarEventRepo,
+		webhooks:          webhooks,
+	}
+}
+
+// automationRun 1回の操作から連鎖して実行されるルールの状態（ループ防止に使用）
+type automationRun struct {
+	depth int
+	fired map[[2]uint]bool // 実行済みの（ルールID, タスクID）の組
+}
+
+func newAutomationRun() *automationRun {
+	return &automationRun{fired: make(map[[2]uint]bool)}
+}
+
+// CreateRule ボードに自動化ルールを作成します
+func (s *automationService) CreateRule(boardID uint, userID uuid.UUID, rule *domain.AutomationRule) (*domain.AutomationRule, error) {
+	if err := s.checkBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	rule.ID = 0
+	rule.BoardID = boardID
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.automationRepo.CreateRule(rule); err != nil {
+		return nil, fmt.Errorf("自動化ルール作成エラー: %w", err)
+	}
+	return rule, nil
+}
+
+// GetBoardRules ボードの自動化ルール一覧を取得します
+func (s *automationService) GetBoardRules(boardID uint, userID uuid.UUID) ([]domain.AutomationRule, error) {
+	if err := s.checkBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.automationRepo.GetRulesByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("自動化ルール取得エラー: %w", err)
+	}
+	return rules, nil
+}
+
+// UpdateRule 自動化ルールの内容を置き換えます
+func (s *automationService) UpdateRule(ruleID uint, userID uuid.UUID, rule *domain.AutomationRule) (*domain.AutomationRule, error) {
+	current, err := s.getOwnedRule(ruleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current.Name = rule.Name
+	current.Enabled = rule.Enabled
+	current.Trigger = rule.Trigger
+	current.TriggerColumnID = rule.TriggerColumnID
+	current.Conditions = rule.Conditions
+	current.Actions = rule.Actions
+	if err := s.validateRule(current); err != nil {
+		return nil, err
+	}
+
+	if err := s.automationRepo.UpdateRule(current); err != nil {
+		return nil, fmt.Errorf("自動化ルール更新エラー: %w", err)
+	}
+	return current, nil
+}
+
+// DeleteRule 自動化ルールを削除します
+func (s *automationService) DeleteRule(ruleID uint, userID uuid.UUID) error {
+	if _, err := s.getOwnedRule(ruleID, userID); err != nil {
+		return err
+	}
+
+	if err := s.automationRepo.DeleteRule(ruleID); err != nil {
+		return fmt.Errorf("自動化ルール削除エラー: %w", err)
+	}
+	return nil
+}
+
+// GetExecutions ボードの自動化ルールの実行ログを新しい順に取得します
+func (s *automationService) GetExecutions(boardID uint, userID uuid.UUID) ([]domain.AutomationExecution, error) {
+	if err := s.checkBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	executions, err := s.automationRepo.GetExecutionsByBoardID(boardID, automationExecutionLimit)
+	if err != nil {
+		return nil, fmt.Errorf("実行ログ取得エラー: %w", err)
+	}
+	return executions, nil
+}
+
+// TaskCreated タスク作成のルールを実行します
+func (s *automationService) TaskCreated(task *domain.Task) bool {
+	return s.trigger(newAutomationRun(), domain.AutomationTriggerTaskCreated, task)
+}
+
+// TaskMoved 別のカラムへの移動のルールを実行します
+func (s *automationService) TaskMoved(task *domain.Task, fromColumnID uint) bool {
+	if task.ColumnID == fromColumnID {
+		return false
+	}
+	return s.trigger(newAutomationRun(), domain.AutomationTriggerTaskMoved, task)
+}
+
+// TimerStopped タイマー停止のルールを実行します
+func (s *automationService) TimerStopped(task *domain.Task) bool {
+	return s.trigger(newAutomationRun(), domain.AutomationTriggerTimerStopped, task)
+}
+
+// RunDueDateRules 期限を過ぎた未完了のタスクに期限切れのルールを実行し、実行したルールの数を返します
+// 期限日は日付のみを表すため、マイワークと同じくnowのタイムゾーンの今日より前の期限日を期限切れとします
+// 同じタスクには、期限日以降に一度だけ実行します（期限を変更すると再び対象になります）
+func (s *automationService) RunDueDateRules(now time.Time) (int, error) {
+	rules, err := s.automationRepo.GetEnabledRulesByTrigger(domain.AutomationTriggerDueDatePassed)
+	if err != nil {
+		return 0, fmt.Errorf("自動化ルール取得エラー: %w", err)
+	}
+	// 期限日は年月日をUTCの0時として保存している
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	count := 0
+	completed := false
+	for i := range rules {
+		rule := &rules[i]
+		tasks, err := s.taskRepo.Search(repository.TaskQuery{
+			BoardIDs:    []uint{rule.BoardID},
+			DueTo:       &today,
+			IsCompleted: &completed,
+		})
+		if err != nil {
+			return count, fmt.Errorf("期限切れタスク取得エラー: %w", err)
+		}
+
+		for j := range tasks {
+			task := &tasks[j]
+			if task.DueDate == nil || !rule.Matches(task) {
+				continue
+			}
+			executed, err := s.automationRepo.HasExecutionSince(rule.ID, task.ID, *task.DueDate)
+			if err != nil {
+				return count, fmt.Errorf("実行ログ取得エラー: %w", err)
+			}
+			if executed {
+				continue
+			}
+			s.runRule(newAutomationRun(), rule, task)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// StartDueDateJob 期限切れのルールを定期的に実行するジョブを開始します
+// 戻り値の関数を呼び出すとジョブを停止します
+func (s *automationService) StartDueDateJob(interval time.Duration) func() {
+	done := make(chan struct{})
+	if interval <= 0 {
+		return func() {}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			count, err := s.RunDueDateRules(time.Now())
+			if err != nil {
+				log.Printf("期限切れの自動化ルールの実行に失敗しました: %v", err)
+			} else if count > 0 {
+				log.Printf("期限切れの自動化ルールを%d件実行しました", count)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// trigger タスクのボードでトリガーに一致する有効なルールを作成順に実行します
+func (s *automationService) trigger(run *automationRun, trigger domain.AutomationTrigger, task *domain.Task) bool {
+	boardID := task.Column.BoardID
+	if boardID == 0 {
+		column, err := s.columnRepo.GetByID(task.ColumnID)
+		if err != nil || column == nil {
+			log.Printf("自動化ルールの実行に失敗しました（カラム取得エラー）: task=%d err=%v", task.ID, err)
+			return false
+		}
+		boardID = column.BoardID
+	}
+
+	rules, err := s.automationRepo.GetEnabledRules(boardID, trigger)
+	if err != nil {
+		log.Printf("自動化ルール取得エラー: %v", err)
+		return false
+	}
+
+	changed := false
+	for i := range rules {
+		rule := &rules[i]
+		if rule.TriggerColumnID != nil && *rule.TriggerColumnID != task.ColumnID {
+			continue
+		}
+		if !rule.Matches(task) {
+			continue
+		}
+		if !s.runRule(run, rule, task) {
+			continue
+		}
+		changed = true
+
+		// 後続のルールは変更後のタスクで条件を判定する
+		updated, err := s.taskRepo.GetByID(task.ID)
+		if err != nil || updated == nil {
+			log.Printf("自動化ルール実行後のタスク取得エラー: task=%d err=%v", task.ID, err)
+			return changed
+		}
+		task = updated
+	}
+	return changed
+}
+
+// runRule ルールのアクションを実行して実行ログを記録し、タスクを変更したかどうかを返します
+// 連鎖の上限を超える場合と、同じ連鎖ですでにタスクに実行したルールは実行しません
+func (s *automationService) runRule(run *automationRun, rule *domain.AutomationRule, task *domain.Task) bool {
+	execution := &domain.AutomationExecution{
+		RuleID:  rule.ID,
+		BoardID: rule.BoardID,
+		TaskID:  task.ID,
+		Trigger: rule.Trigger,
+		Depth:   run.depth,
+	}
+
+	key := [2]uint{rule.ID, task.ID}
+	if run.depth >= MaxAutomationDepth || run.fired[key] {
+		execution.Status = domain.AutomationExecutionSkipped
+		execution.Error = "ループ防止のため実行を中止しました"
+		s.recordExecution(execution)
+		return false
+	}
+	run.fired[key] = true
+
+	applied, changed, movedFrom, err := s.applyActions(rule, task)
+	execution.Actions = applied
+	if err != nil {
+		execution.Status = domain.AutomationExecutionFailed
+		execution.Error = err.Error()
+	} else {
+		execution.Status = domain.AutomationExecutionSucceeded
+	}
+	s.recordExecution(execution)
+
+	// アクションによる移動は、さらに移動のルールを実行する
+	if movedFrom != nil {
+		moved, err := s.taskRepo.GetByID(task.ID)
+		if err != nil || moved == nil {
+			log.Printf("移動したタスク取得エラー: task=%d err=%v", task.ID, err)
+			return changed
+		}
+		if moved.ColumnID != *movedFrom {
+			run.depth++
+			s.trigger(run, domain.AutomationTriggerTaskMoved, moved)
+			run.depth--
+		}
+	}
+	return changed
+}
+
+// applyActions ルールのアクションを定義した順に実行します
+// 実行したアクションの数、タスクを変更したかどうか、移動した場合は移動元のカラムを返します
+// アクションが失敗した場合は、それ以降のアクションを実行しません
+func (s *automationService) applyActions(rule *domain.AutomationRule, task *domain.Task) (int, bool, *uint, error) {
+	now := time.Now()
+	boardID := rule.BoardID
+	changed := false
+	var movedFrom *uint
+
+	for i, action := range rule.Actions {
+		switch action.Type {
+		case domain.AutomationActionSetField, domain.AutomationActionAssign:
+			if action.Type == domain.AutomationActionSetField {
+				if err := action.ApplyField(task, now); err != nil {
+					return i, changed, movedFrom, err
+				}
+			} else {
+				task.AssigneeID = action.AssigneeID
+			}
+			if err := s.taskRepo.Update(task); err != nil {
+				return i, changed, movedFrom, fmt.Errorf("タスク更新エラー: %w", err)
+			}
+			changed = true
+			s.webhooks.Publish(boardID, domain.WebhookEventTaskUpdated, task)
+
+		case domain.AutomationActionMove:
+			if task.ColumnID == action.ColumnID {
+				continue
+			}
+			column, err := s.columnRepo.GetByID(action.ColumnID)
+			if err != nil {
+				return i, changed, movedFrom, fmt.Errorf("カラム取得エラー: %w", err)
+			}
+			if column == nil || column.BoardID != boardID {
+				return i, changed, movedFrom, errors.New("移動先のカラムが見つかりません")
+			}
+
+			// 手動での移動と同じく、移動先のWIP制限（hard）を超える移動は失敗とする
+			if _, err := checkWIPLimit(s.columnRepo, action.ColumnID); err != nil {
+				return i, changed, movedFrom, fmt.Errorf("移動先のカラムに移動できません: %w", err)
+			}
+
+			// 移動先のセルの末尾に追加する（位置はリポジトリで範囲内に丸められる）
+			// 同時実行時の超過はリポジトリでもチェックされる
+			fromColumnID, fromLaneID := task.ColumnID, task.LaneID
+			if err := s.taskRepo.MoveToColumn(task.ID, action.ColumnID, task.LaneID, math.MaxInt32); err != nil {
+				var wipErr *domain.WIPLimitError
+				if errors.As(err, &wipErr) {
+					return i, changed, movedFrom, fmt.Errorf("移動先のカラムに移動できません: %w", err)
+				}
+				return i, changed, movedFrom, fmt.Errorf("タスク移動エラー: %w", err)
+			}
+			if movedFrom == nil {
+				movedFrom = &fromColumnID
+			}
+			changed = true
+
+			if err := s.reload(task); err != nil {
+				return i + 1, changed, movedFrom, err
+			}
+			s.webhooks.Publish(boardID, domain.WebhookEventTaskMoved, newTaskMovedData(task, fromColumnID, fromLaneID))
+
+		case domain.AutomationActionAddLabel:
+			label, err := s.labelRepo.GetByID(action.LabelID)
+			if err != nil {
+				return i, changed, movedFrom, fmt.Errorf("ラベル取得エラー: %w", err)
+			}
+			if label == nil || label.BoardID != boardID {
+				return i, changed, movedFrom, errors.New("付与するラベルが見つかりません")
+			}
+			if err := s.labelRepo.AddToTask(task.ID, action.LabelID); err != nil {
+				return i, changed, movedFrom, fmt.Errorf("ラベル付与エラー: %w", err)
+			}
+			changed = true
+
+			if err := s.reload(task); err != nil {
+				return i + 1, changed, movedFrom, err
+			}
+			s.webhooks.Publish(boardID, domain.WebhookEventTaskUpdated, task)
+
+		case domain.AutomationActionCreateCalendarEvent:
+			// イベントはボードを作成したユーザーのカレンダーに作成する
+			board, err := s.boardRepo.GetByID(boardID)
+			if err != nil {
+				return i, changed, movedFrom, fmt.Errorf("ボード取得エラー: %w", err)
+			}
+			if board == nil {
+				return i, changed, movedFrom, errors.New("ボードが見つかりません")
+			}
+
+			minutes := action.DurationMinutes
+			if minutes == 0 {
+				minutes = domain.DefaultAutomationEventMinutes
+			}
+			start := now
+			if task.DueDate != nil {
+				start = *task.DueDate
+			}
+			taskID := task.ID
+			event := &domain.CalendarEvent{
+				UserID:      board.OwnerID,
+				TaskID:      &taskID,
+				Title:       task.Title,
+				Start:       start,
+				End:         start.Add(time.Duration(minutes) * time.Minute),
+				IsTaskBased: true,
+			}
+			if err := s.calendarEventRepo.Create(event); err != nil {
+				return i, changed, movedFrom, fmt.Errorf("カレンダーイベント作成エラー: %w", err)
+			}
+
+		default:
+			return i, changed, movedFrom, fmt.Errorf("不正なアクションです: %s", action.Type)
+		}
+	}
+	return len(rule.Actions), changed, movedFrom, nil
+}
+
+// reload タスクを関連データと共に取得し直します
+func (s *automationService) reload(task *domain.Task) error {
+	reloaded, err := s.taskRepo.GetByID(task.ID)
+	if err != nil {
+		return fmt.Errorf("タスク取得エラー: %w", err)
+	}
+	if reloaded == nil {
+		return errors.New("タスクが見つかりません")
+	}
+	*task = *reloaded
+	return nil
+}
+
+// recordExecution 実行ログを記録します（記録に失敗してもルールの実行は失敗させない）
+func (s *automationService) recordExecution(execution *domain.AutomationExecution) {
+	if execution.Status == domain.AutomationExecutionFailed {
+		log.Printf("自動化ルールの実行に失敗しました: rule=%d task=%d err=%s", execution.RuleID, execution.TaskID, execution.Error)
+	}
+	if err := s.automationRepo.CreateExecution(execution); err != nil {
+		log.Printf("実行ログ記録エラー: %v", err)
+	}
+}
+
+// validateRule ルールの形式と、参照するカラム・ラベル・担当者がルールのボードのものかをチェックします
+func (s *automationService) validateRule(rule *domain.AutomationRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	var columnIDs, labelIDs []uint
+	var assigneeIDs []uuid.UUID
+	if rule.TriggerColumnID != nil {
+		columnIDs = append(columnIDs, *rule.TriggerColumnID)
+	}
+	for _, condition := range rule.Conditions {
+		switch condition.Type {
+		case domain.AutomationConditionInColumn:
+			columnIDs = append(columnIDs, condition.ColumnID)
+		case domain.AutomationConditionHasLabel:
+			labelIDs = append(labelIDs, condition.LabelID)
+		}
+	}
+	for _, action := range rule.Actions {
+		switch action.Type {
+		case domain.AutomationActionMove:
+			columnIDs = append(columnIDs, action.ColumnID)
+		case domain.AutomationActionAddLabel:
+			labelIDs = append(labelIDs, action.LabelID)
+		case domain.AutomationActionAssign:
+			if action.AssigneeID != nil {
+				assigneeIDs = append(assigneeIDs, *action.AssigneeID)
+			}
+		}
+	}
+
+	for _, id := range columnIDs {
+		column, err := s.columnRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("カラム取得エラー: %w", err)
+		}
+		if column == nil || column.BoardID != rule.BoardID {
+			return fmt.Errorf("カラムが見つかりません: %d", id)
+		}
+	}
+	for _, id := range labelIDs {
+		label, err := s.labelRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("ラベル取得エラー: %w", err)
+		}
+		if label == nil || label.BoardID != rule.BoardID {
+			return fmt.Errorf("ラベルが見つかりません: %d", id)
+		}
+	}
+	// 担当者はボードにアクセスできるユーザーに限る
+	for _, id := range assigneeIDs {
+		member, err := isBoardMember(s.boardRepo, rule.BoardID, id)
+		if err != nil {
+			return err
+		}
+		if !member {
+			return fmt.Errorf("担当者はボードのメンバーではありません: %s", id)
+		}
+	}
+	return nil
+}
+
+// getOwnedRule ルールを取得し、ボードの所有権をチェックします
+func (s *automationService) getOwnedRule(ruleID uint, userID uuid.UUID) (*domain.AutomationRule, error) {
+	rule, err := s.automationRepo.GetRuleByID(ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("自動化ルール取得エラー: %w", err)
+	}
+	if rule == nil {
+		return nil, errors.New("自動化ルールが見つかりません")
+	}
+	if err := s.checkBoardOwnership(rule.BoardID, userID); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// checkBoardOwnership ボードへのアクセス権をチェックします
+func (s *automationService) checkBoardOwnership(boardID uint, userID uuid.UUID) error {
+	board, err := s.boardRepo.GetByID(boardID)
+	if err != nil {
+		return fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		return errors.New("ボードが見つかりません")
+	}
+	member, err := isBoardMember(s.boardRepo, board.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return errors.New("このボードにアクセスする権限がありません")
+	}
+	return nil
+}
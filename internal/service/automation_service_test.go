@@ -0,0 +1,309 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAutomationRepository テスト用のメモリ上のAutomationRepository（使用するメソッドのみ実装）
+type memoryAutomationRepository struct {
+	repository.AutomationRepository
+	rules      []domain.AutomationRule
+	executions []domain.AutomationExecution
+}
+
+func (r *memoryAutomationRepository) CreateRule(rule *domain.AutomationRule) error {
+	rule.ID = uint(len(r.rules) + 1)
+	r.rules = append(r.rules, *rule)
+	return nil
+}
+
+func (r *memoryAutomationRepository) GetEnabledRules(boardID uint, trigger domain.AutomationTrigger) ([]domain.AutomationRule, error) {
+	var rules []domain.AutomationRule
+	for _, rule := range r.rules {
+		if rule.BoardID == boardID && rule.Trigger == trigger && rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (r *memoryAutomationRepository) CreateExecution(execution *domain.AutomationExecution) error {
+	execution.ID = uint(len(r.executions) + 1)
+	r.executions = append(r.executions, *execution)
+	return nil
+}
+
+func (r *memoryAutomationRepository) GetEnabledRulesByTrigger(trigger domain.AutomationTrigger) ([]domain.AutomationRule, error) {
+	var rules []domain.AutomationRule
+	for _, rule := range r.rules {
+		if rule.Trigger == trigger && rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (r *memoryAutomationRepository) HasExecutionSince(ruleID, taskID uint, since time.Time) (bool, error) {
+	for _, execution := range r.executions {
+		if execution.RuleID == ruleID && execution.TaskID == taskID && !execution.CreatedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// memoryTaskRepository テスト用のメモリ上のTaskRepository（使用するメソッドのみ実装）
+type memoryTaskRepository struct {
+	repository.TaskRepository
+	tasks   map[uint]*domain.Task
+	columns map[uint]*domain.Column
+}
+
+func (r *memoryTaskRepository) Create(task *domain.Task) error {
+	task.ID = uint(len(r.tasks) + 1)
+	copied := *task
+	r.tasks[task.ID] = &copied
+	return nil
+}
+
+// CreateWithinWIPLimit カラムのWIP制限（hard）を超える場合は*domain.WIPLimitErrorを返します
+func (r *memoryTaskRepository) CreateWithinWIPLimit(task *domain.Task) error {
+	if column, ok := r.columns[task.ColumnID]; ok && column.WIPLimitMode == domain.WIPLimitModeHard {
+		count := 0
+		for _, existing := range r.tasks {
+			if existing.ColumnID == task.ColumnID {
+				count++
+			}
+		}
+		if wipErr := column.CheckWIPLimit(count + 1); wipErr != nil {
+			return wipErr
+		}
+	}
+	return r.Create(task)
+}
+
+// GetByID カラムを読み込んだタスクのコピーを返します
+func (r *memoryTaskRepository) GetByID(id uint) (*domain.Task, error) {
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *task
+	if column, ok := r.columns[task.ColumnID]; ok {
+		copied.Column = domain.Column{ID: column.ID, BoardID: column.BoardID, Title: column.Title}
+	}
+	return &copied, nil
+}
+
+func (r *memoryTaskRepository) Update(task *domain.Task) error {
+	current, ok := r.tasks[task.ID]
+	if !ok {
+		return nil
+	}
+	copied := *task
+	copied.ColumnID, copied.LaneID = current.ColumnID, current.LaneID
+	r.tasks[task.ID] = &copied
+	return nil
+}
+
+func (r *memoryTaskRepository) MoveToColumn(taskID uint, newColumnID uint, newLaneID *uint, newOrder int) error {
+	task := r.tasks[taskID]
+	task.ColumnID, task.LaneID = newColumnID, newLaneID
+	return nil
+}
+
+// Search ボード・期限日の上限・完了状態の条件のみに対応します
+func (r *memoryTaskRepository) Search(query repository.TaskQuery) ([]domain.Task, error) {
+	var tasks []domain.Task
+	for id := range r.tasks {
+		task, _ := r.GetByID(id)
+		if len(query.BoardIDs) > 0 && !slices.Contains(query.BoardIDs, task.Column.BoardID) {
+			continue
+		}
+		if query.DueTo != nil && (task.DueDate == nil || !task.DueDate.Before(*query.DueTo)) {
+			continue
+		}
+		if query.IsCompleted != nil && task.IsCompleted != *query.IsCompleted {
+			continue
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, nil
+}
+
+// memoryColumnRepository テスト用のメモリ上のColumnRepository（使用するメソッドのみ実装）
+type memoryColumnRepository struct {
+	repository.ColumnRepository
+	columns map[uint]*domain.Column
+	tasks   *memoryTaskRepository
+}
+
+// GetByID カラムにあるタスクを読み込んだコピーを返します（WIP制限の判定に使用）
+func (r *memoryColumnRepository) GetByID(id uint) (*domain.Column, error) {
+	column, ok := r.columns[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *column
+	copied.Tasks = nil
+	for _, task := range r.tasks.tasks {
+		if task.ColumnID == id {
+			copied.Tasks = append(copied.Tasks, *task)
+		}
+	}
+	return &copied, nil
+}
+
+// recordingWebhookPublisher 通知したイベントを記録するWebhookPublisher
+type recordingWebhookPublisher struct {
+	events []domain.WebhookEvent
+}
+
+func (p *recordingWebhookPublisher) Publish(boardID uint, event domain.WebhookEvent, data interface{}) {
+	p.events = append(p.events, event)
+}
+
+// newTestKanban ボード1にカラムを持つテスト用のリポジトリを作成します
+func newTestKanban(owner uuid.UUID, columns ...domain.Column) (*memoryBoardRepository, *memoryColumnRepository, *memoryTaskRepository) {
+	boards := &memoryBoardRepository{boards: map[uint]*domain.Board{1: {ID: 1, OwnerID: owner}}}
+	tasks := &memoryTaskRepository{tasks: make(map[uint]*domain.Task), columns: make(map[uint]*domain.Column)}
+	for i := range columns {
+		tasks.columns[columns[i].ID] = &columns[i]
+	}
+	return boards, &memoryColumnRepository{columns: tasks.columns, tasks: tasks}, tasks
+}
+
+// 2つのルールでタスクが往復する場合に、連鎖が止まり実行ログが記録されることのテスト
+func TestAutomationService_LoopProtection(t *testing.T) {
+	owner := uuid.New()
+	boards, columns, tasks := newTestKanban(owner,
+		domain.Column{ID: 1, BoardID: 1, Title: "ToDo"},
+		domain.Column{ID: 2, BoardID: 1, Title: "Doing"},
+	)
+	automations := &memoryAutomationRepository{}
+	webhooks := &recordingWebhookPublisher{}
+	svc := NewAutomationService(automations, tasks, boards, columns, nil, nil, webhooks)
+
+	todo, doing := uint(1), uint(2)
+	_, err := svc.CreateRule(1, owner, &domain.AutomationRule{
+		Name: "Doingから戻す", Enabled: true, Trigger: domain.AutomationTriggerTaskMoved, TriggerColumnID: &doing,
+		Actions: domain.AutomationActions{{Type: domain.AutomationActionMove, ColumnID: todo}},
+	})
+	require.NoError(t, err)
+	_, err = svc.CreateRule(1, owner, &domain.AutomationRule{
+		Name: "ToDoから進める", Enabled: true, Trigger: domain.AutomationTriggerTaskMoved, TriggerColumnID: &todo,
+		Actions: domain.AutomationActions{{Type: domain.AutomationActionMove, ColumnID: doing}},
+	})
+	require.NoError(t, err)
+
+	task := &domain.Task{Title: "往復するタスク", ColumnID: doing}
+	require.NoError(t, tasks.Create(task))
+	assert.True(t, svc.TaskMoved(task, todo))
+
+	// ルール1 → ルール2 と実行され、同じ連鎖でのルール1の再実行は中止される
+	require.Len(t, automations.executions, 3)
+	assert.Equal(t, domain.AutomationExecutionSucceeded, automations.executions[0].Status)
+	assert.Equal(t, uint(1), automations.executions[0].RuleID)
+	assert.Equal(t, 0, automations.executions[0].Depth)
+	assert.Equal(t, domain.AutomationExecutionSucceeded, automations.executions[1].Status)
+	assert.Equal(t, uint(2), automations.executions[1].RuleID)
+	assert.Equal(t, 1, automations.executions[1].Depth)
+	assert.Equal(t, domain.AutomationExecutionSkipped, automations.executions[2].Status)
+	assert.Equal(t, uint(1), automations.executions[2].RuleID)
+	for _, execution := range automations.executions {
+		assert.LessOrEqual(t, execution.Depth, MaxAutomationDepth)
+	}
+
+	assert.Equal(t, doing, tasks.tasks[task.ID].ColumnID)
+	assert.Equal(t, []domain.WebhookEvent{domain.WebhookEventTaskMoved, domain.WebhookEventTaskMoved}, webhooks.events)
+}
+
+// 移動先のWIP制限（hard）を超える移動のアクションは失敗として記録されることのテスト
+func TestAutomationService_MoveRespectsHardWIPLimit(t *testing.T) {
+	owner := uuid.New()
+	limit := 1
+	boards, columns, tasks := newTestKanban(owner,
+		domain.Column{ID: 1, BoardID: 1, Title: "ToDo"},
+		domain.Column{ID: 2, BoardID: 1, Title: "Doing", WIPLimit: &limit, WIPLimitMode: domain.WIPLimitModeHard},
+	)
+	automations := &memoryAutomationRepository{}
+	svc := NewAutomationService(automations, tasks, boards, columns, nil, nil, &recordingWebhookPublisher{})
+
+	_, err := svc.CreateRule(1, owner, &domain.AutomationRule{
+		Name: "作成したらDoingへ", Enabled: true, Trigger: domain.AutomationTriggerTaskCreated,
+		Actions: domain.AutomationActions{{Type: domain.AutomationActionMove, ColumnID: 2}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, tasks.Create(&domain.Task{Title: "作業中", ColumnID: 2}))
+	task := &domain.Task{Title: "新しいタスク", ColumnID: 1}
+	require.NoError(t, tasks.Create(task))
+
+	assert.False(t, svc.TaskCreated(task))
+	require.Len(t, automations.executions, 1)
+	assert.Equal(t, domain.AutomationExecutionFailed, automations.executions[0].Status)
+	assert.Contains(t, automations.executions[0].Error, "移動先のカラムに移動できません")
+	assert.Equal(t, uint(1), tasks.tasks[task.ID].ColumnID)
+}
+
+// 担当者を設定するアクションにはボードのメンバーのみ指定できることのテスト
+func TestAutomationService_ValidateAssignee(t *testing.T) {
+	owner := uuid.New()
+	boards, columns, tasks := newTestKanban(owner, domain.Column{ID: 1, BoardID: 1, Title: "ToDo"})
+	svc := NewAutomationService(&memoryAutomationRepository{}, tasks, boards, columns, nil, nil, &recordingWebhookPublisher{})
+
+	outsider := uuid.New()
+	_, err := svc.CreateRule(1, owner, &domain.AutomationRule{
+		Name: "部外者に割り当て", Enabled: true, Trigger: domain.AutomationTriggerTaskCreated,
+		Actions: domain.AutomationActions{{Type: domain.AutomationActionAssign, AssigneeID: &outsider}},
+	})
+	assert.Error(t, err)
+
+	_, err = svc.CreateRule(1, owner, &domain.AutomationRule{
+		Name: "自分に割り当て", Enabled: true, Trigger: domain.AutomationTriggerTaskCreated,
+		Actions: domain.AutomationActions{{Type: domain.AutomationActionAssign, AssigneeID: &owner}},
+	})
+	assert.NoError(t, err)
+}
+
+// 期限日の当日は期限切れとせず、マイワークと同じく翌日から期限切れのルールを実行することのテスト
+func TestAutomationService_RunDueDateRules(t *testing.T) {
+	owner := uuid.New()
+	boards, columns, tasks := newTestKanban(owner, domain.Column{ID: 1, BoardID: 1, Title: "ToDo"})
+	automations := &memoryAutomationRepository{}
+	svc := NewAutomationService(automations, tasks, boards, columns, nil, nil, &recordingWebhookPublisher{})
+	_, err := svc.CreateRule(1, owner, &domain.AutomationRule{
+		Name: "期限切れを自分に割り当て", Enabled: true, Trigger: domain.AutomationTriggerDueDatePassed,
+		Actions: domain.AutomationActions{{Type: domain.AutomationActionAssign, AssigneeID: &owner}},
+	})
+	require.NoError(t, err)
+
+	// 期限日は年月日をUTCの0時として保存している
+	yesterday := time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	overdue := &domain.Task{Title: "昨日が期限", ColumnID: 1, DueDate: &yesterday}
+	dueToday := &domain.Task{Title: "今日が期限", ColumnID: 1, DueDate: &today}
+	require.NoError(t, tasks.Create(overdue))
+	require.NoError(t, tasks.Create(dueToday))
+
+	// 日本時間の3月10日18時（UTCでは当日の9時）
+	now := time.Date(2025, 3, 10, 18, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	count, err := svc.RunDueDateRules(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, automations.executions, 1)
+	assert.Equal(t, overdue.ID, automations.executions[0].TaskID)
+	assert.Nil(t, tasks.tasks[dueToday.ID].AssigneeID)
+
+	work := groupMyWorkTasks([]domain.Task{*tasks.tasks[dueToday.ID]}, now)
+	assert.Len(t, work.Today, 1, "マイワークでは今日のタスク")
+}
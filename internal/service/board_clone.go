@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BoardCloneOptions ボードを複製する際のオプション
+type BoardCloneOptions struct {
+	Name              string // 複製先のボード名（空の場合は「<元の名前> のコピー」）
+	IncludeCompleted  bool   // 完了済みのタスクも複製するか
+	IncludeAssignees  bool   // タスクの担当者を引き継ぐか
+	IncludeDueDates   bool   // タスクの期限を引き継ぐか
+	DueDateOffsetDays int    // 引き継ぐ期限をずらす日数（IncludeDueDatesがtrueの場合のみ）
+}
+
+// CloneBoard ボードをカラム・レーン・ラベル・カスタムフィールド・タスクごと複製します
+// タスクはセル内の順序（ランク）を保ったまま複製し、ラベルとカスタムフィールド値は複製先の定義に付け替えます
+func (s *boardService) CloneBoard(boardID uint, userID uuid.UUID, opts BoardCloneOptions) (*domain.Board, error) {
+	// ボードの所有権をチェック
+	if err := s.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	var clone *domain.Board
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		boardRepo := repository.NewBoardRepository(tx)
+		columnRepo := repository.NewColumnRepository(tx)
+		laneRepo := repository.NewLaneRepository(tx)
+		labelRepo := repository.NewLabelRepository(tx)
+		customFieldRepo := repository.NewCustomFieldRepository(tx)
+		taskRepo := repository.NewTaskRepository(tx)
+
+		source, err := boardRepo.GetByIDWithColumns(boardID)
+		if err != nil {
+			return fmt.Errorf("ボード取得エラー: %w", err)
+		}
+		if source == nil {
+			return errors.New("ボードが見つかりません")
+		}
+		lanes, err := laneRepo.GetByBoardID(boardID)
+		if err != nil {
+			return fmt.Errorf("レーン取得エラー: %w", err)
+		}
+		labels, err := labelRepo.GetByBoardID(boardID)
+		if err != nil {
+			return fmt.Errorf("ラベル取得エラー: %w", err)
+		}
+		fields, err := customFieldRepo.GetDefinitionsByBoardID(boardID)
+		if err != nil {
+			return fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+		}
+
+		name := opts.Name
+		if name == "" {
+			name = source.Name + " のコピー"
+		}
+		// 複製元と同じワークスペースに作成する
+		clone = &domain.Board{
+			Name:        name,
+			WorkspaceID: source.WorkspaceID,
+			OwnerID:     userID,
+		}
+		if err := boardRepo.Create(clone); err != nil {
+			return fmt.Errorf("ボード作成エラー: %w", err)
+		}
+
+		laneIDs := make(map[uint]uint, len(lanes))
+		for _, lane := range lanes {
+			copied := &domain.Lane{BoardID: clone.ID, Name: lane.Name, Order: lane.Order}
+			if err := laneRepo.Create(copied); err != nil {
+				return fmt.Errorf("レーン作成エラー: %w", err)
+			}
+			laneIDs[lane.ID] = copied.ID
+		}
+
+		labelIDs := make(map[uint]uint, len(labels))
+		for _, label := range labels {
+			copied := &domain.Label{BoardID: clone.ID, Name: label.Name, Color: label.Color}
+			if err := labelRepo.Create(copied); err != nil {
+				return fmt.Errorf("ラベル作成エラー: %w", err)
+			}
+			labelIDs[label.ID] = copied.ID
+		}
+
+		fieldIDs := make(map[uint]uint, len(fields))
+		for _, field := range fields {
+			copied := &domain.CustomFieldDefinition{
+				BoardID: clone.ID,
+				Name:    field.Name,
+				Type:    field.Type,
+				Options: field.Options,
+				Order:   field.Order,
+			}
+			if err := customFieldRepo.CreateDefinition(copied); err != nil {
+				return fmt.Errorf("カスタムフィールド作成エラー: %w", err)
+			}
+			fieldIDs[field.ID] = copied.ID
+		}
+
+		for _, column := range source.Columns {
+			copiedColumn := &domain.Column{
+				BoardID:      clone.ID,
+				Title:        column.Title,
+				Order:        column.Order,
+				WIPLimit:     column.WIPLimit,
+				WIPLimitMode: column.WIPLimitMode,
+			}
+			if err := columnRepo.Create(copiedColumn); err != nil {
+				return fmt.Errorf("カラム作成エラー: %w", err)
+			}
+
+			for _, task := range column.Tasks {
+				if task.IsCompleted && !opts.IncludeCompleted {
+					continue
+				}
+
+				copied := cloneTask(&task, copiedColumn.ID, laneIDs, opts)
+				if err := taskRepo.Create(copied); err != nil {
+					return fmt.Errorf("タスク作成エラー: %w", err)
+				}
+
+				for _, label := range task.Labels {
+					if err := labelRepo.AddToTask(copied.ID, labelIDs[label.ID]); err != nil {
+						return fmt.Errorf("ラベル付与エラー: %w", err)
+					}
+				}
+				for _, value := range task.CustomFieldValues {
+					fieldID, ok := fieldIDs[value.FieldID]
+					if !ok {
+						continue
+					}
+					if err := customFieldRepo.SaveValue(&domain.TaskCustomFieldValue{
+						TaskID:       copied.ID,
+						FieldID:      fieldID,
+						TextValue:    value.TextValue,
+						NumberValue:  value.NumberValue,
+						DateValue:    value.DateValue,
+						OptionValues: value.OptionValues,
+					}); err != nil {
+						return fmt.Errorf("カスタムフィールド値保存エラー: %w", err)
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// cloneTask 複製先のカラムに配置するタスクのコピーを作成します
+// ランクはそのまま引き継ぐため、セル内の順序は複製元と同じになります
+func cloneTask(task *domain.Task, columnID uint, laneIDs map[uint]uint, opts BoardCloneOptions) *domain.Task {
+	copied := &domain.Task{
+		ColumnID:      columnID,
+		Title:         task.Title,
+		Description:   task.Description,
+		Rank:          task.Rank,
+		EstimatedTime: task.EstimatedTime,
+		IsCompleted:   task.IsCompleted,
+		Priority:      task.Priority,
+	}
+	if task.LaneID != nil {
+		if laneID, ok := laneIDs[*task.LaneID]; ok {
+			copied.LaneID = &laneID
+		}
+	}
+	if opts.IncludeAssignees {
+		copied.AssigneeID = task.AssigneeID
+	}
+	if opts.IncludeDueDates && task.DueDate != nil {
+		dueDate := task.DueDate.AddDate(0, 0, opts.DueDateOffsetDays)
+		copied.DueDate = &dueDate
+	}
+	return copied
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneTask(t *testing.T) {
+	assignee := uuid.New()
+	laneID := uint(3)
+	dueDate := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	task := &domain.Task{
+		ID:         1,
+		ColumnID:   1,
+		LaneID:     &laneID,
+		Title:      "リリースノート作成",
+		Rank:       "i",
+		AssigneeID: &assignee,
+		DueDate:    &dueDate,
+		Priority:   domain.TaskPriorityHigh,
+	}
+	laneIDs := map[uint]uint{3: 13}
+
+	// 担当者・期限を引き継がない場合
+	copied := cloneTask(task, 20, laneIDs, BoardCloneOptions{})
+	assert.Equal(t, uint(20), copied.ColumnID)
+	assert.Equal(t, uint(13), *copied.LaneID)
+	assert.Equal(t, "i", copied.Rank)
+	assert.Equal(t, domain.TaskPriorityHigh, copied.Priority)
+	assert.Nil(t, copied.AssigneeID)
+	assert.Nil(t, copied.DueDate)
+	assert.Zero(t, copied.ID)
+
+	// 担当者を引き継ぎ、期限を14日後にずらす場合
+	copied = cloneTask(task, 20, laneIDs, BoardCloneOptions{IncludeAssignees: true, IncludeDueDates: true, DueDateOffsetDays: 14})
+	assert.Equal(t, assignee, *copied.AssigneeID)
+	assert.Equal(t, time.Date(2025, 3, 24, 0, 0, 0, 0, time.UTC), *copied.DueDate)
+	assert.Equal(t, dueDate, *task.DueDate, "複製元の期限は変更しない")
+}
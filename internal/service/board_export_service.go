@@ -0,0 +1,346 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BoardImportResult ボードのインポート結果
+type BoardImportResult struct {
+	Board              *domain.Board
+	UnmatchedAssignees []string // インポート先のボードのメンバーが見つからず、担当者を外したメールアドレス
+}
+
+// BoardExportService ボードのエクスポート・インポートを管理するインターフェース
+type BoardExportService interface {
+	ExportBoard(boardID uint, userID uuid.UUID) (*domain.BoardExport, error)
+	ImportBoard(userID uuid.UUID, export *domain.BoardExport) (*BoardImportResult, error)
+}
+
+// boardExportService BoardExportServiceの実装
+type boardExportService struct {
+	db              *gorm.DB // インポート用のデータベース接続
+	boardService    BoardService
+	laneRepo        repository.LaneRepository
+	labelRepo       repository.LabelRepository
+	customFieldRepo repository.CustomFieldRepository
+}
+
+// NewBoardExportService BoardExportServiceの新しいインスタンスを作成
+func NewBoardExportService(db *gorm.DB, boardService BoardService, laneRepo repository.LaneRepository, labelRepo repository.LabelRepository, customFieldRepo repository.CustomFieldRepository) BoardExportService {
+	return &boardExportService{
+		db:              db,
+		boardService:    boardService,
+		laneRepo:        laneRepo,
+		labelRepo:       labelRepo,
+		customFieldRepo: customFieldRepo,
+	}
+}
+
+// ExportBoard ボードをカラム・レーン・ラベル・カスタムフィールド・タスクごとエクスポートします
+func (s *boardExportService) ExportBoard(boardID uint, userID uuid.UUID) (*domain.BoardExport, error) {
+	// 所有権のチェックを含めてボードを取得
+	board, err := s.boardService.GetBoardWithColumns(boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	lanes, err := s.laneRepo.GetByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("レーン取得エラー: %w", err)
+	}
+	labels, err := s.labelRepo.GetByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("ラベル取得エラー: %w", err)
+	}
+	fields, err := s.customFieldRepo.GetDefinitionsByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+	}
+
+	return newBoardExport(board, lanes, labels, fields, time.Now()), nil
+}
+
+// ImportBoard エクスポートファイルから現在のユーザーのボードを新しく作成します
+// ファイル内のIDは新しいIDに付け替え、担当者はメールアドレスが一致するボードのメンバーに割り当てます
+func (s *boardExportService) ImportBoard(userID uuid.UUID, export *domain.BoardExport) (*BoardImportResult, error) {
+	if err := export.Validate(); err != nil {
+		return nil, err
+	}
+
+	var imported *boardImport
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		imported, err = importBoard(tx, userID, &export.Board)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return imported.result, nil
+}
+
+// boardImport インポートで作成したボードと、ファイル内のIDから作成したIDへの対応
+type boardImport struct {
+	result    *BoardImportResult
+	columnIDs map[uint]uint
+	taskIDs   map[uint]uint
+}
+
+// importBoard エクスポートファイルのボードをtxのトランザクション内で作成します
+// sourceはValidateでチェック済みである必要があります
+func importBoard(tx *gorm.DB, userID uuid.UUID, source *domain.ExportedBoard) (*boardImport, error) {
+	boardRepo := repository.NewBoardRepository(tx)
+	columnRepo := repository.NewColumnRepository(tx)
+	laneRepo := repository.NewLaneRepository(tx)
+	labelRepo := repository.NewLabelRepository(tx)
+	customFieldRepo := repository.NewCustomFieldRepository(tx)
+	taskRepo := repository.NewTaskRepository(tx)
+	userRepo := repository.NewUserRepository(tx)
+
+	result := &BoardImportResult{UnmatchedAssignees: []string{}}
+	imported := &boardImport{result: result, taskIDs: make(map[uint]uint, len(source.Tasks))}
+
+	// インポートしたボードは個人用ワークスペースに作成する
+	workspaceID, err := resolveWorkspace(repository.NewWorkspaceRepository(tx), userID, 0)
+	if err != nil {
+		return nil, err
+	}
+	board := &domain.Board{Name: source.Name, WorkspaceID: workspaceID, OwnerID: userID}
+	if err := boardRepo.Create(board); err != nil {
+		return nil, fmt.Errorf("ボード作成エラー: %w", err)
+	}
+	result.Board = board
+
+	columns := append([]domain.ExportedColumn(nil), source.Columns...)
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].Order < columns[j].Order })
+	columnIDs := make(map[uint]uint, len(columns))
+	imported.columnIDs = columnIDs
+	for i, exported := range columns {
+		mode := exported.WIPLimitMode
+		if mode == "" {
+			mode = domain.WIPLimitModeSoft
+		}
+		column := &domain.Column{
+			BoardID:      board.ID,
+			Title:        exported.Title,
+			Order:        i + 1,
+			WIPLimit:     exported.WIPLimit,
+			WIPLimitMode: mode,
+		}
+		if err := columnRepo.Create(column); err != nil {
+			return nil, fmt.Errorf("カラム作成エラー: %w", err)
+		}
+		columnIDs[exported.ID] = column.ID
+	}
+
+	lanes := append([]domain.ExportedLane(nil), source.Lanes...)
+	sort.SliceStable(lanes, func(i, j int) bool { return lanes[i].Order < lanes[j].Order })
+	laneIDs := make(map[uint]uint, len(lanes))
+	for i, exported := range lanes {
+		lane := &domain.Lane{BoardID: board.ID, Name: exported.Name, Order: i + 1}
+		if err := laneRepo.Create(lane); err != nil {
+			return nil, fmt.Errorf("レーン作成エラー: %w", err)
+		}
+		laneIDs[exported.ID] = lane.ID
+	}
+
+	labelIDs := make(map[uint]uint, len(source.Labels))
+	for _, exported := range source.Labels {
+		label := &domain.Label{BoardID: board.ID, Name: exported.Name, Color: exported.Color}
+		if label.Color == "" {
+			label.Color = domain.DefaultLabelColor
+		}
+		if err := labelRepo.Create(label); err != nil {
+			return nil, fmt.Errorf("ラベル作成エラー: %w", err)
+		}
+		labelIDs[exported.ID] = label.ID
+	}
+
+	fields := append([]domain.ExportedCustomField(nil), source.CustomFields...)
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Order < fields[j].Order })
+	fieldsByID := make(map[uint]*domain.CustomFieldDefinition, len(fields))
+	for i, exported := range fields {
+		options, err := domain.NormalizeCustomFieldOptions(exported.Type, exported.Options)
+		if err != nil {
+			return nil, fmt.Errorf("カスタムフィールド「%s」: %w", exported.Name, err)
+		}
+		field := &domain.CustomFieldDefinition{
+			BoardID: board.ID,
+			Name:    exported.Name,
+			Type:    exported.Type,
+			Options: options,
+			Order:   i + 1,
+		}
+		if err := customFieldRepo.CreateDefinition(field); err != nil {
+			return nil, fmt.Errorf("カスタムフィールド作成エラー: %w", err)
+		}
+		fieldsByID[exported.ID] = field
+	}
+
+	// メールアドレスからインポート先のボードのメンバーを探す（同じアドレスは1度だけ検索）
+	assignees := make(map[string]*uuid.UUID)
+	findAssignee := func(email string) (*uuid.UUID, error) {
+		email = strings.TrimSpace(email)
+		if id, ok := assignees[email]; ok {
+			return id, nil
+		}
+		user, err := userRepo.GetByEmail(email)
+		if err != nil {
+			return nil, fmt.Errorf("ユーザー取得エラー: %w", err)
+		}
+		member := false
+		if user != nil {
+			if member, err = isBoardMember(boardRepo, board.ID, user.ID); err != nil {
+				return nil, err
+			}
+		}
+		if !member {
+			assignees[email] = nil
+			result.UnmatchedAssignees = append(result.UnmatchedAssignees, email)
+			return nil, nil
+		}
+		assignees[email] = &user.ID
+		return &user.ID, nil
+	}
+
+	// ファイル内の並び順のまま各セルの末尾に追加することで、セル内の順序を再現する
+	for _, exported := range source.Tasks {
+		priority := exported.Priority
+		if priority == "" {
+			priority = domain.TaskPriorityNone
+		}
+		task := &domain.Task{
+			ColumnID:      columnIDs[exported.ColumnID],
+			Title:         exported.Title,
+			Description:   exported.Description,
+			DueDate:       exported.DueDate,
+			EstimatedTime: exported.EstimatedTime,
+			ActualTime:    exported.ActualTime,
+			IsCompleted:   exported.IsCompleted,
+			Priority:      priority,
+		}
+		if exported.LaneID != nil {
+			laneID := laneIDs[*exported.LaneID]
+			task.LaneID = &laneID
+		}
+		if exported.AssigneeEmail != nil && *exported.AssigneeEmail != "" {
+			assigneeID, err := findAssignee(*exported.AssigneeEmail)
+			if err != nil {
+				return nil, err
+			}
+			task.AssigneeID = assigneeID
+		}
+		if err := taskRepo.Create(task); err != nil {
+			return nil, fmt.Errorf("タスク作成エラー: %w", err)
+		}
+		imported.taskIDs[exported.ID] = task.ID
+
+		for _, labelID := range exported.LabelIDs {
+			if err := labelRepo.AddToTask(task.ID, labelIDs[labelID]); err != nil {
+				return nil, fmt.Errorf("ラベル付与エラー: %w", err)
+			}
+		}
+		// 画面からの設定と同じく、フィールド型に従って検証・変換した値を保存する
+		for _, exportedValue := range exported.CustomFieldValues {
+			field := fieldsByID[exportedValue.FieldID]
+			value, err := domain.ParseCustomFieldValue(field, exportedValue.Raw(field.Type))
+			if err != nil {
+				return nil, fmt.Errorf("タスク「%s」: %w", exported.Title, err)
+			}
+			value.TaskID = task.ID
+			value.FieldID = field.ID
+			if err := customFieldRepo.SaveValue(value); err != nil {
+				return nil, fmt.Errorf("カスタムフィールド値保存エラー: %w", err)
+			}
+		}
+	}
+
+	return imported, nil
+}
+
+// newBoardExport ボードの内容からエクスポートファイルの内容を作成します
+// タスクはカラム順・セル内の順序で並べます
+func newBoardExport(board *domain.Board, lanes []domain.Lane, labels []domain.Label, fields []domain.CustomFieldDefinition, exportedAt time.Time) *domain.BoardExport {
+	exported := domain.ExportedBoard{
+		Name:         board.Name,
+		Columns:      make([]domain.ExportedColumn, 0, len(board.Columns)),
+		Lanes:        make([]domain.ExportedLane, 0, len(lanes)),
+		Labels:       make([]domain.ExportedLabel, 0, len(labels)),
+		CustomFields: make([]domain.ExportedCustomField, 0, len(fields)),
+		Tasks:        []domain.ExportedTask{},
+	}
+
+	for _, lane := range lanes {
+		exported.Lanes = append(exported.Lanes, domain.ExportedLane{ID: lane.ID, Name: lane.Name, Order: lane.Order})
+	}
+	for _, label := range labels {
+		exported.Labels = append(exported.Labels, domain.ExportedLabel{ID: label.ID, Name: label.Name, Color: label.Color})
+	}
+	for _, field := range fields {
+		exported.CustomFields = append(exported.CustomFields, domain.ExportedCustomField{
+			ID:      field.ID,
+			Name:    field.Name,
+			Type:    field.Type,
+			Options: field.Options,
+			Order:   field.Order,
+		})
+	}
+
+	for _, column := range board.Columns {
+		exported.Columns = append(exported.Columns, domain.ExportedColumn{
+			ID:           column.ID,
+			Title:        column.Title,
+			Order:        column.Order,
+			WIPLimit:     column.WIPLimit,
+			WIPLimitMode: column.WIPLimitMode,
+		})
+
+		for _, task := range column.Tasks {
+			exportedTask := domain.ExportedTask{
+				ID:            task.ID,
+				ColumnID:      column.ID,
+				LaneID:        task.LaneID,
+				Title:         task.Title,
+				Description:   task.Description,
+				DueDate:       task.DueDate,
+				EstimatedTime: task.EstimatedTime,
+				ActualTime:    task.ActualTime,
+				IsCompleted:   task.IsCompleted,
+				Priority:      task.Priority,
+			}
+			if task.Assignee != nil {
+				email := task.Assignee.Email
+				exportedTask.AssigneeEmail = &email
+			}
+			for _, label := range task.Labels {
+				exportedTask.LabelIDs = append(exportedTask.LabelIDs, label.ID)
+			}
+			for _, value := range task.CustomFieldValues {
+				exportedTask.CustomFieldValues = append(exportedTask.CustomFieldValues, domain.ExportedCustomFieldValue{
+					FieldID:      value.FieldID,
+					TextValue:    value.TextValue,
+					NumberValue:  value.NumberValue,
+					DateValue:    value.DateValue,
+					OptionValues: value.OptionValues,
+				})
+			}
+			exported.Tasks = append(exported.Tasks, exportedTask)
+		}
+	}
+
+	return &domain.BoardExport{
+		Format:     domain.BoardExportFormat,
+		Version:    domain.BoardExportVersion,
+		ExportedAt: exportedAt,
+		Board:      exported,
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBoardExport_RoundTrip(t *testing.T) {
+	laneID := uint(7)
+	points := 3.0
+	board := &domain.Board{
+		ID:   1,
+		Name: "リリース",
+		Columns: []domain.Column{
+			{ID: 10, Title: "To Do", Order: 1, Tasks: []domain.Task{
+				{ID: 100, ColumnID: 10, Title: "設計", Priority: domain.TaskPriorityHigh,
+					Assignee:          &domain.User{Email: "alice@example.com"},
+					Labels:            []domain.Label{{ID: 5, Name: "Story"}},
+					CustomFieldValues: []domain.TaskCustomFieldValue{{FieldID: 9, NumberValue: &points}},
+				},
+				{ID: 101, ColumnID: 10, LaneID: &laneID, Title: "実装"},
+			}},
+			{ID: 11, Title: "Done", Order: 2},
+		},
+	}
+	lanes := []domain.Lane{{ID: laneID, Name: "Backend", Order: 1}}
+	labels := []domain.Label{{ID: 5, Name: "Story", Color: "#3B82F6"}}
+	fields := []domain.CustomFieldDefinition{{ID: 9, Name: "Points", Type: domain.CustomFieldTypeNumber, Order: 1}}
+
+	export := newBoardExport(board, lanes, labels, fields, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, domain.BoardExportFormat, export.Format)
+	assert.Equal(t, domain.BoardExportVersion, export.Version)
+	require.Len(t, export.Board.Tasks, 2)
+	assert.Equal(t, "設計", export.Board.Tasks[0].Title, "セル内の順序で並ぶ")
+	assert.Equal(t, "alice@example.com", *export.Board.Tasks[0].AssigneeEmail)
+	assert.Equal(t, []uint{5}, export.Board.Tasks[0].LabelIDs)
+	assert.Nil(t, export.Board.Tasks[1].AssigneeEmail)
+
+	// JSONを経由しても内容が変わらず、スキーマのチェックを通ること
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+	var restored domain.BoardExport
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, *export, restored)
+	assert.NoError(t, restored.Validate())
+
+	// 存在しないラベルを参照している場合はエラー
+	restored.Board.Tasks[1].LabelIDs = []uint{42}
+	assert.Error(t, restored.Validate())
+
+	// 未対応のバージョンはエラー
+	restored.Board.Tasks[1].LabelIDs = nil
+	restored.Version = domain.BoardExportVersion + 1
+	assert.Error(t, restored.Validate())
+}
+
+// ラベルの色とカスタムフィールドの値を、画面からの作成と同じ規則でチェックすることのテスト
+func TestBoardExport_ValidateLabelsAndCustomFieldValues(t *testing.T) {
+	text := "顧客A"
+	newExport := func() *domain.BoardExport {
+		return &domain.BoardExport{
+			Format:  domain.BoardExportFormat,
+			Version: domain.BoardExportVersion,
+			Board: domain.ExportedBoard{
+				Name:    "インポート",
+				Columns: []domain.ExportedColumn{{ID: 1, Title: "To Do", Order: 1}},
+				Labels:  []domain.ExportedLabel{{ID: 1, Name: "Bug", Color: "#EF4444"}},
+				CustomFields: []domain.ExportedCustomField{
+					{ID: 1, Name: "顧客", Type: domain.CustomFieldTypeText, Order: 1},
+					{ID: 2, Name: "環境", Type: domain.CustomFieldTypeSingleSelect, Options: domain.StringList{"本番", "検証"}, Order: 2},
+				},
+				Tasks: []domain.ExportedTask{{ID: 1, ColumnID: 1, Title: "障害対応", CustomFieldValues: []domain.ExportedCustomFieldValue{
+					{FieldID: 1, TextValue: &text},
+					{FieldID: 2, OptionValues: domain.StringList{"本番"}},
+				}}},
+			},
+		}
+	}
+	require.NoError(t, newExport().Validate())
+
+	// カラーコードでない色
+	export := newExport()
+	export.Board.Labels[0].Color = "red; background: url(x)"
+	assert.Error(t, export.Validate())
+
+	// 定義にない選択肢
+	export = newExport()
+	export.Board.Tasks[0].CustomFieldValues[1].OptionValues = domain.StringList{"開発"}
+	assert.Error(t, export.Validate())
+
+	// フィールド型と異なる値
+	points := 3.0
+	export = newExport()
+	export.Board.Tasks[0].CustomFieldValues[0] = domain.ExportedCustomFieldValue{FieldID: 1, NumberValue: &points}
+	assert.Error(t, export.Validate())
+
+	// 選択肢のない選択型のフィールド
+	export = newExport()
+	export.Board.CustomFields[1].Options = nil
+	export.Board.Tasks[0].CustomFieldValues = nil
+	assert.Error(t, export.Validate())
+}
+
+// インポート先のボードのメンバーでないユーザーは、登録されていても担当者にしないことのテスト
+func TestBoardExportService_ImportAssigneeMembers(t *testing.T) {
+	db := openTestDB(t)
+	alice := &domain.User{Email: "import-alice-" + uuid.NewString() + "@example.com", PasswordHash: "x"}
+	bob := &domain.User{Email: "import-bob-" + uuid.NewString() + "@example.com", PasswordHash: "x"}
+	require.NoError(t, db.Create(alice).Error)
+	require.NoError(t, db.Create(bob).Error)
+	var boardID uint
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM tasks WHERE column_id IN (SELECT id FROM columns WHERE board_id = ?)", boardID)
+		db.Exec("DELETE FROM columns WHERE board_id = ?", boardID)
+		db.Unscoped().Delete(&domain.Board{}, boardID)
+		var workspaceIDs []uint
+		db.Model(&domain.WorkspaceMember{}).Where("user_id = ?", alice.ID).Pluck("workspace_id", &workspaceIDs)
+		db.Where("user_id = ?", alice.ID).Delete(&domain.WorkspaceMember{})
+		db.Delete(&domain.Workspace{}, workspaceIDs)
+		db.Unscoped().Delete(alice)
+		db.Unscoped().Delete(bob)
+	})
+
+	email := func(s string) *string { return &s }
+	svc := NewBoardExportService(db, nil, nil, nil, nil)
+	result, err := svc.ImportBoard(alice.ID, &domain.BoardExport{
+		Format:  domain.BoardExportFormat,
+		Version: domain.BoardExportVersion,
+		Board: domain.ExportedBoard{
+			Name:    "インポート",
+			Columns: []domain.ExportedColumn{{ID: 1, Title: "To Do", Order: 1}},
+			Tasks: []domain.ExportedTask{
+				{ID: 1, ColumnID: 1, Title: "自分", AssigneeEmail: email(alice.Email)},
+				{ID: 2, ColumnID: 1, Title: "メンバーでないユーザー", AssigneeEmail: email(bob.Email)},
+			},
+		},
+	})
+	require.NoError(t, err)
+	boardID = result.Board.ID
+	assert.Equal(t, []string{bob.Email}, result.UnmatchedAssignees)
+
+	var tasks []domain.Task
+	require.NoError(t, db.Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id = ?", boardID).Order("tasks.id").Find(&tasks).Error)
+	require.Len(t, tasks, 2)
+	require.NotNil(t, tasks[0].AssigneeID)
+	assert.Equal(t, alice.ID, *tasks[0].AssigneeID)
+	assert.Nil(t, tasks[1].AssigneeID)
+}
@@ -13,58 +13,70 @@ import (
 
 // BoardService ボード関連のビジネスロジックを管理するインターフェース
 type BoardService interface {
-	CreateBoard(ownerID uuid.UUID, name string) (*domain.Board, error)
-	GetUserBoards(userID uuid.UUID) ([]domain.Board, error)
+	CreateBoard(ownerID uuid.UUID, workspaceID uint, name, templateID string) (*domain.Board, error)
+	GetUserBoards(userID uuid.UUID, workspaceID uint) ([]domain.Board, error)
 	GetBoardWithColumns(boardID uint, userID uuid.UUID) (*domain.Board, error)
-	UpdateBoard(boardID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Board, error)
-	DeleteBoard(boardID uint, userID uuid.UUID) error
+	UpdateBoard(boardID uint, userID uuid.UUID, version int, updates map[string]interface{}) (*domain.Board, error)
+	DeleteBoard(boardID uint, userID uuid.UUID, version int) error
 	CheckBoardOwnership(boardID uint, userID uuid.UUID) error
+	GetColumn(columnID uint, userID uuid.UUID) (*domain.Column, error)
+	UpdateColumnWIPLimit(columnID uint, userID uuid.UUID, version int, limit *int, mode domain.WIPLimitMode) (*domain.Column, error)
+	CloneBoard(boardID uint, userID uuid.UUID, opts BoardCloneOptions) (*domain.Board, error)
 }
 
 // boardService BoardServiceの実装
 type boardService struct {
-	boardRepo  repository.BoardRepository  // ボードリポジトリ
-	columnRepo repository.ColumnRepository //nolint:unused // カラムリポジトリ（作成予定）
-	db         *gorm.DB                    // データベース接続
+	boardRepo    repository.BoardRepository         // ボードリポジトリ
+	columnRepo   repository.ColumnRepository        // カラムリポジトリ
+	templateRepo repository.BoardTemplateRepository // ボードテンプレートリポジトリ
+	workspaces   repository.WorkspaceRepository     // ワークスペースリポジトリ
+	db           *gorm.DB                           // データベース接続
+	webhooks     WebhookPublisher                   // Webhookの配信キュー
 }
 
 // NewBoardService BoardServiceの新しいインスタンスを作成
-func NewBoardService(boardRepo repository.BoardRepository, db *gorm.DB) BoardService {
+func NewBoardService(boardRepo repository.BoardRepository, columnRepo repository.ColumnRepository, templateRepo repository.BoardTemplateRepository, workspaces repository.WorkspaceRepository, db *gorm.DB, webhooks WebhookPublisher) BoardService {
 	return &boardService{
-		boardRepo: boardRepo,
-		db:        db,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		templateRepo: templateRepo,
+		workspaces:   workspaces,
+		db:           db,
+		webhooks:     webhooks,
 	}
 }
 
-// CreateBoard 新しいボードを作成します
-func (s *boardService) CreateBoard(ownerID uuid.UUID, name string) (*domain.Board, error) {
+// CreateBoard ワークスペースに新しいボードを作成します（workspaceIDが0の場合は個人用ワークスペース）
+// templateIDで指定したテンプレートのカラム・ラベル・カスタムフィールド・初期タスクを作成します
+// templateIDが空の場合は既定の組み込みテンプレート（To Do / In Progress / Done）を使用します
+func (s *boardService) CreateBoard(ownerID uuid.UUID, workspaceID uint, name, templateID string) (*domain.Board, error) {
+	workspaceID, err := resolveWorkspace(s.workspaces, ownerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := findBoardTemplate(s.templateRepo, templateID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Definition.Validate(); err != nil {
+		return nil, err
+	}
+
 	// 新しいボードを作成
 	board := &domain.Board{
-		Name:    name,
-		OwnerID: ownerID,
+		Name:        name,
+		WorkspaceID: workspaceID,
+		OwnerID:     ownerID,
 	}
 
-	// トランザクション内でボードとデフォルトカラムを作成
-	err := s.db.Transaction(func(tx *gorm.DB) error {
+	// トランザクション内でボードとテンプレートの構成を作成
+	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// ボードを作成
-		if err := s.boardRepo.Create(board); err != nil {
+		if err := repository.NewBoardRepository(tx).Create(board); err != nil {
 			return fmt.Errorf("ボード作成エラー: %w", err)
 		}
 
-		// デフォルトのカラムを作成
-		defaultColumns := []domain.Column{
-			{BoardID: board.ID, Title: "To Do", Order: 1},
-			{BoardID: board.ID, Title: "In Progress", Order: 2},
-			{BoardID: board.ID, Title: "Done", Order: 3},
-		}
-
-		for _, column := range defaultColumns {
-			if err := tx.Create(&column).Error; err != nil {
-				return fmt.Errorf("デフォルトカラム作成エラー: %w", err)
-			}
-		}
-
-		return nil
+		return applyBoardTemplate(tx, board.ID, &template.Definition)
 	})
 
 	if err != nil {
@@ -74,9 +86,19 @@ func (s *boardService) CreateBoard(ownerID uuid.UUID, name string) (*domain.Boar
 	return board, nil
 }
 
-// GetUserBoards ユーザーが所有するボード一覧を取得します
-func (s *boardService) GetUserBoards(userID uuid.UUID) ([]domain.Board, error) {
-	boards, err := s.boardRepo.GetByOwnerID(userID)
+// GetUserBoards ユーザーがアクセスできるボード一覧を取得します
+// workspaceIDが0以外の場合は、そのワークスペースのボードのみを取得します
+func (s *boardService) GetUserBoards(userID uuid.UUID, workspaceID uint) ([]domain.Board, error) {
+	var boards []domain.Board
+	var err error
+	if workspaceID == 0 {
+		boards, err = s.boardRepo.GetByUserID(userID)
+	} else {
+		if _, err := getWorkspaceMember(s.workspaces, workspaceID, userID); err != nil {
+			return nil, err
+		}
+		boards, err = s.boardRepo.GetByWorkspaceID(workspaceID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("ボード取得エラー: %w", err)
 	}
@@ -103,7 +125,8 @@ func (s *boardService) GetBoardWithColumns(boardID uint, userID uuid.UUID) (*dom
 }
 
 // UpdateBoard ボード情報を更新します
-func (s *boardService) UpdateBoard(boardID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Board, error) {
+// versionがdomain.AnyVersion以外の場合は、現在のバージョンと一致するときのみ更新します
+func (s *boardService) UpdateBoard(boardID uint, userID uuid.UUID, version int, updates map[string]interface{}) (*domain.Board, error) {
 	// ボードの所有権をチェック
 	if err := s.CheckBoardOwnership(boardID, userID); err != nil {
 		return nil, err
@@ -117,36 +140,62 @@ func (s *boardService) UpdateBoard(boardID uint, userID uuid.UUID, updates map[s
 	if board == nil {
 		return nil, errors.New("ボードが見つかりません")
 	}
+	if err := domain.CheckVersion(version, board.Version); err != nil {
+		return nil, err
+	}
 
 	// 更新可能なフィールドのみ処理
 	if name, ok := updates["name"].(string); ok && name != "" {
 		board.Name = name
 	}
+	// 別のワークスペースへの移動（移動元の管理者で、移動先のメンバーである必要がある）
+	if workspaceID, ok := updates["workspace_id"].(uint); ok && workspaceID != board.WorkspaceID {
+		if err := checkWorkspaceAdmin(s.workspaces, board.WorkspaceID, userID); err != nil {
+			return nil, err
+		}
+		if _, err := getWorkspaceMember(s.workspaces, workspaceID, userID); err != nil {
+			return nil, err
+		}
+		board.WorkspaceID = workspaceID
+	}
 
 	// データベースに保存
 	if err := s.boardRepo.Update(board); err != nil {
 		return nil, fmt.Errorf("ボード更新エラー: %w", err)
 	}
 
+	s.webhooks.Publish(board.ID, domain.WebhookEventBoardUpdated, board)
 	return board, nil
 }
 
-// DeleteBoard ボードを削除します
-func (s *boardService) DeleteBoard(boardID uint, userID uuid.UUID) error {
+// DeleteBoard ボードを削除します（ボードのワークスペースの管理者のみ）
+// versionがdomain.AnyVersion以外の場合は、現在のバージョンと一致するときのみ削除します
+func (s *boardService) DeleteBoard(boardID uint, userID uuid.UUID, version int) error {
 	// ボードの所有権をチェック
 	if err := s.CheckBoardOwnership(boardID, userID); err != nil {
 		return err
 	}
+	board, err := s.boardRepo.GetByID(boardID)
+	if err != nil {
+		return fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		return errors.New("ボードが見つかりません")
+	}
+	if err := checkWorkspaceAdmin(s.workspaces, board.WorkspaceID, userID); err != nil {
+		return err
+	}
 
 	// ボードを削除（カスケード削除でカラムとタスクも削除される）
-	if err := s.boardRepo.Delete(boardID); err != nil {
+	if err := s.boardRepo.Delete(boardID, version); err != nil {
 		return fmt.Errorf("ボード削除エラー: %w", err)
 	}
 
+	s.webhooks.Publish(boardID, domain.WebhookEventBoardDeleted, map[string]interface{}{"board_id": boardID})
 	return nil
 }
 
-// CheckBoardOwnership ボードの所有権をチェックします
+// CheckBoardOwnership ボードへのアクセス権（ボードのワークスペースのメンバーかどうか）をチェックします
 func (s *boardService) CheckBoardOwnership(boardID uint, userID uuid.UUID) error {
 	board, err := s.boardRepo.GetByID(boardID)
 	if err != nil {
@@ -155,8 +204,72 @@ func (s *boardService) CheckBoardOwnership(boardID uint, userID uuid.UUID) error
 	if board == nil {
 		return errors.New("ボードが見つかりません")
 	}
-	if board.OwnerID != userID {
+	member, err := isBoardMember(s.boardRepo, board.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
 		return errors.New("このボードにアクセスする権限がありません")
 	}
 	return nil
 }
+
+// isBoardMember ユーザーがボードのワークスペースのメンバーかどうかを判定します
+func isBoardMember(boardRepo repository.BoardRepository, boardID uint, userID uuid.UUID) (bool, error) {
+	member, err := boardRepo.IsAccessibleBy(boardID, userID)
+	if err != nil {
+		return false, fmt.Errorf("権限確認エラー: %w", err)
+	}
+	return member, nil
+}
+
+// GetColumn カラムを取得します
+func (s *boardService) GetColumn(columnID uint, userID uuid.UUID) (*domain.Column, error) {
+	column, err := s.columnRepo.GetByID(columnID)
+	if err != nil {
+		return nil, fmt.Errorf("カラム取得エラー: %w", err)
+	}
+	if column == nil {
+		return nil, errors.New("カラムが見つかりません")
+	}
+
+	// ボードの所有権をチェック
+	if err := s.CheckBoardOwnership(column.BoardID, userID); err != nil {
+		return nil, err
+	}
+
+	return column, nil
+}
+
+// UpdateColumnWIPLimit カラムのWIP制限を設定します
+// limitがnilの場合は制限を解除します
+// versionがdomain.AnyVersion以外の場合は、現在のバージョンと一致するときのみ更新します
+func (s *boardService) UpdateColumnWIPLimit(columnID uint, userID uuid.UUID, version int, limit *int, mode domain.WIPLimitMode) (*domain.Column, error) {
+	column, err := s.GetColumn(columnID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.CheckVersion(version, column.Version); err != nil {
+		return nil, err
+	}
+
+	if mode == "" {
+		mode = domain.WIPLimitModeSoft
+	}
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("不正なWIP制限モードです: %s", mode)
+	}
+	if limit != nil && *limit < 1 {
+		return nil, errors.New("WIP制限は1以上で指定してください")
+	}
+
+	if err := s.columnRepo.UpdateWIPLimit(columnID, column.Version, limit, mode); err != nil {
+		return nil, fmt.Errorf("WIP制限更新エラー: %w", err)
+	}
+
+	column.WIPLimit = limit
+	column.WIPLimitMode = mode
+	column.Version++
+	s.webhooks.Publish(column.BoardID, domain.WebhookEventColumnUpdated, column)
+	return column, nil
+}
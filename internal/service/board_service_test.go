@@ -0,0 +1,90 @@
+package service
+
+import (
+	"testing"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// workspaceBoardRepository ワークスペースのメンバーをボードのメンバーとして判定するBoardRepository（使用するメソッドのみ実装）
+type workspaceBoardRepository struct {
+	repository.BoardRepository
+	boards     map[uint]*domain.Board
+	workspaces *memoryWorkspaceRepository
+}
+
+func (r *workspaceBoardRepository) GetByID(id uint) (*domain.Board, error) {
+	board, ok := r.boards[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *board
+	return &copied, nil
+}
+
+func (r *workspaceBoardRepository) IsAccessibleBy(boardID uint, userID uuid.UUID) (bool, error) {
+	board, ok := r.boards[boardID]
+	if !ok {
+		return false, nil
+	}
+	member, err := r.workspaces.GetMember(board.WorkspaceID, userID)
+	return member != nil, err
+}
+
+func (r *workspaceBoardRepository) Update(board *domain.Board) error {
+	copied := *board
+	r.boards[board.ID] = &copied
+	return nil
+}
+
+func (r *workspaceBoardRepository) Delete(id uint, version int) error {
+	delete(r.boards, id)
+	return nil
+}
+
+// 共有のワークスペースのボードの移動と削除は、ワークスペースの管理者のみができることのテスト
+func TestBoardService_WorkspaceAdminOnly(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	workspaces := &memoryWorkspaceRepository{
+		workspaces: make(map[uint]*domain.Workspace),
+		members:    make(map[uint]map[uuid.UUID]*domain.WorkspaceMember),
+	}
+	team := &domain.Workspace{Name: "開発チーム", Members: []domain.WorkspaceMember{{UserID: alice, Role: domain.WorkspaceRoleAdmin}}}
+	require.NoError(t, workspaces.Create(team))
+	require.NoError(t, workspaces.AddMember(&domain.WorkspaceMember{WorkspaceID: team.ID, UserID: bob, Role: domain.WorkspaceRoleMember}))
+	alicePersonal, err := resolveWorkspace(workspaces, alice, 0)
+	require.NoError(t, err)
+	bobPersonal, err := resolveWorkspace(workspaces, bob, 0)
+	require.NoError(t, err)
+
+	boards := &workspaceBoardRepository{workspaces: workspaces, boards: map[uint]*domain.Board{
+		1: {ID: 1, Name: "チームのボード", WorkspaceID: team.ID, OwnerID: bob},
+		2: {ID: 2, Name: "リリース", WorkspaceID: team.ID, OwnerID: bob},
+	}}
+	webhooks := &recordingWebhookPublisher{}
+	svc := NewBoardService(boards, nil, nil, workspaces, nil, webhooks)
+
+	// メンバーはボードを編集できるが、自分の個人用ワークスペースへの移動と削除はできない
+	board, err := svc.UpdateBoard(1, bob, domain.AnyVersion, map[string]interface{}{"name": "チームのボード（改）"})
+	require.NoError(t, err)
+	assert.Equal(t, team.ID, board.WorkspaceID)
+	_, err = svc.UpdateBoard(1, bob, domain.AnyVersion, map[string]interface{}{"workspace_id": bobPersonal})
+	assert.Error(t, err)
+	assert.Equal(t, team.ID, boards.boards[1].WorkspaceID)
+	assert.Error(t, svc.DeleteBoard(2, bob, domain.AnyVersion))
+	assert.Contains(t, boards.boards, uint(2))
+
+	// 管理者は移動・削除できる
+	board, err = svc.UpdateBoard(1, alice, domain.AnyVersion, map[string]interface{}{"workspace_id": alicePersonal})
+	require.NoError(t, err)
+	assert.Equal(t, alicePersonal, board.WorkspaceID)
+	require.NoError(t, svc.DeleteBoard(2, alice, domain.AnyVersion))
+	assert.NotContains(t, boards.boards, uint(2))
+
+	assert.Equal(t, []domain.WebhookEvent{domain.WebhookEventBoardUpdated, domain.WebhookEventBoardUpdated, domain.WebhookEventBoardDeleted}, webhooks.events)
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BoardTemplateService ボードテンプレート関連のビジネスロジックを管理するインターフェース
+type BoardTemplateService interface {
+	ListTemplates(userID uuid.UUID) ([]domain.BoardTemplate, error)
+	GetTemplate(templateID string, userID uuid.UUID) (*domain.BoardTemplate, error)
+	CreateTemplate(userID uuid.UUID, name, description string, definition domain.BoardTemplateDefinition) (*domain.BoardTemplate, error)
+	SaveBoardAsTemplate(boardID uint, userID uuid.UUID, name, description string, includeTasks bool) (*domain.BoardTemplate, error)
+	DeleteTemplate(templateID string, userID uuid.UUID) error
+}
+
+// boardTemplateService BoardTemplateServiceの実装
+type boardTemplateService struct {
+	templateRepo    repository.BoardTemplateRepository
+	boardRepo       repository.BoardRepository
+	labelRepo       repository.LabelRepository
+	customFieldRepo repository.CustomFieldRepository
+	boardService    BoardService
+}
+
+// NewBoardTemplateService BoardTemplateServiceの新しいインスタンスを作成
+func NewBoardTemplateService(templateRepo repository.BoardTemplateRepository, boardRepo repository.BoardRepository, labelRepo repository.LabelRepository, customFieldRepo repository.CustomFieldRepository, boardService BoardService) BoardTemplateService {
+	return &boardTemplateService{
+		templateRepo:    templateRepo,
+		boardRepo:       boardRepo,
+		labelRepo:       labelRepo,
+		customFieldRepo: customFieldRepo,
+		boardService:    boardService,
+	}
+}
+
+// ListTemplates 組み込みテンプレートとユーザーが保存したテンプレートの一覧を取得します
+func (s *boardTemplateService) ListTemplates(userID uuid.UUID) ([]domain.BoardTemplate, error) {
+	templates, err := s.templateRepo.GetByOwnerID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("テンプレート取得エラー: %w", err)
+	}
+	return append(domain.BuiltinBoardTemplates(), templates...), nil
+}
+
+// GetTemplate テンプレートIDでテンプレートを取得します
+func (s *boardTemplateService) GetTemplate(templateID string, userID uuid.UUID) (*domain.BoardTemplate, error) {
+	return findBoardTemplate(s.templateRepo, templateID, userID)
+}
+
+// CreateTemplate 構成を指定してテンプレートを作成します
+func (s *boardTemplateService) CreateTemplate(userID uuid.UUID, name, description string, definition domain.BoardTemplateDefinition) (*domain.BoardTemplate, error) {
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	template := &domain.BoardTemplate{
+		OwnerID:     userID,
+		Name:        name,
+		Description: description,
+		Definition:  definition,
+	}
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, fmt.Errorf("テンプレート作成エラー: %w", err)
+	}
+	return template, nil
+}
+
+// SaveBoardAsTemplate ボードのカラム・WIP制限・ラベル・カスタムフィールドをテンプレートとして保存します
+// includeTasksがtrueの場合は、ボードのタスクを初期タスクとして含めます
+func (s *boardTemplateService) SaveBoardAsTemplate(boardID uint, userID uuid.UUID, name, description string, includeTasks bool) (*domain.BoardTemplate, error) {
+	// ボードの所有権をチェック
+	if err := s.boardService.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardRepo.GetByIDWithColumns(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		return nil, errors.New("ボードが見つかりません")
+	}
+	labels, err := s.labelRepo.GetByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("ラベル取得エラー: %w", err)
+	}
+	fields, err := s.customFieldRepo.GetDefinitionsByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+	}
+
+	return s.CreateTemplate(userID, name, description, newBoardTemplateDefinition(board, labels, fields, includeTasks))
+}
+
+// DeleteTemplate ユーザーが保存したテンプレートを削除します（組み込みテンプレートは削除できません）
+func (s *boardTemplateService) DeleteTemplate(templateID string, userID uuid.UUID) error {
+	template, err := findBoardTemplate(s.templateRepo, templateID, userID)
+	if err != nil {
+		return err
+	}
+	if template.IsBuiltin() {
+		return errors.New("組み込みテンプレートは削除できません")
+	}
+
+	if err := s.templateRepo.Delete(template.ID); err != nil {
+		return fmt.Errorf("テンプレート削除エラー: %w", err)
+	}
+	return nil
+}
+
+// findBoardTemplate テンプレートIDでテンプレートを取得します
+// 空文字列の場合は既定の組み込みテンプレート、数値以外は組み込みテンプレートのキーとして扱います
+func findBoardTemplate(templateRepo repository.BoardTemplateRepository, templateID string, userID uuid.UUID) (*domain.BoardTemplate, error) {
+	templateID = strings.TrimSpace(templateID)
+	if templateID == "" {
+		templateID = domain.DefaultBoardTemplateKey
+	}
+
+	id, err := strconv.ParseUint(templateID, 10, 32)
+	if err != nil {
+		if template := domain.FindBuiltinBoardTemplate(templateID); template != nil {
+			return template, nil
+		}
+		return nil, errors.New("テンプレートが見つかりません")
+	}
+
+	template, err := templateRepo.GetByID(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("テンプレート取得エラー: %w", err)
+	}
+	if template == nil {
+		return nil, errors.New("テンプレートが見つかりません")
+	}
+	if template.OwnerID != userID {
+		return nil, errors.New("このテンプレートにアクセスする権限がありません")
+	}
+	return template, nil
+}
+
+// newBoardTemplateDefinition ボードの構成からテンプレートの構成を作成します
+func newBoardTemplateDefinition(board *domain.Board, labels []domain.Label, fields []domain.CustomFieldDefinition, includeTasks bool) domain.BoardTemplateDefinition {
+	definition := domain.BoardTemplateDefinition{
+		Columns:      make([]domain.TemplateColumn, 0, len(board.Columns)),
+		Labels:       make([]domain.TemplateLabel, 0, len(labels)),
+		CustomFields: make([]domain.TemplateCustomField, 0, len(fields)),
+		Tasks:        []domain.TemplateTask{},
+	}
+
+	for i, column := range board.Columns {
+		definition.Columns = append(definition.Columns, domain.TemplateColumn{
+			Title:        column.Title,
+			WIPLimit:     column.WIPLimit,
+			WIPLimitMode: column.WIPLimitMode,
+		})
+		if !includeTasks {
+			continue
+		}
+		for _, task := range column.Tasks {
+			labelNames := make([]string, 0, len(task.Labels))
+			for _, label := range task.Labels {
+				labelNames = append(labelNames, label.Name)
+			}
+			definition.Tasks = append(definition.Tasks, domain.TemplateTask{
+				Column:      i,
+				Title:       task.Title,
+				Description: task.Description,
+				Priority:    task.Priority,
+				Labels:      labelNames,
+			})
+		}
+	}
+	for _, label := range labels {
+		definition.Labels = append(definition.Labels, domain.TemplateLabel{Name: label.Name, Color: label.Color})
+	}
+	for _, field := range fields {
+		definition.CustomFields = append(definition.CustomFields, domain.TemplateCustomField{
+			Name:    field.Name,
+			Type:    field.Type,
+			Options: field.Options,
+		})
+	}
+	return definition
+}
+
+// applyBoardTemplate テンプレートの構成（カラム・ラベル・カスタムフィールド・初期タスク）をボードに作成します
+// txはボード作成と同じトランザクションを指定します
+func applyBoardTemplate(tx *gorm.DB, boardID uint, definition *domain.BoardTemplateDefinition) error {
+	columnRepo := repository.NewColumnRepository(tx)
+	labelRepo := repository.NewLabelRepository(tx)
+	customFieldRepo := repository.NewCustomFieldRepository(tx)
+	taskRepo := repository.NewTaskRepository(tx)
+
+	columnIDs := make([]uint, 0, len(definition.Columns))
+	for i, templateColumn := range definition.Columns {
+		mode := templateColumn.WIPLimitMode
+		if mode == "" {
+			mode = domain.WIPLimitModeSoft
+		}
+		column := &domain.Column{
+			BoardID:      boardID,
+			Title:        templateColumn.Title,
+			Order:        i + 1,
+			WIPLimit:     templateColumn.WIPLimit,
+			WIPLimitMode: mode,
+		}
+		if err := columnRepo.Create(column); err != nil {
+			return fmt.Errorf("カラム作成エラー: %w", err)
+		}
+		columnIDs = append(columnIDs, column.ID)
+	}
+
+	labelIDs := make(map[string]uint, len(definition.Labels))
+	for _, templateLabel := range definition.Labels {
+		label := &domain.Label{
+			BoardID: boardID,
+			Name:    templateLabel.Name,
+			Color:   templateLabel.Color,
+		}
+		if label.Color == "" {
+			label.Color = "#6B7280"
+		}
+		if err := labelRepo.Create(label); err != nil {
+			return fmt.Errorf("ラベル作成エラー: %w", err)
+		}
+		labelIDs[label.Name] = label.ID
+	}
+
+	for i, templateField := range definition.CustomFields {
+		field := &domain.CustomFieldDefinition{
+			BoardID: boardID,
+			Name:    templateField.Name,
+			Type:    templateField.Type,
+			Order:   i + 1,
+		}
+		if templateField.Type.IsSelect() {
+			field.Options = templateField.Options
+		}
+		if err := customFieldRepo.CreateDefinition(field); err != nil {
+			return fmt.Errorf("カスタムフィールド作成エラー: %w", err)
+		}
+	}
+
+	for _, templateTask := range definition.Tasks {
+		priority := templateTask.Priority
+		if priority == "" {
+			priority = domain.TaskPriorityNone
+		}
+		task := &domain.Task{
+			ColumnID:    columnIDs[templateTask.Column],
+			Title:       templateTask.Title,
+			Description: templateTask.Description,
+			Priority:    priority,
+		}
+		if err := taskRepo.Create(task); err != nil {
+			return fmt.Errorf("タスク作成エラー: %w", err)
+		}
+		for _, name := range templateTask.Labels {
+			labelID, ok := labelIDs[name]
+			if !ok {
+				continue
+			}
+			if err := labelRepo.AddToTask(task.ID, labelID); err != nil {
+				return fmt.Errorf("ラベル付与エラー: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
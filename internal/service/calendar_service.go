@@ -21,8 +21,9 @@ type CalendarService interface {
 	// カレンダーイベント関連
 	CreateEvent(userID uuid.UUID, event *domain.CalendarEvent) error
 	GetEventsByDateRange(userID uuid.UUID, start, end time.Time) ([]*domain.CalendarEvent, error)
-	UpdateEvent(userID uuid.UUID, eventID uint, event *domain.CalendarEvent) error
-	DeleteEvent(userID uuid.UUID, eventID uint) error
+	GetEvent(userID uuid.UUID, eventID uint) (*domain.CalendarEvent, error)
+	UpdateEvent(userID uuid.UUID, eventID uint, version int, event *domain.CalendarEvent) (*domain.CalendarEvent, error)
+	DeleteEvent(userID uuid.UUID, eventID uint, version int) error
 
 	// タスクからカレンダーイベント作成
 	CreateEventFromTask(userID uuid.UUID, task *domain.Task, start, end time.Time) error
@@ -117,16 +118,35 @@ func (s *calendarService) GetEventsByDateRange(userID uuid.UUID, start, end time
 	return s.calendarEventRepo.GetByUserIDAndDateRange(userID, start, end)
 }
 
+// GetEvent カレンダーイベントを取得します
+func (s *calendarService) GetEvent(userID uuid.UUID, eventID uint) (*domain.CalendarEvent, error) {
+	existing, err := s.calendarEventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ユーザー権限チェック
+	if existing.UserID != userID {
+		return nil, errors.New("このイベントにアクセスする権限がありません")
+	}
+
+	return existing, nil
+}
+
 // UpdateEvent カレンダーイベントを更新します
-func (s *calendarService) UpdateEvent(userID uuid.UUID, eventID uint, event *domain.CalendarEvent) error {
+// versionがdomain.AnyVersion以外の場合は、現在のバージョンと一致するときのみ更新します
+func (s *calendarService) UpdateEvent(userID uuid.UUID, eventID uint, version int, event *domain.CalendarEvent) (*domain.CalendarEvent, error) {
 	existing, err := s.calendarEventRepo.GetByID(eventID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// ユーザー権限チェック
 	if existing.UserID != userID {
-		return errors.New("このイベントを更新する権限がありません")
+		return nil, errors.New("このイベントを更新する権限がありません")
+	}
+	if err := domain.CheckVersion(version, existing.Version); err != nil {
+		return nil, err
 	}
 
 	// 更新フィールドを設定
@@ -135,11 +155,15 @@ func (s *calendarService) UpdateEvent(userID uuid.UUID, eventID uint, event *dom
 	existing.End = event.End
 	existing.Color = event.Color
 
-	return s.calendarEventRepo.Update(existing)
+	if err := s.calendarEventRepo.Update(existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
 }
 
 // DeleteEvent カレンダーイベントを削除します
-func (s *calendarService) DeleteEvent(userID uuid.UUID, eventID uint) error {
+// versionがdomain.AnyVersion以外の場合は、現在のバージョンと一致するときのみ削除します
+func (s *calendarService) DeleteEvent(userID uuid.UUID, eventID uint, version int) error {
 	existing, err := s.calendarEventRepo.GetByID(eventID)
 	if err != nil {
 		return err
@@ -150,7 +174,7 @@ func (s *calendarService) DeleteEvent(userID uuid.UUID, eventID uint) error {
 		return errors.New("このイベントを削除する権限がありません")
 	}
 
-	return s.calendarEventRepo.Delete(eventID)
+	return s.calendarEventRepo.Delete(eventID, version)
 }
 
 // CreateEventFromTask タスクからカレンダーイベントを作成します
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// CustomFieldService カスタムフィールド関連のビジネスロジックを管理するインターフェース
+type CustomFieldService interface {
+	CreateField(boardID uint, userID uuid.UUID, name string, fieldType domain.CustomFieldType, options []string) (*domain.CustomFieldDefinition, error)
+	GetBoardFields(boardID uint, userID uuid.UUID) ([]domain.CustomFieldDefinition, error)
+	UpdateField(fieldID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.CustomFieldDefinition, error)
+	DeleteField(fieldID uint, userID uuid.UUID) error
+	SetTaskValue(taskID, fieldID uint, userID uuid.UUID, raw interface{}) (*domain.TaskCustomFieldValue, error)
+	ClearTaskValue(taskID, fieldID uint, userID uuid.UUID) error
+}
+
+// customFieldService CustomFieldServiceの実装
+type customFieldService struct {
+	customFieldRepo repository.CustomFieldRepository
+	taskRepo        repository.TaskRepository
+	boardService    BoardService
+	webhooks        WebhookPublisher
+}
+
+// NewCustomFieldService CustomFieldServiceの新しいインスタンスを作成
+func NewCustomFieldService(customFieldRepo repository.CustomFieldRepository, taskRepo repository.TaskRepository, boardService BoardService, webhooks WebhookPublisher) CustomFieldService {
+	return &customFieldService{
+		customFieldRepo: customFieldRepo,
+		taskRepo:        taskRepo,
+		boardService:    boardService,
+		webhooks:        webhooks,
+	}
+}
+
+// CreateField ボードに新しいカスタムフィールドを定義します
+func (s *customFieldService) CreateField(boardID uint, userID uuid.UUID, name string, fieldType domain.CustomFieldType, options []string) (*domain.CustomFieldDefinition, error) {
+	// ボードの所有権をチェック
+	if err := s.boardService.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	if !fieldType.IsValid() {
+		return nil, fmt.Errorf("不正なフィールド型です: %s", fieldType)
+	}
+
+	normalized, err := domain.NormalizeCustomFieldOptions(fieldType, options)
+	if err != nil {
+		return nil, err
+	}
+
+	field := &domain.CustomFieldDefinition{
+		BoardID: boardID,
+		Name:    name,
+		Type:    fieldType,
+		Options: normalized,
+	}
+
+	if err := s.customFieldRepo.CreateDefinition(field); err != nil {
+		return nil, fmt.Errorf("カスタムフィールド作成エラー: %w", err)
+	}
+
+	return field, nil
+}
+
+// GetBoardFields ボードのカスタムフィールド定義一覧を取得します
+func (s *customFieldService) GetBoardFields(boardID uint, userID uuid.UUID) ([]domain.CustomFieldDefinition, error) {
+	// ボードの所有権をチェック
+	if err := s.boardService.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	fields, err := s.customFieldRepo.GetDefinitionsByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+	}
+	return fields, nil
+}
+
+// UpdateField カスタムフィールド定義を更新します
+// フィールド型は変更できません（既存の値との整合性を保つため）
+func (s *customFieldService) UpdateField(fieldID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.CustomFieldDefinition, error) {
+	field, err := s.getFieldWithAccess(fieldID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新可能なフィールドのみ処理
+	if name, ok := updates["name"].(string); ok && name != "" {
+		field.Name = name
+	}
+	if options, ok := updates["options"].([]string); ok {
+		normalized, err := domain.NormalizeCustomFieldOptions(field.Type, options)
+		if err != nil {
+			return nil, err
+		}
+		field.Options = normalized
+	}
+	if order, ok := updates["order"].(int); ok && order > 0 {
+		field.Order = order
+	}
+
+	if err := s.customFieldRepo.UpdateDefinition(field); err != nil {
+		return nil, fmt.Errorf("カスタムフィールド更新エラー: %w", err)
+	}
+
+	return field, nil
+}
+
+// DeleteField カスタムフィールド定義を削除します
+func (s *customFieldService) DeleteField(fieldID uint, userID uuid.UUID) error {
+	if _, err := s.getFieldWithAccess(fieldID, userID); err != nil {
+		return err
+	}
+
+	if err := s.customFieldRepo.DeleteDefinition(fieldID); err != nil {
+		return fmt.Errorf("カスタムフィールド削除エラー: %w", err)
+	}
+	return nil
+}
+
+// SetTaskValue タスクのカスタムフィールド値を設定します
+// rawはJSONからデコードされた値で、フィールド型に応じて検証されます
+func (s *customFieldService) SetTaskValue(taskID, fieldID uint, userID uuid.UUID, raw interface{}) (*domain.TaskCustomFieldValue, error) {
+	field, err := s.getFieldForTask(taskID, fieldID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := domain.ParseCustomFieldValue(field, raw)
+	if err != nil {
+		return nil, err
+	}
+	value.TaskID = taskID
+	value.FieldID = fieldID
+
+	if err := s.customFieldRepo.SaveValue(value); err != nil {
+		return nil, fmt.Errorf("カスタムフィールド値保存エラー: %w", err)
+	}
+
+	publishTaskEvent(s.webhooks, s.taskRepo, taskID, domain.WebhookEventTaskUpdated)
+	return value, nil
+}
+
+// ClearTaskValue タスクのカスタムフィールド値を削除します
+func (s *customFieldService) ClearTaskValue(taskID, fieldID uint, userID uuid.UUID) error {
+	if _, err := s.getFieldForTask(taskID, fieldID, userID); err != nil {
+		return err
+	}
+
+	if err := s.customFieldRepo.DeleteValue(taskID, fieldID); err != nil {
+		return fmt.Errorf("カスタムフィールド値削除エラー: %w", err)
+	}
+
+	publishTaskEvent(s.webhooks, s.taskRepo, taskID, domain.WebhookEventTaskUpdated)
+	return nil
+}
+
+// getFieldWithAccess フィールド定義を取得し、ボードの所有権をチェックします
+func (s *customFieldService) getFieldWithAccess(fieldID uint, userID uuid.UUID) (*domain.CustomFieldDefinition, error) {
+	field, err := s.customFieldRepo.GetDefinitionByID(fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+	}
+	if field == nil {
+		return nil, errors.New("カスタムフィールドが見つかりません")
+	}
+
+	if err := s.boardService.CheckBoardOwnership(field.BoardID, userID); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// getFieldForTask フィールド定義を取得し、タスクと同じボードに属することを確認します
+func (s *customFieldService) getFieldForTask(taskID, fieldID uint, userID uuid.UUID) (*domain.CustomFieldDefinition, error) {
+	field, err := s.getFieldWithAccess(fieldID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("タスク取得エラー: %w", err)
+	}
+	if task == nil {
+		return nil, errors.New("タスクが見つかりません")
+	}
+	if task.Column.BoardID != field.BoardID {
+		return nil, errors.New("このフィールドはタスクのボードに定義されていません")
+	}
+
+	return field, nil
+}
+
+// parseCustomFieldFilter クエリ文字列の値をフィールド型に従って検索条件に変換します
+func parseCustomFieldFilter(field *domain.CustomFieldDefinition, raw string) (repository.CustomFieldFilter, error) {
+	filter := repository.CustomFieldFilter{FieldID: field.ID, Type: field.Type}
+
+	switch field.Type {
+	case domain.CustomFieldTypeNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return filter, fmt.Errorf("%sの検索条件には数値を指定してください", field.Name)
+		}
+		filter.Value = number
+	case domain.CustomFieldTypeDate:
+		date, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return filter, fmt.Errorf("%sの検索条件には日付を指定してください（YYYY-MM-DD形式）", field.Name)
+		}
+		filter.Value = date
+	case domain.CustomFieldTypeSingleSelect, domain.CustomFieldTypeMultiSelect:
+		if !field.Options.Contains(raw) {
+			return filter, fmt.Errorf("%sの検索条件には定義済みの選択肢を指定してください", field.Name)
+		}
+		filter.Value = raw
+	default:
+		filter.Value = raw
+	}
+
+	return filter, nil
+}
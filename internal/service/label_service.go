@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// LabelService ラベル関連のビジネスロジックを管理するインターフェース
+type LabelService interface {
+	CreateLabel(boardID uint, userID uuid.UUID, name, color string) (*domain.Label, error)
+	GetBoardLabels(boardID uint, userID uuid.UUID) ([]domain.Label, error)
+	UpdateLabel(labelID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Label, error)
+	DeleteLabel(labelID uint, userID uuid.UUID) error
+	AddLabelToTask(taskID, labelID uint, userID uuid.UUID) error
+	RemoveLabelFromTask(taskID, labelID uint, userID uuid.UUID) error
+}
+
+// labelService LabelServiceの実装
+type labelService struct {
+	labelRepo    repository.LabelRepository
+	taskRepo     repository.TaskRepository
+	boardService BoardService
+	webhooks     WebhookPublisher
+}
+
+// NewLabelService LabelServiceの新しいインスタンスを作成
+func NewLabelService(labelRepo repository.LabelRepository, taskRepo repository.TaskRepository, boardService BoardService, webhooks WebhookPublisher) LabelService {
+	return &labelService{
+		labelRepo:    labelRepo,
+		taskRepo:     taskRepo,
+		boardService: boardService,
+		webhooks:     webhooks,
+	}
+}
+
+// CreateLabel ボードに新しいラベルを作成します
+func (s *labelService) CreateLabel(boardID uint, userID uuid.UUID, name, color string) (*domain.Label, error) {
+	// ボードの所有権をチェック
+	if err := s.boardService.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	if color != "" {
+		if err := domain.ValidateLabelColor(color); err != nil {
+			return nil, err
+		}
+	}
+
+	label := &domain.Label{
+		BoardID: boardID,
+		Name:    name,
+		Color:   color,
+	}
+	if err := s.labelRepo.Create(label); err != nil {
+		return nil, fmt.Errorf("ラベル作成エラー: %w", err)
+	}
+
+	return label, nil
+}
+
+// GetBoardLabels ボードのラベル一覧を取得します
+func (s *labelService) GetBoardLabels(boardID uint, userID uuid.UUID) ([]domain.Label, error) {
+	// ボードの所有権をチェック
+	if err := s.boardService.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.labelRepo.GetByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("ラベル取得エラー: %w", err)
+	}
+	return labels, nil
+}
+
+// UpdateLabel ラベル情報を更新します
+func (s *labelService) UpdateLabel(labelID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Label, error) {
+	label, err := s.getLabelWithAccess(labelID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新可能なフィールドのみ処理
+	if name, ok := updates["name"].(string); ok && name != "" {
+		label.Name = name
+	}
+	if color, ok := updates["color"].(string); ok && color != "" {
+		if err := domain.ValidateLabelColor(color); err != nil {
+			return nil, err
+		}
+		label.Color = color
+	}
+
+	if err := s.labelRepo.Update(label); err != nil {
+		return nil, fmt.Errorf("ラベル更新エラー: %w", err)
+	}
+	return label, nil
+}
+
+// DeleteLabel ラベルを削除します
+func (s *labelService) DeleteLabel(labelID uint, userID uuid.UUID) error {
+	if _, err := s.getLabelWithAccess(labelID, userID); err != nil {
+		return err
+	}
+
+	if err := s.labelRepo.Delete(labelID); err != nil {
+		return fmt.Errorf("ラベル削除エラー: %w", err)
+	}
+	return nil
+}
+
+// AddLabelToTask タスクにラベルを付与します
+func (s *labelService) AddLabelToTask(taskID, labelID uint, userID uuid.UUID) error {
+	if err := s.checkTaskLabel(taskID, labelID, userID); err != nil {
+		return err
+	}
+
+	if err := s.labelRepo.AddToTask(taskID, labelID); err != nil {
+		return fmt.Errorf("ラベル付与エラー: %w", err)
+	}
+
+	publishTaskEvent(s.webhooks, s.taskRepo, taskID, domain.WebhookEventTaskUpdated)
+	return nil
+}
+
+// RemoveLabelFromTask タスクからラベルを外します
+func (s *labelService) RemoveLabelFromTask(taskID, labelID uint, userID uuid.UUID) error {
+	if err := s.checkTaskLabel(taskID, labelID, userID); err != nil {
+		return err
+	}
+
+	if err := s.labelRepo.RemoveFromTask(taskID, labelID); err != nil {
+		return fmt.Errorf("ラベル解除エラー: %w", err)
+	}
+
+	publishTaskEvent(s.webhooks, s.taskRepo, taskID, domain.WebhookEventTaskUpdated)
+	return nil
+}
+
+// getLabelWithAccess ラベルを取得し、ボードの所有権をチェックします
+func (s *labelService) getLabelWithAccess(labelID uint, userID uuid.UUID) (*domain.Label, error) {
+	label, err := s.labelRepo.GetByID(labelID)
+	if err != nil {
+		return nil, fmt.Errorf("ラベル取得エラー: %w", err)
+	}
+	if label == nil {
+		return nil, errors.New("ラベルが見つかりません")
+	}
+
+	if err := s.boardService.CheckBoardOwnership(label.BoardID, userID); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// checkTaskLabel ラベルとタスクが同じボードに属し、操作権限があることを確認します
+func (s *labelService) checkTaskLabel(taskID, labelID uint, userID uuid.UUID) error {
+	label, err := s.getLabelWithAccess(labelID, userID)
+	if err != nil {
+		return err
+	}
+
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return fmt.Errorf("タスク取得エラー: %w", err)
+	}
+	if task == nil {
+		return errors.New("タスクが見つかりません")
+	}
+	if task.Column.BoardID != label.BoardID {
+		return errors.New("このラベルはタスクのボードに定義されていません")
+	}
+	return nil
+}
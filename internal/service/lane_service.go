@@ -0,0 +1,252 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Swimlane スイムレーン表示の1行
+// Cellsはカラムごとのタスク一覧（セル内の順序順）です
+type Swimlane struct {
+	Key    string // レーンの識別子（レーンID・担当者ID・優先度、該当なしは "none"）
+	Name   string
+	LaneID *uint // groupByがlaneの場合のレーンID
+	Cells  map[uint][]domain.Task
+}
+
+// SwimlaneBoard スイムレーン表示のボード
+type SwimlaneBoard struct {
+	Board   *domain.Board // カラム一覧を含みます
+	GroupBy domain.SwimlaneGroupBy
+	Lanes   []Swimlane
+}
+
+// LaneService スイムレーン関連のビジネスロジックを管理するインターフェース
+type LaneService interface {
+	CreateLane(boardID uint, userID uuid.UUID, name string) (*domain.Lane, error)
+	GetBoardLanes(boardID uint, userID uuid.UUID) ([]domain.Lane, error)
+	UpdateLane(laneID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Lane, error)
+	DeleteLane(laneID uint, userID uuid.UUID) error
+	GetSwimlanes(boardID uint, userID uuid.UUID, groupBy domain.SwimlaneGroupBy) (*SwimlaneBoard, error)
+}
+
+// laneService LaneServiceの実装
+type laneService struct {
+	laneRepo     repository.LaneRepository
+	boardRepo    repository.BoardRepository
+	boardService BoardService
+}
+
+// NewLaneService LaneServiceの新しいインスタンスを作成
+func NewLaneService(laneRepo repository.LaneRepository, boardRepo repository.BoardRepository, boardService BoardService) LaneService {
+	return &laneService{
+		laneRepo:     laneRepo,
+		boardRepo:    boardRepo,
+		boardService: boardService,
+	}
+}
+
+// CreateLane ボードに新しいレーンを作成します
+func (s *laneService) CreateLane(boardID uint, userID uuid.UUID, name string) (*domain.Lane, error) {
+	// ボードの所有権をチェック
+	if err := s.boardService.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	lane := &domain.Lane{
+		BoardID: boardID,
+		Name:    name,
+	}
+	if err := s.laneRepo.Create(lane); err != nil {
+		return nil, fmt.Errorf("レーン作成エラー: %w", err)
+	}
+
+	return lane, nil
+}
+
+// GetBoardLanes ボードのレーン一覧を取得します
+func (s *laneService) GetBoardLanes(boardID uint, userID uuid.UUID) ([]domain.Lane, error) {
+	// ボードの所有権をチェック
+	if err := s.boardService.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	lanes, err := s.laneRepo.GetByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("レーン取得エラー: %w", err)
+	}
+	return lanes, nil
+}
+
+// UpdateLane レーン情報を更新します
+func (s *laneService) UpdateLane(laneID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Lane, error) {
+	lane, err := s.getLaneWithAccess(laneID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新可能なフィールドのみ処理
+	if name, ok := updates["name"].(string); ok && name != "" {
+		lane.Name = name
+	}
+	if order, ok := updates["order"].(int); ok && order > 0 {
+		lane.Order = order
+	}
+
+	if err := s.laneRepo.Update(lane); err != nil {
+		return nil, fmt.Errorf("レーン更新エラー: %w", err)
+	}
+
+	return lane, nil
+}
+
+// DeleteLane レーンを削除します（レーンのタスクはレーンなしに移動します）
+func (s *laneService) DeleteLane(laneID uint, userID uuid.UUID) error {
+	if _, err := s.getLaneWithAccess(laneID, userID); err != nil {
+		return err
+	}
+
+	if err := s.laneRepo.Delete(laneID); err != nil {
+		return fmt.Errorf("レーン削除エラー: %w", err)
+	}
+	return nil
+}
+
+// GetSwimlanes ボードのタスクをスイムレーン（行）とカラム（列）の表形式で取得します
+func (s *laneService) GetSwimlanes(boardID uint, userID uuid.UUID, groupBy domain.SwimlaneGroupBy) (*SwimlaneBoard, error) {
+	if groupBy == "" {
+		groupBy = domain.SwimlaneByLane
+	}
+	if !groupBy.IsValid() {
+		return nil, fmt.Errorf("不正なスイムレーンの分け方です: %s", groupBy)
+	}
+
+	// ボードの所有権をチェック
+	if err := s.boardService.CheckBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardRepo.GetByIDWithColumns(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		return nil, errors.New("ボードが見つかりません")
+	}
+
+	var lanes []domain.Lane
+	if groupBy == domain.SwimlaneByLane {
+		if lanes, err = s.laneRepo.GetByBoardID(boardID); err != nil {
+			return nil, fmt.Errorf("レーン取得エラー: %w", err)
+		}
+	}
+
+	return &SwimlaneBoard{
+		Board:   board,
+		GroupBy: groupBy,
+		Lanes:   groupSwimlanes(board.Columns, lanes, groupBy),
+	}, nil
+}
+
+// getLaneWithAccess レーンを取得し、ボードの所有権をチェックします
+func (s *laneService) getLaneWithAccess(laneID uint, userID uuid.UUID) (*domain.Lane, error) {
+	lane, err := s.laneRepo.GetByID(laneID)
+	if err != nil {
+		return nil, fmt.Errorf("レーン取得エラー: %w", err)
+	}
+	if lane == nil {
+		return nil, errors.New("レーンが見つかりません")
+	}
+
+	if err := s.boardService.CheckBoardOwnership(lane.BoardID, userID); err != nil {
+		return nil, err
+	}
+	return lane, nil
+}
+
+// groupSwimlanes カラムのタスクをスイムレーンに振り分けます
+// lane: 定義済みレーン（順序順）の後に「レーンなし」
+// assignee: 担当者ごと（メールアドレス順）の後に「未割り当て」
+// priority: 優先度の高い順
+// タスクのいないレーンは、定義済みレーンと優先度を除いて省略します
+func groupSwimlanes(columns []domain.Column, lanes []domain.Lane, groupBy domain.SwimlaneGroupBy) []Swimlane {
+	var result []Swimlane
+	index := make(map[string]int)
+	add := func(key, name string, laneID *uint) {
+		index[key] = len(result)
+		result = append(result, Swimlane{Key: key, Name: name, LaneID: laneID, Cells: make(map[uint][]domain.Task)})
+	}
+
+	// 常に表示するレーン
+	switch groupBy {
+	case domain.SwimlaneByLane:
+		for i := range lanes {
+			add(strconv.FormatUint(uint64(lanes[i].ID), 10), lanes[i].Name, &lanes[i].ID)
+		}
+	case domain.SwimlaneByPriority:
+		for i := len(domain.TaskPriorities) - 1; i >= 0; i-- {
+			p := domain.TaskPriorities[i]
+			add(string(p), string(p), nil)
+		}
+	}
+
+	// 担当者レーンはタスクから作成し、メールアドレス順に並べる
+	var assignees []domain.User
+	seen := make(map[uuid.UUID]bool)
+	for _, column := range columns {
+		for _, task := range column.Tasks {
+			if groupBy == domain.SwimlaneByAssignee && task.Assignee != nil && !seen[task.Assignee.ID] {
+				seen[task.Assignee.ID] = true
+				assignees = append(assignees, *task.Assignee)
+			}
+		}
+	}
+	sort.Slice(assignees, func(i, j int) bool { return assignees[i].Email < assignees[j].Email })
+	for _, user := range assignees {
+		add(user.ID.String(), user.Email, nil)
+	}
+
+	for _, column := range columns {
+		tasks := append([]domain.Task(nil), column.Tasks...)
+		sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Order < tasks[j].Order })
+
+		for _, task := range tasks {
+			key := "none"
+			switch groupBy {
+			case domain.SwimlaneByLane:
+				if task.LaneID != nil {
+					key = strconv.FormatUint(uint64(*task.LaneID), 10)
+				}
+			case domain.SwimlaneByAssignee:
+				if task.AssigneeID != nil {
+					key = task.AssigneeID.String()
+				}
+			case domain.SwimlaneByPriority:
+				key = string(task.Priority)
+			}
+
+			i, ok := index[key]
+			if !ok {
+				// 「レーンなし」「未割り当て」、または削除済みレーンを参照するタスク
+				if i, ok = index["none"]; !ok {
+					name := "レーンなし"
+					if groupBy == domain.SwimlaneByAssignee {
+						name = "未割り当て"
+					}
+					add("none", name, nil)
+					i = index["none"]
+				}
+			}
+			result[i].Cells[column.ID] = append(result[i].Cells[column.ID], task)
+		}
+	}
+
+	return result
+}
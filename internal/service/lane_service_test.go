@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// groupSwimlanesのレーン別の振り分けテスト
+func TestGroupSwimlanes_ByLane(t *testing.T) {
+	laneA, deletedLane := uint(10), uint(99)
+	lanes := []domain.Lane{{ID: laneA, Name: "フロントエンド", Order: 1}, {ID: 11, Name: "バックエンド", Order: 2}}
+	columns := []domain.Column{
+		{ID: 1, Tasks: []domain.Task{
+			{ID: 1, LaneID: &laneA, Order: 2},
+			{ID: 2, LaneID: &laneA, Order: 1},
+			{ID: 3, Order: 1},
+		}},
+		{ID: 2, Tasks: []domain.Task{
+			{ID: 4, LaneID: &deletedLane, Order: 1},
+		}},
+	}
+
+	result := groupSwimlanes(columns, lanes, domain.SwimlaneByLane)
+
+	assert.Len(t, result, 3)
+	assert.Equal(t, "フロントエンド", result[0].Name)
+	assert.Equal(t, []uint{2, 1}, taskIDs(result[0].Cells[1]))
+	assert.Empty(t, result[1].Cells) // タスクのないレーンも表示する
+	assert.Equal(t, "none", result[2].Key)
+	assert.Equal(t, []uint{3}, taskIDs(result[2].Cells[1]))
+	assert.Equal(t, []uint{4}, taskIDs(result[2].Cells[2]))
+}
+
+// groupSwimlanesの優先度別の振り分けテスト
+func TestGroupSwimlanes_ByPriority(t *testing.T) {
+	columns := []domain.Column{
+		{ID: 1, Tasks: []domain.Task{
+			{ID: 1, Priority: domain.TaskPriorityLow},
+			{ID: 2, Priority: domain.TaskPriorityUrgent},
+		}},
+	}
+
+	result := groupSwimlanes(columns, nil, domain.SwimlaneByPriority)
+
+	assert.Len(t, result, len(domain.TaskPriorities))
+	assert.Equal(t, string(domain.TaskPriorityUrgent), result[0].Key)
+	assert.Equal(t, []uint{2}, taskIDs(result[0].Cells[1]))
+	assert.Equal(t, string(domain.TaskPriorityNone), result[len(result)-1].Key)
+}
+
+func taskIDs(tasks []domain.Task) []uint {
+	var ids []uint
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
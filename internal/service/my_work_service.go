@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MyWork 自分が担当するタスクと今日の予定をまとめた個人ダッシュボード
+type MyWork struct {
+	Overdue     []domain.Task           // 期限切れ
+	Today       []domain.Task           // 今日が期限
+	ThisWeek    []domain.Task           // 今週中（明日〜日曜日）が期限
+	Later       []domain.Task           // 来週以降が期限
+	NoDueDate   []domain.Task           // 期限なし
+	Events      []*domain.CalendarEvent // 今日のカレンダー予定
+	ActiveTimer *domain.TimerSession    // 実行中のタイマー（なければnil）
+}
+
+// MyWorkService 個人ダッシュボードのビジネスロジックを管理するインターフェース
+type MyWorkService interface {
+	GetMyWork(userID uuid.UUID, now time.Time) (*MyWork, error)
+}
+
+// myWorkService MyWorkServiceの実装
+type myWorkService struct {
+	taskRepo          repository.TaskRepository
+	boardRepo         repository.BoardRepository
+	calendarEventRepo repository.CalendarEventRepository
+	timerSessionRepo  repository.TimerSessionRepository
+}
+
+// NewMyWorkService MyWorkServiceの新しいインスタンスを作成
+func NewMyWorkService(
+	taskRepo repository.TaskRepository,
+	boardRepo repository.BoardRepository,
+	calendarEventRepo repository.CalendarEventRepository,
+	timerSessionRepo repository.TimerSessionRepository,
+) MyWorkService {
+	return &myWorkService{
+		taskRepo:          taskRepo,
+		boardRepo:         boardRepo,
+		calendarEventRepo: calendarEventRepo,
+		timerSessionRepo:  timerSessionRepo,
+	}
+}
+
+// GetMyWork アクセス可能な全ボードから自分が担当する未完了タスクを期限ごとに分類して取得します
+// nowのタイムゾーンを基準に「今日」「今週」を判定します
+func (s *myWorkService) GetMyWork(userID uuid.UUID, now time.Time) (*MyWork, error) {
+	boards, err := s.boardRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	accessible := make(map[uint]bool, len(boards))
+	for _, board := range boards {
+		accessible[board.ID] = true
+	}
+
+	assigned, err := s.taskRepo.GetTasksByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("担当タスク取得エラー: %w", err)
+	}
+
+	// 完了済みのタスクと、アクセスできないボードのタスクは除外
+	var tasks []domain.Task
+	for _, task := range assigned {
+		if task.IsCompleted || !accessible[task.Column.BoardID] {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	work := groupMyWorkTasks(tasks, now)
+
+	// 今日のカレンダー予定
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	work.Events, err = s.calendarEventRepo.GetByUserIDAndDateRange(userID, startOfDay, startOfDay.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("カレンダー予定取得エラー: %w", err)
+	}
+
+	// 実行中のタイマー
+	activeTimer, err := s.timerSessionRepo.GetActiveByUserID(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("アクティブタイマー取得エラー: %w", err)
+	}
+	work.ActiveTimer = activeTimer
+
+	return work, nil
+}
+
+// groupMyWorkTasks タスクを期限日ごとのグループに分類します
+// 期限日は日付のみを表すため、保存された年月日をnowのタイムゾーンの日付として比較します
+func groupMyWorkTasks(tasks []domain.Task, now time.Time) *MyWork {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	// 週の終わり（次の月曜日0時）
+	daysUntilMonday := (8 - int(today.Weekday())) % 7
+	if daysUntilMonday == 0 {
+		daysUntilMonday = 7
+	}
+	nextWeek := today.AddDate(0, 0, daysUntilMonday)
+
+	work := &MyWork{}
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			work.NoDueDate = append(work.NoDueDate, task)
+			continue
+		}
+
+		due := task.DueDate.UTC()
+		dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, loc)
+		switch {
+		case dueDay.Before(today):
+			work.Overdue = append(work.Overdue, task)
+		case dueDay.Before(tomorrow):
+			work.Today = append(work.Today, task)
+		case dueDay.Before(nextWeek):
+			work.ThisWeek = append(work.ThisWeek, task)
+		default:
+			work.Later = append(work.Later, task)
+		}
+	}
+	return work
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// groupMyWorkTasksの期限日による分類テスト
+func TestGroupMyWorkTasks(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// 2025-01-15（水）の朝（UTCではまだ前日）
+	now := time.Date(2025, 1, 15, 8, 0, 0, 0, jst)
+
+	date := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+
+	tasks := []domain.Task{
+		{ID: 1, DueDate: date("2025-01-14")},
+		{ID: 2, DueDate: date("2025-01-15")},
+		{ID: 3, DueDate: date("2025-01-16")},
+		{ID: 4, DueDate: date("2025-01-19")},
+		{ID: 5, DueDate: date("2025-01-20")},
+		{ID: 6},
+	}
+
+	work := groupMyWorkTasks(tasks, now)
+
+	ids := func(tasks []domain.Task) []uint {
+		var result []uint
+		for _, task := range tasks {
+			result = append(result, task.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []uint{1}, ids(work.Overdue))
+	assert.Equal(t, []uint{2}, ids(work.Today))
+	assert.Equal(t, []uint{3, 4}, ids(work.ThisWeek))
+	assert.Equal(t, []uint{5}, ids(work.Later))
+	assert.Equal(t, []uint{6}, ids(work.NoDueDate))
+}
@@ -0,0 +1,511 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"simple-kanban/config"
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// IDプロバイダーへのリクエストのタイムアウト
+const oidcRequestTimeout = 10 * time.Second
+
+// IDプロバイダーの設定と公開鍵（JWKS）を再利用する期間
+const oidcProviderCacheTTL = time.Hour
+
+// 署名の公開鍵が見つからない場合に公開鍵を取得し直す最短の間隔（鍵のローテーションへの対応）
+const oidcJWKSRefreshInterval = time.Minute
+
+// ErrOIDCDisabled OpenID Connectによるログインが設定されていない場合のエラー
+var ErrOIDCDisabled = errors.New("OpenID Connectによるログインは設定されていません")
+
+// ErrInvalidOIDCLoginCode ログインのコードが無効か、有効期限が切れている場合のエラー
+var ErrInvalidOIDCLoginCode = errors.New("ログインのコードが無効か、有効期限が切れています。もう一度ログインしてください")
+
+// OIDCAuthorization ログインの開始時にブラウザに返す内容
+type OIDCAuthorization struct {
+	URL          string // IDプロバイダーの認可エンドポイントのURL
+	State        string // ブラウザのCookieに保存し、コールバックで照合するstate
+	SecureCookie bool   // CookieをHTTPSでのみ送信するかどうか（コールバックのURLがhttpsの場合）
+}
+
+// OIDCService OpenID Connect（認可コードフロー + PKCE）によるログインを管理するインターフェース
+type OIDCService interface {
+	Authorize() (*OIDCAuthorization, error)
+	Login(code, state string) (string, error)
+	ExchangeLoginCode(loginCode string) (*domain.User, string, error)
+	FrontendCallbackURL(query url.Values) string
+}
+
+// oidcService OIDCServiceの実装
+type oidcService struct {
+	cfg       *config.Config
+	userRepo  repository.UserRepository
+	oidcRepo  repository.OIDCRepository
+	tokenRepo repository.AccountTokenRepository
+	client    *http.Client
+
+	mu                sync.Mutex
+	provider          *oidcProvider // 取得済みのIDプロバイダーの設定
+	providerFetchedAt time.Time
+	jwks              []jsonWebKey // 取得済みのIDプロバイダーの公開鍵
+	jwksFetchedAt     time.Time
+}
+
+// oidcProvider IDプロバイダーの設定（/.well-known/openid-configuration）
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcIDTokenClaims IDトークンのクレーム
+type oidcIDTokenClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	jwt.RegisteredClaims
+}
+
+// NewOIDCService OIDCServiceの新しいインスタンスを作成
+// clientがnilの場合はタイムアウト付きの既定のクライアントを使用します
+func NewOIDCService(cfg *config.Config, userRepo repository.UserRepository, oidcRepo repository.OIDCRepository, tokenRepo repository.AccountTokenRepository, client *http.Client) OIDCService {
+	if client == nil {
+		client = &http.Client{Timeout: oidcRequestTimeout}
+	}
+	return &oidcService{
+		cfg:       cfg,
+		userRepo:  userRepo,
+		oidcRepo:  oidcRepo,
+		tokenRepo: tokenRepo,
+		client:    client,
+	}
+}
+
+// Authorize ログインを開始し、IDプロバイダーの認可エンドポイントのURLと、ブラウザに保存するstateを返します
+func (s *oidcService) Authorize() (*OIDCAuthorization, error) {
+	if !s.cfg.OIDC.Enabled() {
+		return nil, ErrOIDCDisabled
+	}
+	provider, err := s.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomURLToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomURLToken()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomURLToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.oidcRepo.DeleteExpiredLoginStates(now); err != nil {
+		log.Printf("期限切れのログイン状態の削除に失敗しました: %v", err)
+	}
+	if err := s.oidcRepo.CreateLoginState(&domain.OIDCLoginState{
+		State:        state,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    now.Add(domain.OIDCLoginStateTTL),
+	}); err != nil {
+		return nil, fmt.Errorf("ログイン状態の保存エラー: %w", err)
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {s.cfg.OIDC.ClientID},
+		"redirect_uri":          {s.cfg.OIDC.RedirectURL},
+		"scope":                 {s.cfg.OIDC.Scopes},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {domain.PKCEChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	separator := "?"
+	if strings.Contains(provider.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return &OIDCAuthorization{
+		URL:          provider.AuthorizationEndpoint + separator + query.Encode(),
+		State:        state,
+		SecureCookie: strings.HasPrefix(s.cfg.OIDC.RedirectURL, "https://"),
+	}, nil
+}
+
+// Login 認可コードをIDトークンと交換してユーザーを特定し、フロントエンドに渡す1回限りのログインのコードを返します
+// JWTトークンはURLに含めず、フロントエンドがExchangeLoginCodeでコードと交換します
+// 初めてのログインでは、同じメールアドレスの確認済みのユーザーに紐付けるか、新しいユーザーを作成します
+func (s *oidcService) Login(code, state string) (string, error) {
+	if !s.cfg.OIDC.Enabled() {
+		return "", ErrOIDCDisabled
+	}
+
+	loginState, err := s.oidcRepo.ConsumeLoginState(state)
+	if err != nil {
+		return "", fmt.Errorf("ログイン状態の取得エラー: %w", err)
+	}
+	if loginState == nil || loginState.IsExpired(time.Now()) {
+		return "", errors.New("ログインの有効期限が切れました。もう一度ログインしてください")
+	}
+
+	provider, err := s.discover()
+	if err != nil {
+		return "", err
+	}
+	rawIDToken, err := s.exchangeCode(provider, code, loginState.CodeVerifier)
+	if err != nil {
+		return "", err
+	}
+	claims, err := s.verifyIDToken(provider, rawIDToken, loginState.Nonce)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := s.findOrProvisionUser(provider.Issuer, claims)
+	if err != nil {
+		return "", err
+	}
+
+	loginCode, err := randomURLToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	if err := s.tokenRepo.DeleteExpired(now); err != nil {
+		log.Printf("期限切れのトークンの削除に失敗しました: %v", err)
+	}
+	if err := s.tokenRepo.Create(&domain.AccountToken{
+		UserID:    user.ID,
+		Purpose:   domain.AccountTokenOIDCLogin,
+		TokenHash: domain.HashAccountToken(loginCode),
+		Email:     user.Email,
+		ExpiresAt: now.Add(domain.OIDCLoginCodeTTL),
+	}); err != nil {
+		return "", fmt.Errorf("ログインのコードの保存エラー: %w", err)
+	}
+	return loginCode, nil
+}
+
+// ExchangeLoginCode コールバックで渡したログインのコードをJWTトークンと交換します（同じコードは1回のみ使用できる）
+// 二要素認証が有効なユーザーの場合は*TwoFactorRequiredErrorを返します
+func (s *oidcService) ExchangeLoginCode(loginCode string) (*domain.User, string, error) {
+	if !s.cfg.OIDC.Enabled() {
+		return nil, "", ErrOIDCDisabled
+	}
+
+	accountToken, err := s.tokenRepo.Consume(domain.AccountTokenOIDCLogin, domain.HashAccountToken(loginCode), time.Now())
+	if err != nil {
+		return nil, "", fmt.Errorf("ログインのコードの取得エラー: %w", err)
+	}
+	if accountToken == nil {
+		return nil, "", ErrInvalidOIDCLoginCode
+	}
+	user, err := s.userRepo.GetByID(accountToken.UserID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil {
+		return nil, "", ErrInvalidOIDCLoginCode
+	}
+
+	// パスワードでのログインと同じく、二要素認証が有効な場合はコードの入力を待つトークンを返す
+	if user.TwoFactorEnabled() {
+		challengeToken, err := middleware.GenerateChallengeToken(user.ID, user.Email, TwoFactorChallengeTTL, s.cfg)
+		if err != nil {
+			return nil, "", fmt.Errorf("トークン生成エラー: %w", err)
+		}
+		return user, "", &TwoFactorRequiredError{ChallengeToken: challengeToken}
+	}
+
+	// JWTトークンを生成
+	token, err := middleware.GenerateToken(user.ID, user.Email, user.TokenVersion, s.cfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("トークン生成エラー: %w", err)
+	}
+	return user, token, nil
+}
+
+// findOrProvisionUser IDトークンのクレームに対応するユーザーを取得、紐付け、または作成します
+func (s *oidcService) findOrProvisionUser(issuer string, claims *oidcIDTokenClaims) (*domain.User, error) {
+	identity, err := s.oidcRepo.GetIdentity(issuer, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("アカウントの紐付け取得エラー: %w", err)
+	}
+	if identity != nil {
+		user, err := s.userRepo.GetByID(identity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("ユーザー取得エラー: %w", err)
+		}
+		if user == nil {
+			return nil, errors.New("ユーザーが見つかりません")
+		}
+		return user, nil
+	}
+
+	if claims.Email == "" {
+		return nil, errors.New("IDプロバイダーからメールアドレスが提供されていません")
+	}
+	user, err := s.userRepo.GetByEmail(claims.Email)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー検索エラー: %w", err)
+	}
+	if user != nil {
+		// 確認されていないメールアドレスで既存のアカウントを乗っ取られないようにする
+		if !claims.EmailVerified {
+			return nil, errors.New("メールアドレスが確認されていないため、既存のアカウントに紐付けできません")
+		}
+	} else {
+		// パスワードを持たないユーザーとして作成する（パスワードでのログインは不可）
+		user = &domain.User{
+			ID:       uuid.New(),
+			Email:    claims.Email,
+			TimeZone: domain.DefaultUserTimeZone,
+			Locale:   domain.DefaultUserLocale,
+		}
+		if claims.EmailVerified {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+		}
+		if err := s.userRepo.Create(user); err != nil {
+			return nil, fmt.Errorf("ユーザー作成エラー: %w", err)
+		}
+	}
+
+	if err := s.oidcRepo.CreateIdentity(&domain.UserIdentity{
+		UserID:  user.ID,
+		Issuer:  issuer,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+	}); err != nil {
+		return nil, fmt.Errorf("アカウントの紐付けエラー: %w", err)
+	}
+	return user, nil
+}
+
+// FrontendCallbackURL ログインの結果を渡すフロントエンドのページ（/oidc/callback）のURLを返します
+func (s *oidcService) FrontendCallbackURL(query url.Values) string {
+	return strings.TrimSuffix(s.cfg.Mail.AppBaseURL, "/") + "/oidc/callback?" + query.Encode()
+}
+
+// discover IDプロバイダーの設定を取得します（取得した設定はoidcProviderCacheTTLの間再利用する）
+func (s *oidcService) discover() (*oidcProvider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.provider != nil && time.Since(s.providerFetchedAt) < oidcProviderCacheTTL {
+		return s.provider, nil
+	}
+
+	issuer := strings.TrimSuffix(s.cfg.OIDC.IssuerURL, "/")
+	var provider oidcProvider
+	if err := s.getJSON(issuer+"/.well-known/openid-configuration", &provider); err != nil {
+		return nil, fmt.Errorf("IDプロバイダーの設定取得エラー: %w", err)
+	}
+	if strings.TrimSuffix(provider.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("IDプロバイダーのIssuerが設定と一致しません: %s", provider.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, errors.New("IDプロバイダーの設定にエンドポイントが含まれていません")
+	}
+
+	// エンドポイントが変わった場合に古い公開鍵を使わないよう、公開鍵も取得し直す
+	if s.provider == nil || s.provider.JWKSURI != provider.JWKSURI {
+		s.jwks = nil
+	}
+	s.provider = &provider
+	s.providerFetchedAt = time.Now()
+	return s.provider, nil
+}
+
+// signingKey IDトークンの署名の公開鍵を返します
+// 取得した公開鍵はoidcProviderCacheTTLの間再利用し、kidが見つからない場合は鍵のローテーションとみなして取得し直します
+func (s *oidcService) signingKey(provider *oidcProvider, kid string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stale := s.jwks == nil || time.Since(s.jwksFetchedAt) >= oidcProviderCacheTTL
+	key := findJSONWebKey(s.jwks, kid)
+	if stale || (key == nil && time.Since(s.jwksFetchedAt) >= oidcJWKSRefreshInterval) {
+		var jwks struct {
+			Keys []jsonWebKey `json:"keys"`
+		}
+		if err := s.getJSON(provider.JWKSURI, &jwks); err != nil {
+			return nil, fmt.Errorf("IDプロバイダーの公開鍵の取得エラー: %w", err)
+		}
+		s.jwks = jwks.Keys
+		s.jwksFetchedAt = time.Now()
+		key = findJSONWebKey(s.jwks, kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("署名の公開鍵が見つかりません: %s", kid)
+	}
+	return key.publicKey()
+}
+
+// findJSONWebKey kidに一致する公開鍵を返します（kidがなく公開鍵が1つだけの場合はその公開鍵）
+func findJSONWebKey(keys []jsonWebKey, kid string) *jsonWebKey {
+	for i := range keys {
+		if keys[i].Kid == kid || (kid == "" && len(keys) == 1) {
+			return &keys[i]
+		}
+	}
+	return nil
+}
+
+// exchangeCode 認可コードとPKCEのコード検証値をトークンエンドポイントに送信し、IDトークンを取得します
+func (s *oidcService) exchangeCode(provider *oidcProvider, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {s.cfg.OIDC.RedirectURL},
+		"client_id":     {s.cfg.OIDC.ClientID},
+		"code_verifier": {verifier},
+	}
+	if s.cfg.OIDC.ClientSecret != "" {
+		form.Set("client_secret", s.cfg.OIDC.ClientSecret)
+	}
+
+	resp, err := s.client.PostForm(provider.TokenEndpoint, form)
+	if err != nil {
+		return "", fmt.Errorf("トークンエンドポイントへの送信エラー: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("トークンエンドポイントの応答を解析できません: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return "", fmt.Errorf("認可コードを交換できませんでした: %s %s", body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", errors.New("トークンエンドポイントの応答にIDトークンが含まれていません")
+	}
+	return body.IDToken, nil
+}
+
+// verifyIDToken IDトークンの署名・Issuer・Audience・有効期限・nonceを検証します
+func (s *oidcService) verifyIDToken(provider *oidcProvider, rawIDToken, nonce string) (*oidcIDTokenClaims, error) {
+	claims := &oidcIDTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return s.signingKey(provider, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(provider.Issuer),
+		jwt.WithAudience(s.cfg.OIDC.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("IDトークンの検証エラー: %w", err)
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("IDトークンのnonceが一致しません")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("IDトークンにユーザーID（sub）が含まれていません")
+	}
+	return claims, nil
+}
+
+// getJSON URLからJSONを取得します
+func (s *oidcService) getJSON(url string, v interface{}) error {
+	resp, err := s.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTPステータス %d が返されました", resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// jsonWebKey IDプロバイダーの公開鍵（JWK）
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey JWKをIDトークンの署名の検証に使用する公開鍵に変換します（RSAと楕円曲線に対応）
+func (k *jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵を解析できません: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵を解析できません: %w", err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("対応していない楕円曲線です: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵を解析できません: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵を解析できません: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("対応していない公開鍵の種類です: %s", k.Kty)
+}
+
+// randomURLToken URLに含められるランダムな文字列（32バイト）を生成します
+func randomURLToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("乱数生成エラー: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"simple-kanban/config"
+	"simple-kanban/internal/domain"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOIDCRepository テスト用のメモリ上のOIDCRepository
+type memoryOIDCRepository struct {
+	states     map[string]domain.OIDCLoginState
+	identities []domain.UserIdentity
+}
+
+func (r *memoryOIDCRepository) CreateLoginState(state *domain.OIDCLoginState) error {
+	r.states[state.State] = *state
+	return nil
+}
+
+func (r *memoryOIDCRepository) ConsumeLoginState(state string) (*domain.OIDCLoginState, error) {
+	loginState, ok := r.states[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.states, state)
+	return &loginState, nil
+}
+
+func (r *memoryOIDCRepository) DeleteExpiredLoginStates(now time.Time) error {
+	for key, state := range r.states {
+		if state.IsExpired(now) {
+			delete(r.states, key)
+		}
+	}
+	return nil
+}
+
+func (r *memoryOIDCRepository) GetIdentity(issuer, subject string) (*domain.UserIdentity, error) {
+	for i := range r.identities {
+		if r.identities[i].Issuer == issuer && r.identities[i].Subject == subject {
+			return &r.identities[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryOIDCRepository) CreateIdentity(identity *domain.UserIdentity) error {
+	r.identities = append(r.identities, *identity)
+	return nil
+}
+
+// mockOIDCProvider テスト用のOpenID Connectのプロバイダー
+// 認可エンドポイントの代わりにauthorizeで認可コードを発行し、トークンエンドポイントでPKCEを検証します
+type mockOIDCProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+
+	mu          sync.Mutex
+	codes       map[string]mockOIDCGrant
+	discoveries int // 設定を取得した回数
+	jwksFetches int // 公開鍵を取得した回数
+}
+
+// mockOIDCGrant 発行した認可コードに対応する内容
+type mockOIDCGrant struct {
+	challenge     string
+	nonce         string
+	subject       string
+	email         string
+	emailVerified bool
+}
+
+func newMockOIDCProvider(t *testing.T, clientID string) *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &mockOIDCProvider{key: key, clientID: clientID, codes: make(map[string]mockOIDCGrant)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		p.discoveries++
+		p.mu.Unlock()
+		writeTestJSON(w, http.StatusOK, map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		p.jwksFetches++
+		p.mu.Unlock()
+		writeTestJSON(w, http.StatusOK, map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		grant, ok := p.codes[r.PostFormValue("code")]
+		delete(p.codes, r.PostFormValue("code"))
+		p.mu.Unlock()
+
+		if !ok || r.PostFormValue("client_id") != p.clientID || r.PostFormValue("grant_type") != "authorization_code" {
+			writeTestJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		if domain.PKCEChallenge(r.PostFormValue("code_verifier")) != grant.challenge {
+			writeTestJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, &oidcIDTokenClaims{
+			Nonce:         grant.nonce,
+			Email:         grant.email,
+			EmailVerified: grant.emailVerified,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    p.server.URL,
+				Subject:   grant.subject,
+				Audience:  jwt.ClaimStrings{p.clientID},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+				IssuedAt:  jwt.NewNumericDate(time.Now()),
+			},
+		})
+		token.Header["kid"] = "test-key"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			writeTestJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+			return
+		}
+		writeTestJSON(w, http.StatusOK, map[string]string{"access_token": "at", "token_type": "Bearer", "id_token": idToken})
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+// authorize 利用者がIDプロバイダーでログインしたものとして、認可URLに対する認可コードとstateを返します
+func (p *mockOIDCProvider) authorize(t *testing.T, authorization *OIDCAuthorization, subject, email string, emailVerified bool) (string, string) {
+	u, err := url.Parse(authorization.URL)
+	require.NoError(t, err)
+	query := u.Query()
+	require.Equal(t, authorization.State, query.Get("state"))
+	require.Equal(t, "code", query.Get("response_type"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	require.Equal(t, p.clientID, query.Get("client_id"))
+
+	code := uuid.NewString()
+	p.mu.Lock()
+	p.codes[code] = mockOIDCGrant{
+		challenge:     query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		subject:       subject,
+		email:         email,
+		emailVerified: emailVerified,
+	}
+	p.mu.Unlock()
+	return code, query.Get("state")
+}
+
+func writeTestJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// 初回ログインでのユーザー作成、2回目以降のログイン、既存ユーザーへの紐付けのテスト
+func TestOIDCService_Login(t *testing.T) {
+	provider := newMockOIDCProvider(t, "kanban")
+	defer provider.server.Close()
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{SecretKey: "test-secret", ExpireHours: 1},
+		OIDC: config.OIDCConfig{
+			IssuerURL:   provider.server.URL,
+			ClientID:    "kanban",
+			RedirectURL: "http://localhost:8080/api/v1/auth/oidc/callback",
+			Scopes:      "openid email",
+		},
+	}
+	existing := &domain.User{ID: uuid.New(), Email: "bob@example.com", PasswordHash: "hash"}
+	userRepo := &memoryUserRepository{users: map[uuid.UUID]*domain.User{existing.ID: existing}}
+	oidcRepo := &memoryOIDCRepository{states: make(map[string]domain.OIDCLoginState)}
+	svc := NewOIDCService(cfg, userRepo, oidcRepo, &memoryAccountTokenRepository{}, provider.server.Client())
+
+	login := func(subject, email string, emailVerified bool) (*domain.User, string, error) {
+		authorization, err := svc.Authorize()
+		require.NoError(t, err)
+		code, state := provider.authorize(t, authorization, subject, email, emailVerified)
+		loginCode, err := svc.Login(code, state)
+		if err != nil {
+			return nil, "", err
+		}
+		return svc.ExchangeLoginCode(loginCode)
+	}
+
+	// 初回ログインでユーザーを作成する
+	alice, token, err := login("sub-alice", "alice@example.com", true)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", alice.Email)
+	claims, err := middleware.ValidateToken(token, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, claims.UserID)
+
+	// 2回目以降は紐付けたユーザーでログインする（メールアドレスが変わっても同じユーザー）
+	again, _, err := login("sub-alice", "alice@new.example.com", true)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, again.ID)
+	assert.Len(t, userRepo.users, 2)
+
+	// 確認されていないメールアドレスでは既存のユーザーに紐付けない
+	_, _, err = login("sub-bob", "bob@example.com", false)
+	assert.Error(t, err)
+
+	// 確認済みのメールアドレスで既存のユーザーに紐付ける
+	bob, _, err := login("sub-bob", "bob@example.com", true)
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, bob.ID)
+	assert.Len(t, userRepo.users, 2)
+	assert.Len(t, oidcRepo.identities, 2)
+
+	// IDプロバイダーの設定と公開鍵はログインのたびに取得しない
+	assert.Equal(t, 1, provider.discoveries)
+	assert.Equal(t, 1, provider.jwksFetches)
+}
+
+// stateの再利用とPKCEのコード検証値の不一致を拒否することのテスト
+func TestOIDCService_RejectsReplayAndPKCEMismatch(t *testing.T) {
+	provider := newMockOIDCProvider(t, "kanban")
+	defer provider.server.Close()
+
+	cfg := &config.Config{
+		JWT:  config.JWTConfig{SecretKey: "test-secret", ExpireHours: 1},
+		OIDC: config.OIDCConfig{IssuerURL: provider.server.URL, ClientID: "kanban", Scopes: "openid email"},
+	}
+	oidcRepo := &memoryOIDCRepository{states: make(map[string]domain.OIDCLoginState)}
+	svc := NewOIDCService(cfg, &memoryUserRepository{users: make(map[uuid.UUID]*domain.User)}, oidcRepo, &memoryAccountTokenRepository{}, provider.server.Client())
+
+	authorization, err := svc.Authorize()
+	require.NoError(t, err)
+	code, state := provider.authorize(t, authorization, "sub-alice", "alice@example.com", true)
+	loginCode, err := svc.Login(code, state)
+	require.NoError(t, err)
+
+	// ログインのコードは1回のみJWTトークンと交換できる
+	_, token, err := svc.ExchangeLoginCode(loginCode)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	_, _, err = svc.ExchangeLoginCode(loginCode)
+	assert.ErrorIs(t, err, ErrInvalidOIDCLoginCode)
+
+	// 同じstateは2回使用できない
+	code, _ = provider.authorize(t, authorization, "sub-alice", "alice@example.com", true)
+	_, err = svc.Login(code, state)
+	assert.Error(t, err)
+
+	// 保存したコード検証値と異なるチャレンジで発行された認可コードは交換できない
+	authorization, err = svc.Authorize()
+	require.NoError(t, err)
+	code, state = provider.authorize(t, authorization, "sub-alice", "alice@example.com", true)
+	provider.codes[code] = mockOIDCGrant{challenge: domain.PKCEChallenge("other"), nonce: "n", subject: "sub-alice"}
+	_, err = svc.Login(code, state)
+	assert.Error(t, err)
+
+	// 設定がない場合は無効
+	disabled := NewOIDCService(&config.Config{}, nil, nil, nil, nil)
+	_, err = disabled.Authorize()
+	assert.ErrorIs(t, err, ErrOIDCDisabled)
+}
+
+// 二要素認証が有効なユーザーは、IDプロバイダーでのログインでもコードの入力が必要なことのテスト
+func TestOIDCService_LoginRequiresTwoFactor(t *testing.T) {
+	provider := newMockOIDCProvider(t, "kanban")
+	defer provider.server.Close()
+
+	cfg := &config.Config{
+		JWT:  config.JWTConfig{SecretKey: "test-secret", ExpireHours: 1},
+		OIDC: config.OIDCConfig{IssuerURL: provider.server.URL, ClientID: "kanban", Scopes: "openid email"},
+	}
+	now := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "alice@example.com", TOTPSecret: "secret", TOTPEnabledAt: &now}
+	oidcRepo := &memoryOIDCRepository{
+		states:     make(map[string]domain.OIDCLoginState),
+		identities: []domain.UserIdentity{{UserID: user.ID, Issuer: provider.server.URL, Subject: "sub-alice"}},
+	}
+	svc := NewOIDCService(cfg, &memoryUserRepository{users: map[uuid.UUID]*domain.User{user.ID: user}}, oidcRepo, &memoryAccountTokenRepository{}, provider.server.Client())
+
+	authorization, err := svc.Authorize()
+	require.NoError(t, err)
+	code, state := provider.authorize(t, authorization, "sub-alice", "alice@example.com", true)
+	loginCode, err := svc.Login(code, state)
+	require.NoError(t, err)
+	_, token, err := svc.ExchangeLoginCode(loginCode)
+
+	var challenge *TwoFactorRequiredError
+	require.ErrorAs(t, err, &challenge)
+	assert.Empty(t, token, "コードの確認前にJWTトークンを発行しない")
+	claims, err := middleware.ValidateChallengeToken(challenge.ChallengeToken, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+	_, err = middleware.ValidateToken(challenge.ChallengeToken, cfg)
+	assert.Error(t, err, "チャレンジトークンは通常のAPIには使用できない")
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/google/uuid"
+)
+
+// 最終使用日時を更新する間隔（リクエストのたびに書き込まないようにする）
+const tokenLastUsedInterval = time.Minute
+
+// tokenPrefixLength 一覧でトークンを見分けるために保存する先頭部分の長さ（接頭辞を除く）
+const tokenPrefixLength = 8
+
+// tokenRouteResource パスパラメータから対象のボードを特定できるルート
+type tokenRouteResource struct {
+	route    string // ルートのパターン（このパターンで始まるルートが対象）
+	param    string
+	resource repository.BoardResource
+}
+
+// tokenRouteResources ボードに属するリソースを操作するルートの一覧
+// ここにないルートはボードに属さないAPIとして、すべてのボードを対象とするスコープが必要です
+var tokenRouteResources = []tokenRouteResource{
+	{"/api/v1/boards/:id", "id", repository.BoardResourceBoard},
+	{"/api/v1/tasks/:id", "id", repository.BoardResourceTask},
+	{"/api/v1/columns/:columnId", "columnId", repository.BoardResourceColumn},
+	{"/api/v1/labels/:id", "id", repository.BoardResourceLabel},
+	{"/api/v1/lanes/:id", "id", repository.BoardResourceLane},
+	{"/api/v1/custom-fields/:id", "id", repository.BoardResourceCustomField},
+	{"/api/v1/webhooks/:id", "id", repository.BoardResourceWebhook},
+	{"/api/v1/automation-rules/:id", "id", repository.BoardResourceAutomationRule},
+	{"/api/v1/trash/boards/:id", "id", repository.BoardResourceBoard},
+	{"/api/v1/trash/columns/:id", "id", repository.BoardResourceColumn},
+	{"/api/v1/trash/tasks/:id", "id", repository.BoardResourceTask},
+	{"/api/v1/calendar/tasks/:taskId", "taskId", repository.BoardResourceTask},
+	{"/api/v1/timer/tasks/:taskId", "taskId", repository.BoardResourceTask},
+}
+
+// tokenBodyColumnRoutes リクエストボディのcolumn_idのカラムにタスクを追加するルート（カラムのボードのスコープも必要）
+var tokenBodyColumnRoutes = map[string]bool{
+	http.MethodPost + " /api/v1/tasks":                   true,
+	http.MethodPost + " /api/v1/tasks/:id/move-to-board": true,
+}
+
+// tokenForbiddenRoutes トークン自体の管理や二要素認証・パスワード・メールアドレスの変更、アカウントのエクスポート・削除など、トークンでの利用は許可しないAPI
+var tokenForbiddenRoutes = []string{
+	"/api/v1/tokens",
+	"/api/v1/auth/2fa",
+	"/api/v1/auth/password",
+	"/api/v1/auth/email",
+	"/api/v1/account",
+}
+
+// PersonalAccessTokenService パーソナルアクセストークンの発行・失効・検証を管理するインターフェース
+type PersonalAccessTokenService interface {
+	middleware.TokenAuthenticator
+	CreateToken(userID uuid.UUID, name string, scopes domain.TokenScopes, expiresAt *time.Time) (*domain.PersonalAccessToken, string, error)
+	ListTokens(userID uuid.UUID) ([]domain.PersonalAccessToken, error)
+	RevokeToken(tokenID uint, userID uuid.UUID) error
+}
+
+// personalAccessTokenService PersonalAccessTokenServiceの実装
+type personalAccessTokenService struct {
+	tokenRepo repository.PersonalAccessTokenRepository
+	userRepo  repository.UserRepository
+	boardRepo repository.BoardRepository
+}
+
+// NewPersonalAccessTokenService PersonalAccessTokenServiceの新しいインスタンスを作成
+func NewPersonalAccessTokenService(tokenRepo repository.PersonalAccessTokenRepository, userRepo repository.UserRepository, boardRepo repository.BoardRepository) PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+		boardRepo: boardRepo,
+	}
+}
+
+// CreateToken トークンを発行します
+// トークンそのものは保存しないため、戻り値のトークンはこの時点でのみ取得できます
+func (s *personalAccessTokenService) CreateToken(userID uuid.UUID, name string, scopes domain.TokenScopes, expiresAt *time.Time) (*domain.PersonalAccessToken, string, error) {
+	if err := scopes.Validate(); err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", errors.New("有効期限には未来の日時を指定してください")
+	}
+
+	// アクセスできるボードのみスコープに指定できる
+	for _, scope := range scopes {
+		if scope.BoardID == nil {
+			continue
+		}
+		board, err := s.boardRepo.GetByID(*scope.BoardID)
+		if err != nil {
+			return nil, "", fmt.Errorf("ボード取得エラー: %w", err)
+		}
+		if board == nil {
+			return nil, "", fmt.Errorf("ボードが見つかりません: %d", *scope.BoardID)
+		}
+		member, err := isBoardMember(s.boardRepo, board.ID, userID)
+		if err != nil {
+			return nil, "", err
+		}
+		if !member {
+			return nil, "", fmt.Errorf("ボードが見つかりません: %d", *scope.BoardID)
+		}
+	}
+
+	secret, err := generatePersonalAccessToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := &domain.PersonalAccessToken{
+		UserID:      userID,
+		Name:        name,
+		TokenHash:   domain.HashPersonalAccessToken(secret),
+		TokenPrefix: secret[:len(middleware.PersonalAccessTokenPrefix)+tokenPrefixLength],
+		Scopes:      scopes,
+		ExpiresAt:   expiresAt,
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return nil, "", fmt.Errorf("トークン作成エラー: %w", err)
+	}
+	return token, secret, nil
+}
+
+// ListTokens ユーザーのトークン一覧を取得します（トークンそのものは含まない）
+func (s *personalAccessTokenService) ListTokens(userID uuid.UUID) ([]domain.PersonalAccessToken, error) {
+	tokens, err := s.tokenRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("トークン取得エラー: %w", err)
+	}
+	return tokens, nil
+}
+
+// RevokeToken トークンを失効させます
+func (s *personalAccessTokenService) RevokeToken(tokenID uint, userID uuid.UUID) error {
+	token, err := s.tokenRepo.GetByID(tokenID)
+	if err != nil {
+		return fmt.Errorf("トークン取得エラー: %w", err)
+	}
+	if token == nil || token.UserID != userID {
+		return errors.New("トークンが見つかりません")
+	}
+
+	if err := s.tokenRepo.Delete(tokenID); err != nil {
+		return fmt.Errorf("トークン削除エラー: %w", err)
+	}
+	return nil
+}
+
+// AuthenticateToken トークンを検証し、スコープでリクエストが許可されるかをチェックします
+// 許可された場合はトークンの所有者のユーザーIDとメールアドレスを返します
+func (s *personalAccessTokenService) AuthenticateToken(secret string, access middleware.TokenAccess) (uuid.UUID, string, error) {
+	now := time.Now()
+	token, err := s.tokenRepo.GetByHash(domain.HashPersonalAccessToken(secret))
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("トークン取得エラー: %w", err)
+	}
+	if token == nil || token.IsExpired(now) {
+		return uuid.Nil, "", errors.New("無効なトークンです")
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil {
+		return uuid.Nil, "", errors.New("無効なトークンです")
+	}
+
+	if err := s.checkScopes(token.Scopes, access); err != nil {
+		return uuid.Nil, "", err
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenLastUsedInterval {
+		if err := s.tokenRepo.UpdateLastUsed(token.ID, now); err != nil {
+			log.Printf("トークンの最終使用日時の更新に失敗しました: %v", err)
+		}
+	}
+	return user.ID, user.Email, nil
+}
+
+// checkScopes リクエストの対象のボードと操作がスコープで許可されるかをチェックします
+// 参照（GET / HEAD）はread以上、それ以外はwriteの権限が必要です
+func (s *personalAccessTokenService) checkScopes(scopes domain.TokenScopes, access middleware.TokenAccess) error {
+	for _, route := range tokenForbiddenRoutes {
+		if access.Route == route || strings.HasPrefix(access.Route, route+"/") {
+			return middleware.ErrTokenForbidden
+		}
+	}
+	write := access.Method != http.MethodGet && access.Method != http.MethodHead
+
+	// すべてのボードを対象とするスコープで許可される場合はボードを特定する必要がない
+	if scopes.Allows(nil, write) {
+		return nil
+	}
+
+	boardID, err := s.resolveRouteBoard(access)
+	if err != nil {
+		return err
+	}
+
+	// タスクを追加するルートでは、追加先のカラムのボードが許可されている必要がある
+	bodyColumn := tokenBodyColumnRoutes[access.Method+" "+access.Route]
+	if boardID != nil || !bodyColumn {
+		if !scopes.Allows(boardID, write) {
+			return middleware.ErrTokenForbidden
+		}
+	}
+	if bodyColumn {
+		var body struct {
+			ColumnID uint `json:"column_id"`
+		}
+		if err := json.Unmarshal(access.Body(), &body); err != nil || body.ColumnID == 0 {
+			// 追加先のカラムを特定できない（ボディが大きすぎる・不正な）場合は許可しない
+			return middleware.ErrTokenForbidden
+		}
+		columnBoardID, err := s.tokenRepo.GetResourceBoardID(repository.BoardResourceColumn, body.ColumnID)
+		if err != nil {
+			return fmt.Errorf("カラム取得エラー: %w", err)
+		}
+		if columnBoardID == nil || !scopes.Allows(columnBoardID, write) {
+			return middleware.ErrTokenForbidden
+		}
+	}
+	return nil
+}
+
+// resolveRouteBoard ルートのパスパラメータから対象のボードIDを特定します
+// ボードに属さないルートの場合はnilを返します
+func (s *personalAccessTokenService) resolveRouteBoard(access middleware.TokenAccess) (*uint, error) {
+	for _, r := range tokenRouteResources {
+		if access.Route != r.route && !strings.HasPrefix(access.Route, r.route+"/") {
+			continue
+		}
+
+		id, err := strconv.ParseUint(access.Params[r.param], 10, 32)
+		if err != nil {
+			// 不正なIDはハンドラでエラーにするが、ボードを特定できないため許可はしない
+			return nil, middleware.ErrTokenForbidden
+		}
+		boardID, err := s.tokenRepo.GetResourceBoardID(r.resource, uint(id))
+		if err != nil {
+			return nil, fmt.Errorf("ボードの特定に失敗しました: %w", err)
+		}
+		if boardID == nil {
+			// 存在しないリソースはボードを特定できないため許可しない
+			return nil, middleware.ErrTokenForbidden
+		}
+		return boardID, nil
+	}
+	return nil, nil
+}
+
+// generatePersonalAccessToken ランダムなトークン（接頭辞 + 32バイトの16進数）を生成します
+func generatePersonalAccessToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("トークン生成エラー: %w", err)
+	}
+	return middleware.PersonalAccessTokenPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTokenRepository テスト用のメモリ上のPersonalAccessTokenRepository
+type memoryTokenRepository struct {
+	tokens      map[uint]*domain.PersonalAccessToken
+	taskBoards  map[uint]uint // タスクID → ボードID
+	columnBoard map[uint]uint // カラムID → ボードID
+}
+
+func (r *memoryTokenRepository) Create(token *domain.PersonalAccessToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens[token.ID] = token
+	return nil
+}
+
+func (r *memoryTokenRepository) GetByID(id uint) (*domain.PersonalAccessToken, error) {
+	return r.tokens[id], nil
+}
+
+func (r *memoryTokenRepository) GetByHash(tokenHash string) (*domain.PersonalAccessToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryTokenRepository) GetByUserID(userID uuid.UUID) ([]domain.PersonalAccessToken, error) {
+	var tokens []domain.PersonalAccessToken
+	for _, token := range r.tokens {
+		if token.UserID == userID {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *memoryTokenRepository) UpdateLastUsed(id uint, at time.Time) error {
+	r.tokens[id].LastUsedAt = &at
+	return nil
+}
+
+func (r *memoryTokenRepository) Delete(id uint) error {
+	delete(r.tokens, id)
+	return nil
+}
+
+func (r *memoryTokenRepository) GetResourceBoardID(resource repository.BoardResource, id uint) (*uint, error) {
+	var boardID uint
+	var ok bool
+	switch resource {
+	case repository.BoardResourceBoard:
+		return &id, nil
+	case repository.BoardResourceTask:
+		boardID, ok = r.taskBoards[id]
+	case repository.BoardResourceColumn:
+		boardID, ok = r.columnBoard[id]
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &boardID, nil
+}
+
+// memoryUserRepository テスト用のメモリ上のUserRepository（使用するメソッドのみ実装）
+type memoryUserRepository struct {
+	repository.UserRepository
+	users map[uuid.UUID]*domain.User
+}
+
+func (r *memoryUserRepository) Create(user *domain.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *memoryUserRepository) GetByID(id uuid.UUID) (*domain.User, error) {
+	return r.users[id], nil
+}
+
+func (r *memoryUserRepository) Update(user *domain.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *memoryUserRepository) IncrementFailedLogins(id uuid.UUID) (int, error) {
+	r.users[id].FailedLogins++
+	return r.users[id].FailedLogins, nil
+}
+
+func (r *memoryUserRepository) LockUntil(id uuid.UUID, until time.Time) error {
+	r.users[id].LockedUntil = &until
+	return nil
+}
+
+func (r *memoryUserRepository) ResetFailedLogins(id uuid.UUID) error {
+	r.users[id].FailedLogins = 0
+	r.users[id].LockedUntil = nil
+	return nil
+}
+
+func (r *memoryUserRepository) GetByEmail(email string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// memoryBoardRepository テスト用のメモリ上のBoardRepository（使用するメソッドのみ実装）
+type memoryBoardRepository struct {
+	repository.BoardRepository
+	boards map[uint]*domain.Board
+}
+
+func (r *memoryBoardRepository) GetByID(id uint) (*domain.Board, error) {
+	return r.boards[id], nil
+}
+
+// IsAccessibleBy 各ボードは作成したユーザーだけがメンバーのワークスペースにあるものとして判定します
+func (r *memoryBoardRepository) IsAccessibleBy(boardID uint, userID uuid.UUID) (bool, error) {
+	board, ok := r.boards[boardID]
+	return ok && board.OwnerID == userID, nil
+}
+
+// ボード単位のスコープと権限（read / write）によるリクエストの許可のテスト
+func TestPersonalAccessTokenService_Scopes(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Email: "cli@example.com"}
+	tokenRepo := &memoryTokenRepository{
+		tokens:      make(map[uint]*domain.PersonalAccessToken),
+		taskBoards:  map[uint]uint{10: 1, 20: 2},
+		columnBoard: map[uint]uint{100: 1, 200: 2},
+	}
+	boardRepo := &memoryBoardRepository{boards: map[uint]*domain.Board{
+		1: {ID: 1, OwnerID: user.ID},
+		2: {ID: 2, OwnerID: user.ID},
+		3: {ID: 3, OwnerID: uuid.New()},
+	}}
+	svc := NewPersonalAccessTokenService(tokenRepo, &memoryUserRepository{users: map[uuid.UUID]*domain.User{user.ID: user}}, boardRepo)
+
+	board1, board2, board3 := uint(1), uint(2), uint(3)
+	_, _, err := svc.CreateToken(user.ID, "他人のボード", domain.TokenScopes{{BoardID: &board3, Access: domain.TokenAccessRead}}, nil)
+	assert.Error(t, err, "自分のボード以外はスコープに指定できない")
+
+	token, secret, err := svc.CreateToken(user.ID, "CI", domain.TokenScopes{
+		{BoardID: &board1, Access: domain.TokenAccessWrite},
+		{BoardID: &board2, Access: domain.TokenAccessRead},
+	}, nil)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, middleware.PersonalAccessTokenPrefix))
+	assert.True(t, strings.HasPrefix(secret, token.TokenPrefix))
+	assert.NotContains(t, token.TokenHash, secret, "トークンはハッシュのみを保存する")
+
+	access := func(method, route string, params map[string]string, body string) middleware.TokenAccess {
+		return middleware.TokenAccess{Method: method, Route: route, Params: params, Body: func() []byte { return []byte(body) }}
+	}
+	tests := []struct {
+		name    string
+		access  middleware.TokenAccess
+		allowed bool
+	}{
+		{"書き込み可能なボードのタスク更新", access(http.MethodPut, "/api/v1/tasks/:id", map[string]string{"id": "10"}, ""), true},
+		{"参照のみのボードのタスク取得", access(http.MethodGet, "/api/v1/tasks/:id", map[string]string{"id": "20"}, ""), true},
+		{"参照のみのボードのタスク更新", access(http.MethodPut, "/api/v1/tasks/:id", map[string]string{"id": "20"}, ""), false},
+		{"スコープにないボード", access(http.MethodGet, "/api/v1/boards/:id/columns", map[string]string{"id": "3"}, ""), false},
+		{"ボードに属さないAPI", access(http.MethodGet, "/api/v1/boards", nil, ""), false},
+		{"書き込み可能なボードへのタスク作成", access(http.MethodPost, "/api/v1/tasks", nil, `{"column_id":100}`), true},
+		{"参照のみのボードへのタスク作成", access(http.MethodPost, "/api/v1/tasks", nil, `{"column_id":200}`), false},
+		{"追加先のカラムを特定できないタスク作成", access(http.MethodPost, "/api/v1/tasks", nil, `{"title":"途中で切れた`), false},
+		{"追加先のカラムのないタスク作成", access(http.MethodPost, "/api/v1/tasks", nil, `{"title":"タスク"}`), false},
+		{"トークンの管理", access(http.MethodGet, "/api/v1/tokens", nil, ""), false},
+		{"二要素認証の設定", access(http.MethodPost, "/api/v1/auth/2fa/disable", nil, ""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, email, err := svc.AuthenticateToken(secret, tt.access)
+			if tt.allowed {
+				require.NoError(t, err)
+				assert.Equal(t, user.ID, userID)
+				assert.Equal(t, user.Email, email)
+			} else {
+				assert.ErrorIs(t, err, middleware.ErrTokenForbidden)
+			}
+		})
+	}
+	assert.NotNil(t, tokenRepo.tokens[token.ID].LastUsedAt)
+
+	// 失効・期限切れのトークンは認証しない
+	getBoard := access(http.MethodGet, "/api/v1/boards/:id/columns", map[string]string{"id": "1"}, "")
+	_, _, err = svc.AuthenticateToken(middleware.PersonalAccessTokenPrefix+"unknown", getBoard)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, middleware.ErrTokenForbidden)
+
+	expired := time.Now().Add(-time.Minute)
+	tokenRepo.tokens[token.ID].ExpiresAt = &expired
+	_, _, err = svc.AuthenticateToken(secret, getBoard)
+	assert.Error(t, err)
+
+	require.NoError(t, svc.RevokeToken(token.ID, user.ID))
+	assert.Empty(t, tokenRepo.tokens)
+}
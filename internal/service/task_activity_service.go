@@ -0,0 +1,64 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// TaskActivityService タスクの履歴を管理するインターフェース
+type TaskActivityService interface {
+	GetTaskActivities(taskID uint, userID uuid.UUID) ([]domain.TaskActivity, error)
+}
+
+// taskActivityService TaskActivityServiceの実装
+type taskActivityService struct {
+	activityRepo repository.TaskActivityRepository
+	taskRepo     repository.TaskRepository
+	boardRepo    repository.BoardRepository
+}
+
+// NewTaskActivityService TaskActivityServiceの新しいインスタンスを作成
+func NewTaskActivityService(activityRepo repository.TaskActivityRepository, taskRepo repository.TaskRepository, boardRepo repository.BoardRepository) TaskActivityService {
+	return &taskActivityService{
+		activityRepo: activityRepo,
+		taskRepo:     taskRepo,
+		boardRepo:    boardRepo,
+	}
+}
+
+// GetTaskActivities タスクの履歴を新しい順に取得します
+func (s *taskActivityService) GetTaskActivities(taskID uint, userID uuid.UUID) ([]domain.TaskActivity, error) {
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("タスク取得エラー: %w", err)
+	}
+	if task == nil {
+		return nil, errors.New("タスクが見つかりません")
+	}
+
+	board, err := s.boardRepo.GetByID(task.Column.BoardID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		return nil, errors.New("このタスクにアクセスする権限がありません")
+	}
+	member, err := isBoardMember(s.boardRepo, board.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, errors.New("このタスクにアクセスする権限がありません")
+	}
+
+	activities, err := s.activityRepo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("履歴取得エラー: %w", err)
+	}
+	return activities, nil
+}
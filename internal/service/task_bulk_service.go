@@ -0,0 +1,362 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 一括操作で指定できるタスク数の上限
+const MaxBulkTaskCount = 200
+
+// TaskBulkOperationType 一括操作の種類
+type TaskBulkOperationType string
+
+const (
+	TaskBulkMove        TaskBulkOperationType = "move"
+	TaskBulkSetAssignee TaskBulkOperationType = "set_assignee"
+	TaskBulkSetDueDate  TaskBulkOperationType = "set_due_date"
+	TaskBulkComplete    TaskBulkOperationType = "complete"
+	TaskBulkDelete      TaskBulkOperationType = "delete"
+	TaskBulkAddLabel    TaskBulkOperationType = "add_label"
+)
+
+// TaskBulkOperation 複数のタスクに適用する操作
+type TaskBulkOperation struct {
+	Type       TaskBulkOperationType
+	ColumnID   uint       // move: 移動先のカラム
+	AssigneeID *uuid.UUID // set_assignee: nilの場合は担当者を外す
+	DueDate    *time.Time // set_due_date: nilの場合は期限を外す
+	LabelID    uint       // add_label: 付与するラベル
+}
+
+// TaskBulkResult タスクごとの一括操作の結果
+type TaskBulkResult struct {
+	TaskID  uint
+	Success bool
+	Error   string
+}
+
+// TaskBulkService タスクの一括操作を管理するインターフェース
+type TaskBulkService interface {
+	Apply(userID uuid.UUID, taskIDs []uint, op TaskBulkOperation) ([]TaskBulkResult, error)
+}
+
+// taskBulkService TaskBulkServiceの実装
+type taskBulkService struct {
+	db         *gorm.DB // トランザクション用のデータベース接続
+	webhooks   WebhookPublisher
+	automation AutomationRunner
+}
+
+// NewTaskBulkService TaskBulkServiceの新しいインスタンスを作成
+func NewTaskBulkService(db *gorm.DB, webhooks WebhookPublisher, automation AutomationRunner) TaskBulkService {
+	return &taskBulkService{db: db, webhooks: webhooks, automation: automation}
+}
+
+// Apply 複数のタスクに同じ操作を1つのトランザクションで適用します
+// タスクが見つからない・権限がないなどの個別のエラーは結果に記録して残りの処理を続け、
+// データベースエラーが発生した場合は全体をロールバックしてエラーを返します
+// 移動したタスクは、1件ずつの移動と同じくタスクごとにWebhookで通知し、自動化ルール（task_moved）を実行します
+func (s *taskBulkService) Apply(userID uuid.UUID, taskIDs []uint, op TaskBulkOperation) ([]TaskBulkResult, error) {
+	if len(taskIDs) == 0 {
+		return nil, errors.New("タスクIDを指定してください")
+	}
+	if len(taskIDs) > MaxBulkTaskCount {
+		return nil, fmt.Errorf("一度に操作できるタスクは%d件までです", MaxBulkTaskCount)
+	}
+
+	var results []TaskBulkResult
+	var events []webhookEvent
+	var moves []taskBulkMove
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		bulk := &taskBulkTx{
+			userID:     userID,
+			op:         op,
+			taskRepo:   repository.NewTaskRepository(tx),
+			boardRepo:  repository.NewBoardRepository(tx),
+			columnRepo: repository.NewColumnRepository(tx),
+			labelRepo:  repository.NewLabelRepository(tx),
+			boardAuth:  make(map[uint]string),
+		}
+		if err := bulk.prepare(); err != nil {
+			return err
+		}
+
+		results = make([]TaskBulkResult, 0, len(taskIDs))
+		seen := make(map[uint]bool, len(taskIDs))
+		for _, taskID := range taskIDs {
+			if seen[taskID] {
+				continue
+			}
+			seen[taskID] = true
+
+			result, err := bulk.apply(taskID)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		events = bulk.events
+		moves = bulk.moves
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// コミット後にWebhookの配信キューへ追加する
+	for _, e := range events {
+		s.webhooks.Publish(e.boardID, e.event, e.data)
+	}
+	for _, m := range moves {
+		s.automation.TaskMoved(m.task, m.fromColumnID)
+	}
+	return results, nil
+}
+
+// taskBulkMove コミット後に自動化ルールを実行する移動
+type taskBulkMove struct {
+	task         *domain.Task // 移動後のタスク
+	fromColumnID uint
+}
+
+// taskBulkTx 1回の一括操作のトランザクション内の状態
+type taskBulkTx struct {
+	userID     uuid.UUID
+	op         TaskBulkOperation
+	taskRepo   repository.TaskRepository
+	boardRepo  repository.BoardRepository
+	columnRepo repository.ColumnRepository
+	labelRepo  repository.LabelRepository
+
+	boardAuth    map[uint]string // ボードごとの権限チェック結果（空文字は許可）
+	assigneeAuth map[uint]bool   // set_assignee: ボードごとの担当者がメンバーかどうか
+	targetColumn *domain.Column
+	label        *domain.Label
+	events       []webhookEvent // コミット後に通知するWebhookのイベント
+	moves        []taskBulkMove // コミット後に自動化ルールを実行する移動
+}
+
+// prepare 操作の対象（移動先カラム・ラベル）を検証します
+func (b *taskBulkTx) prepare() error {
+	switch b.op.Type {
+	case TaskBulkMove:
+		column, err := b.columnRepo.GetByID(b.op.ColumnID)
+		if err != nil {
+			return fmt.Errorf("カラム取得エラー: %w", err)
+		}
+		if column == nil {
+			return errors.New("移動先のカラムが見つかりません")
+		}
+		if denied, err := b.checkBoard(column.BoardID); err != nil || denied != "" {
+			return firstError(err, denied)
+		}
+		b.targetColumn = column
+
+	case TaskBulkAddLabel:
+		label, err := b.labelRepo.GetByID(b.op.LabelID)
+		if err != nil {
+			return fmt.Errorf("ラベル取得エラー: %w", err)
+		}
+		if label == nil {
+			return errors.New("ラベルが見つかりません")
+		}
+		if denied, err := b.checkBoard(label.BoardID); err != nil || denied != "" {
+			return firstError(err, denied)
+		}
+		b.label = label
+
+	case TaskBulkSetAssignee:
+		// 担当者がメンバーかどうかは、タスクのボードごとに1回だけ問い合わせる
+		b.assigneeAuth = make(map[uint]bool)
+
+	case TaskBulkSetDueDate, TaskBulkComplete, TaskBulkDelete:
+	default:
+		return fmt.Errorf("不正な操作です: %s", b.op.Type)
+	}
+	return nil
+}
+
+// apply 1件のタスクに操作を適用します
+// 個別のエラーは結果として返し、データベースエラーのみerrorとして返します
+func (b *taskBulkTx) apply(taskID uint) (TaskBulkResult, error) {
+	result := TaskBulkResult{TaskID: taskID}
+	fail := func(message string) (TaskBulkResult, error) {
+		result.Error = message
+		return result, nil
+	}
+
+	task, err := b.taskRepo.GetByID(taskID)
+	if err != nil {
+		return result, fmt.Errorf("タスク取得エラー: %w", err)
+	}
+	if task == nil {
+		return fail("タスクが見つかりません")
+	}
+	denied, err := b.checkBoard(task.Column.BoardID)
+	if err != nil {
+		return result, err
+	}
+	if denied != "" {
+		return fail(denied)
+	}
+
+	switch b.op.Type {
+	case TaskBulkMove:
+		if b.targetColumn.BoardID != task.Column.BoardID {
+			return fail("別のボードのカラムには移動できません")
+		}
+		if task.ColumnID != b.targetColumn.ID {
+			// 移動先カラムの同じレーンのセルの末尾に追加
+			tasks, err := b.taskRepo.GetByColumnID(b.targetColumn.ID)
+			if err != nil {
+				return result, fmt.Errorf("タスク取得エラー: %w", err)
+			}
+			order := 1
+			for _, t := range tasks {
+				if sameLane(t.LaneID, task.LaneID) {
+					order++
+				}
+			}
+			if err := b.taskRepo.MoveToColumn(task.ID, b.targetColumn.ID, task.LaneID, order); err != nil {
+				// WIP制限（hard）による拒否はタスクごとの結果として扱う
+				var wipErr *domain.WIPLimitError
+				if errors.As(err, &wipErr) {
+					return fail(wipErr.Error())
+				}
+				return result, fmt.Errorf("タスク移動エラー: %w", err)
+			}
+			moved, err := b.taskRepo.GetByID(task.ID)
+			if err != nil {
+				return result, fmt.Errorf("タスク取得エラー: %w", err)
+			}
+			b.addEvent(task.Column.BoardID, domain.WebhookEventTaskMoved, newTaskMovedData(moved, task.ColumnID, task.LaneID))
+			b.moves = append(b.moves, taskBulkMove{task: moved, fromColumnID: task.ColumnID})
+		}
+
+	case TaskBulkSetAssignee:
+		member, err := b.checkAssignee(task.Column.BoardID)
+		if err != nil {
+			return result, err
+		}
+		if !member {
+			return fail("担当者はこのボードのメンバーではありません")
+		}
+		task.AssigneeID = b.op.AssigneeID
+		task.Assignee = nil
+		if err := b.taskRepo.Update(task); err != nil {
+			return result, fmt.Errorf("タスク更新エラー: %w", err)
+		}
+		b.addEvent(task.Column.BoardID, domain.WebhookEventTaskUpdated, task)
+
+	case TaskBulkSetDueDate:
+		task.DueDate = b.op.DueDate
+		if err := b.taskRepo.Update(task); err != nil {
+			return result, fmt.Errorf("タスク更新エラー: %w", err)
+		}
+		b.addEvent(task.Column.BoardID, domain.WebhookEventTaskUpdated, task)
+
+	case TaskBulkComplete:
+		task.IsCompleted = true
+		if err := b.taskRepo.Update(task); err != nil {
+			return result, fmt.Errorf("タスク更新エラー: %w", err)
+		}
+		b.addEvent(task.Column.BoardID, domain.WebhookEventTaskUpdated, task)
+
+	case TaskBulkDelete:
+		if err := b.taskRepo.Delete(task.ID, task.Version); err != nil {
+			return result, fmt.Errorf("タスク削除エラー: %w", err)
+		}
+		b.addEvent(task.Column.BoardID, domain.WebhookEventTaskDeleted, task)
+
+	case TaskBulkAddLabel:
+		if b.label.BoardID != task.Column.BoardID {
+			return fail("このラベルはタスクのボードに定義されていません")
+		}
+		if err := b.labelRepo.AddToTask(task.ID, b.label.ID); err != nil {
+			return result, fmt.Errorf("ラベル付与エラー: %w", err)
+		}
+		labeled, err := b.taskRepo.GetByID(task.ID)
+		if err != nil {
+			return result, fmt.Errorf("タスク取得エラー: %w", err)
+		}
+		b.addEvent(task.Column.BoardID, domain.WebhookEventTaskUpdated, labeled)
+	}
+
+	result.Success = true
+	return result, nil
+}
+
+// addEvent コミット後に通知するWebhookのイベントを追加します
+func (b *taskBulkTx) addEvent(boardID uint, event domain.WebhookEvent, data interface{}) {
+	b.events = append(b.events, webhookEvent{boardID: boardID, event: event, data: data})
+}
+
+// checkBoard ボードへのアクセス権をチェックし、拒否する場合はその理由を返します
+// ボードごとに1回だけ問い合わせ、結果を再利用します
+func (b *taskBulkTx) checkBoard(boardID uint) (string, error) {
+	if denied, checked := b.boardAuth[boardID]; checked {
+		return denied, nil
+	}
+
+	board, err := b.boardRepo.GetByID(boardID)
+	if err != nil {
+		return "", fmt.Errorf("ボード取得エラー: %w", err)
+	}
+
+	var denied string
+	if board == nil {
+		denied = "ボードが見つかりません"
+	} else {
+		member, err := isBoardMember(b.boardRepo, board.ID, b.userID)
+		if err != nil {
+			return "", err
+		}
+		if !member {
+			denied = "このボードにアクセスする権限がありません"
+		}
+	}
+
+	b.boardAuth[boardID] = denied
+	return denied, nil
+}
+
+// checkAssignee set_assigneeの担当者がボードのメンバーかどうかをチェックします（担当者を外す場合は常に許可）
+// ボードごとに1回だけ問い合わせ、結果を再利用します
+func (b *taskBulkTx) checkAssignee(boardID uint) (bool, error) {
+	if b.op.AssigneeID == nil {
+		return true, nil
+	}
+	if member, checked := b.assigneeAuth[boardID]; checked {
+		return member, nil
+	}
+	member, err := isBoardMember(b.boardRepo, boardID, *b.op.AssigneeID)
+	if err != nil {
+		return false, err
+	}
+	b.assigneeAuth[boardID] = member
+	return member, nil
+}
+
+// firstError データベースエラーを優先し、なければ拒否理由をエラーとして返します
+func firstError(err error, denied string) error {
+	if err != nil {
+		return err
+	}
+	return errors.New(denied)
+}
+
+// sameLane 2つのレーン参照が同じレーン（どちらもレーンなしを含む）を指すかを判定します
+func sameLane(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
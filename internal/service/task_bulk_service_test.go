@@ -0,0 +1,194 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB テスト用のデータベースに接続します
+// TEST_DATABASE_URL が設定されていない場合はテストをスキップします
+func openTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL が設定されていないためスキップします")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, repository.Migrate(db))
+	return db
+}
+
+// recordingAutomationRunner 実行を依頼された移動を記録するAutomationRunner
+type recordingAutomationRunner struct {
+	AutomationRunner
+	moved []taskBulkMove
+}
+
+func (r *recordingAutomationRunner) TaskMoved(task *domain.Task, fromColumnID uint) bool {
+	r.moved = append(r.moved, taskBulkMove{task: task, fromColumnID: fromColumnID})
+	return false
+}
+
+// taskBulkFixture 一括操作のテスト用のボード
+type taskBulkFixture struct {
+	alice, bob  *domain.User
+	todo, doing *domain.Column // aliceのボードのカラム（doingはWIP制限1件のhard）
+	label       *domain.Label  // aliceのボードのラベル
+	otherLabel  *domain.Label  // bobのボードのラベル
+	tasks       []*domain.Task // aliceのボードのToDoのタスク3件
+	otherTask   *domain.Task   // bobのボードのタスク
+}
+
+// newTaskBulkFixture aliceとbobがそれぞれ自分だけのワークスペースにボードを持つデータを作成します
+func newTaskBulkFixture(t *testing.T, db *gorm.DB) *taskBulkFixture {
+	f := &taskBulkFixture{}
+	var workspaces []*domain.Workspace
+	var boards []*domain.Board
+	newBoard := func(prefix string) (*domain.User, *domain.Board) {
+		user := &domain.User{Email: fmt.Sprintf("%s-%s@example.com", prefix, uuid.NewString()), PasswordHash: "x"}
+		require.NoError(t, db.Create(user).Error)
+		workspace := &domain.Workspace{Name: prefix, Personal: true}
+		require.NoError(t, db.Create(workspace).Error)
+		require.NoError(t, db.Create(&domain.WorkspaceMember{WorkspaceID: workspace.ID, UserID: user.ID, Role: domain.WorkspaceRoleAdmin}).Error)
+		board := &domain.Board{Name: "一括操作テスト", WorkspaceID: workspace.ID, OwnerID: user.ID}
+		require.NoError(t, db.Create(board).Error)
+		workspaces = append(workspaces, workspace)
+		boards = append(boards, board)
+		return user, board
+	}
+
+	var aliceBoard, bobBoard *domain.Board
+	f.alice, aliceBoard = newBoard("bulk-alice")
+	f.bob, bobBoard = newBoard("bulk-bob")
+
+	limit := 1
+	f.todo = &domain.Column{BoardID: aliceBoard.ID, Title: "To Do", Order: 1}
+	f.doing = &domain.Column{BoardID: aliceBoard.ID, Title: "Doing", Order: 2, WIPLimit: &limit, WIPLimitMode: domain.WIPLimitModeHard}
+	otherColumn := &domain.Column{BoardID: bobBoard.ID, Title: "To Do", Order: 1}
+	for _, column := range []*domain.Column{f.todo, f.doing, otherColumn} {
+		require.NoError(t, db.Create(column).Error)
+	}
+	f.label = &domain.Label{BoardID: aliceBoard.ID, Name: "Bug", Color: "#EF4444"}
+	f.otherLabel = &domain.Label{BoardID: bobBoard.ID, Name: "Bug", Color: "#EF4444"}
+	require.NoError(t, db.Create(f.label).Error)
+	require.NoError(t, db.Create(f.otherLabel).Error)
+
+	taskRepo := repository.NewTaskRepository(db)
+	for i := 0; i < 3; i++ {
+		task := &domain.Task{ColumnID: f.todo.ID, Title: fmt.Sprintf("タスク%d", i+1), Priority: domain.TaskPriorityNone}
+		require.NoError(t, taskRepo.Create(task))
+		f.tasks = append(f.tasks, task)
+	}
+	f.otherTask = &domain.Task{ColumnID: otherColumn.ID, Title: "bobのタスク", Priority: domain.TaskPriorityNone}
+	require.NoError(t, taskRepo.Create(f.otherTask))
+
+	t.Cleanup(func() {
+		columnIDs := []uint{f.todo.ID, f.doing.ID, otherColumn.ID}
+		db.Exec("DELETE FROM task_labels WHERE label_id IN ?", []uint{f.label.ID, f.otherLabel.ID})
+		db.Unscoped().Where("column_id IN ?", columnIDs).Delete(&domain.Task{})
+		db.Unscoped().Delete(&domain.Label{}, []uint{f.label.ID, f.otherLabel.ID})
+		db.Unscoped().Delete(&domain.Column{}, columnIDs)
+		for i := range boards {
+			db.Unscoped().Delete(boards[i])
+			db.Where("workspace_id = ?", workspaces[i].ID).Delete(&domain.WorkspaceMember{})
+			db.Delete(workspaces[i])
+		}
+		db.Unscoped().Delete(f.alice)
+		db.Unscoped().Delete(f.bob)
+	})
+	return f
+}
+
+// 一括移動で、権限・WIP制限（hard）・存在しないタスクの失敗をタスクごとに返し、成功した移動だけを通知することのテスト
+func TestTaskBulkService_MovePartialFailure(t *testing.T) {
+	db := openTestDB(t)
+	f := newTaskBulkFixture(t, db)
+	webhooks := &recordingWebhookPublisher{}
+	automation := &recordingAutomationRunner{}
+	svc := NewTaskBulkService(db, webhooks, automation)
+
+	missingID := f.otherTask.ID + 1000
+	results, err := svc.Apply(f.alice.ID, []uint{f.tasks[0].ID, f.tasks[1].ID, f.otherTask.ID, missingID, f.tasks[0].ID}, TaskBulkOperation{
+		Type:     TaskBulkMove,
+		ColumnID: f.doing.ID,
+	})
+	require.NoError(t, err)
+
+	// 重複したIDは1回だけ処理する
+	require.Len(t, results, 4)
+	assert.True(t, results[0].Success)
+	assert.False(t, results[1].Success, "WIP制限（hard）を超える移動は失敗する")
+	assert.Contains(t, results[1].Error, "WIP")
+	assert.False(t, results[2].Success, "他のユーザーのボードのタスクは移動できない")
+	assert.Equal(t, "このボードにアクセスする権限がありません", results[2].Error)
+	assert.False(t, results[3].Success)
+	assert.Equal(t, "タスクが見つかりません", results[3].Error)
+
+	// 成功した移動のみ、タスクごとにWebhookで通知し自動化ルールを実行する
+	assert.Equal(t, []domain.WebhookEvent{domain.WebhookEventTaskMoved}, webhooks.events)
+	require.Len(t, automation.moved, 1)
+	assert.Equal(t, f.tasks[0].ID, automation.moved[0].task.ID)
+	assert.Equal(t, f.doing.ID, automation.moved[0].task.ColumnID)
+	assert.Equal(t, f.todo.ID, automation.moved[0].fromColumnID)
+
+	var other domain.Task
+	require.NoError(t, db.First(&other, f.otherTask.ID).Error)
+	assert.Equal(t, f.otherTask.ColumnID, other.ColumnID)
+}
+
+// 操作の対象（移動先のカラム・ラベル）が他のユーザーのボードの場合は、何も変更せずにエラーを返すことのテスト
+func TestTaskBulkService_Authorization(t *testing.T) {
+	db := openTestDB(t)
+	f := newTaskBulkFixture(t, db)
+	webhooks := &recordingWebhookPublisher{}
+	svc := NewTaskBulkService(db, webhooks, &recordingAutomationRunner{})
+
+	_, err := svc.Apply(f.bob.ID, []uint{f.otherTask.ID}, TaskBulkOperation{Type: TaskBulkMove, ColumnID: f.todo.ID})
+	assert.Error(t, err)
+	_, err = svc.Apply(f.alice.ID, []uint{f.tasks[0].ID}, TaskBulkOperation{Type: TaskBulkAddLabel, LabelID: f.otherLabel.ID})
+	assert.Error(t, err)
+	assert.Empty(t, webhooks.events)
+
+	// 自分のボードのラベルは、他のボードのタスクには付与できない
+	results, err := svc.Apply(f.alice.ID, []uint{f.tasks[0].ID, f.otherTask.ID}, TaskBulkOperation{Type: TaskBulkAddLabel, LabelID: f.label.ID})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Success)
+	assert.False(t, results[1].Success)
+	assert.Equal(t, []domain.WebhookEvent{domain.WebhookEventTaskUpdated}, webhooks.events)
+
+	// 担当者には、タスクのボードのメンバーのみを設定できる
+	webhooks.events = nil
+	results, err = svc.Apply(f.alice.ID, []uint{f.tasks[0].ID, f.tasks[1].ID}, TaskBulkOperation{Type: TaskBulkSetAssignee, AssigneeID: &f.bob.ID})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Success || results[1].Success)
+	assert.Equal(t, "担当者はこのボードのメンバーではありません", results[0].Error)
+	results, err = svc.Apply(f.alice.ID, []uint{f.tasks[0].ID}, TaskBulkOperation{Type: TaskBulkSetAssignee, AssigneeID: &f.alice.ID})
+	require.NoError(t, err)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, []domain.WebhookEvent{domain.WebhookEventTaskUpdated}, webhooks.events)
+
+	// 完了・削除もタスクごとに通知する
+	webhooks.events = nil
+	results, err = svc.Apply(f.alice.ID, []uint{f.tasks[1].ID, f.tasks[2].ID}, TaskBulkOperation{Type: TaskBulkComplete})
+	require.NoError(t, err)
+	assert.True(t, results[0].Success && results[1].Success)
+	_, err = svc.Apply(f.alice.ID, []uint{f.tasks[2].ID}, TaskBulkOperation{Type: TaskBulkDelete})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.WebhookEvent{domain.WebhookEventTaskUpdated, domain.WebhookEventTaskUpdated, domain.WebhookEventTaskDeleted}, webhooks.events)
+}
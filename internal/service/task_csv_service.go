@@ -0,0 +1,251 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskCSVImportResult タスクのCSVインポート結果
+type TaskCSVImportResult struct {
+	DryRun         bool                     // trueの場合はチェックのみで、何も作成していない
+	Rows           int                      // タスクの行数
+	CreatedColumns []string                 // 作成した（ドライランでは作成する）カラム名
+	CreatedTasks   int                      // 作成した（ドライランでは作成する）タスク数
+	Errors         []domain.TaskCSVRowError // 行ごとのエラー（1件でもあれば何も作成しない）
+}
+
+// TaskCSVService タスクのCSVエクスポート・インポートを管理するインターフェース
+type TaskCSVService interface {
+	ExportTasks(boardID uint, userID uuid.UUID) ([]domain.TaskCSVRow, error)
+	ImportTasks(boardID uint, userID uuid.UUID, rows []domain.TaskCSVRow, rowErrors []domain.TaskCSVRowError, dryRun bool) (*TaskCSVImportResult, error)
+}
+
+// taskCSVService TaskCSVServiceの実装
+type taskCSVService struct {
+	db           *gorm.DB // インポート用のデータベース接続
+	boardService BoardService
+	boardRepo    repository.BoardRepository
+	taskRepo     repository.TaskRepository
+	userRepo     repository.UserRepository
+	webhooks     WebhookPublisher
+	automation   AutomationRunner
+}
+
+// NewTaskCSVService TaskCSVServiceの新しいインスタンスを作成
+func NewTaskCSVService(db *gorm.DB, boardService BoardService, boardRepo repository.BoardRepository, taskRepo repository.TaskRepository, userRepo repository.UserRepository, webhooks WebhookPublisher, automation AutomationRunner) TaskCSVService {
+	return &taskCSVService{
+		db:           db,
+		boardService: boardService,
+		boardRepo:    boardRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+		webhooks:     webhooks,
+		automation:   automation,
+	}
+}
+
+// ExportTasks ボードのタスクをカラム順・セル内の順序でCSVの行に変換します
+func (s *taskCSVService) ExportTasks(boardID uint, userID uuid.UUID) ([]domain.TaskCSVRow, error) {
+	// 所有権のチェックを含めてボードを取得
+	board, err := s.boardService.GetBoardWithColumns(boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTaskCSVRows(board), nil
+}
+
+// ImportTasks CSVの行からボードにタスクを作成します
+// 存在しないカラムはタイトルで作成し、担当者はメールアドレスで探します
+// 先にすべての行をチェックし、エラーが1件でもあれば何も作成せずにエラーを返します。dryRunの場合はチェックのみ行います
+// WIP制限（hard）のあるカラムは、既存のタスクと先の行の分を数えて超える行をエラーとします
+// 作成したタスクは、画面からの作成と同じくWebhookで通知し、自動化ルールを実行します
+func (s *taskCSVService) ImportTasks(boardID uint, userID uuid.UUID, rows []domain.TaskCSVRow, rowErrors []domain.TaskCSVRowError, dryRun bool) (*TaskCSVImportResult, error) {
+	// 所有権のチェックを含めてボードを取得
+	board, err := s.boardService.GetBoardWithColumns(boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TaskCSVImportResult{
+		DryRun:         dryRun,
+		Rows:           len(rows) + countTaskCSVErrorLines(rowErrors),
+		CreatedColumns: []string{},
+		Errors:         append([]domain.TaskCSVRowError{}, rowErrors...),
+	}
+
+	// カラムはタイトルで照合し、見つからないものは最初に現れた順に作成する
+	columnIDs := make(map[string]uint, len(board.Columns))
+	columns := make(map[string]*domain.Column, len(board.Columns))
+	for i, column := range board.Columns {
+		if _, ok := columnIDs[column.Title]; !ok {
+			columnIDs[column.Title] = column.ID
+			columns[column.Title] = &board.Columns[i]
+		}
+	}
+	for _, row := range rows {
+		if _, ok := columnIDs[row.Column]; !ok && !slices.Contains(result.CreatedColumns, row.Column) {
+			result.CreatedColumns = append(result.CreatedColumns, row.Column)
+		}
+	}
+
+	// 既存のカラムに追加する行は、CSVの並び順にWIP制限（hard）をチェックする（作成するカラムは無制限）
+	added := make(map[string]int)
+	for _, row := range rows {
+		column, ok := columns[row.Column]
+		if !ok || column.WIPLimitMode != domain.WIPLimitModeHard {
+			continue
+		}
+		added[row.Column]++
+		if wipErr := column.CheckWIPLimit(len(column.Tasks) + added[row.Column]); wipErr != nil {
+			result.Errors = append(result.Errors, domain.TaskCSVRowError{
+				Line:    row.Line,
+				Message: fmt.Sprintf("カラム「%s」のWIP制限（%d件）を超えるため追加できません", row.Column, wipErr.Limit),
+			})
+		}
+	}
+
+	// メールアドレスからボードのメンバーを探す（同じアドレスは1度だけ検索）
+	assignees := make(map[string]*uuid.UUID)
+	for _, row := range rows {
+		if row.AssigneeEmail == "" {
+			continue
+		}
+		if _, ok := assignees[row.AssigneeEmail]; !ok {
+			user, err := s.userRepo.GetByEmail(row.AssigneeEmail)
+			if err != nil {
+				return nil, fmt.Errorf("ユーザー取得エラー: %w", err)
+			}
+			assignees[row.AssigneeEmail] = nil
+			if user != nil {
+				member, err := isBoardMember(s.boardRepo, boardID, user.ID)
+				if err != nil {
+					return nil, err
+				}
+				if member {
+					assignees[row.AssigneeEmail] = &user.ID
+				}
+			}
+		}
+		if assignees[row.AssigneeEmail] == nil {
+			// 登録されているメールアドレスかどうかは明かさない
+			result.Errors = append(result.Errors, domain.TaskCSVRowError{
+				Line:    row.Line,
+				Message: fmt.Sprintf("担当者のメールアドレスに一致するボードのメンバーが見つかりません: %s", row.AssigneeEmail),
+			})
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Line < result.Errors[j].Line })
+		result.CreatedColumns = []string{}
+		return result, nil
+	}
+	result.CreatedTasks = len(rows)
+	if dryRun {
+		return result, nil
+	}
+
+	createdIDs := make([]uint, 0, len(rows))
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		columnRepo := repository.NewColumnRepository(tx)
+		taskRepo := repository.NewTaskRepository(tx)
+
+		for i, title := range result.CreatedColumns {
+			column := &domain.Column{
+				BoardID:      boardID,
+				Title:        title,
+				Order:        len(board.Columns) + i + 1,
+				WIPLimitMode: domain.WIPLimitModeSoft,
+			}
+			if err := columnRepo.Create(column); err != nil {
+				return fmt.Errorf("カラム作成エラー: %w", err)
+			}
+			columnIDs[title] = column.ID
+		}
+
+		// CSVの並び順のまま各カラムの末尾に追加する
+		for _, row := range rows {
+			task := &domain.Task{
+				ColumnID:      columnIDs[row.Column],
+				Title:         row.Title,
+				Description:   row.Description,
+				DueDate:       row.DueDate,
+				EstimatedTime: row.EstimatedTime,
+				ActualTime:    row.ActualTime,
+				IsCompleted:   row.IsCompleted,
+				Priority:      domain.TaskPriorityNone,
+			}
+			if row.AssigneeEmail != "" {
+				task.AssigneeID = assignees[row.AssigneeEmail]
+			}
+			// チェック後に同時に追加されたタスクで制限を超えた場合は、すべて取り消す
+			if err := taskRepo.CreateWithinWIPLimit(task); err != nil {
+				var wipErr *domain.WIPLimitError
+				if errors.As(err, &wipErr) {
+					return fmt.Errorf("%d行目のタスクを追加できません: %w", row.Line, wipErr)
+				}
+				return fmt.Errorf("タスク作成エラー: %w", err)
+			}
+			createdIDs = append(createdIDs, task.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// コミット後に、作成したタスクごとにWebhookの通知と自動化ルールの実行を行う
+	for _, id := range createdIDs {
+		created, err := s.taskRepo.GetByID(id)
+		if err != nil || created == nil {
+			log.Printf("インポートしたタスク取得エラー: task=%d err=%v", id, err)
+			continue
+		}
+		s.webhooks.Publish(boardID, domain.WebhookEventTaskCreated, created)
+		s.automation.TaskCreated(created)
+	}
+
+	return result, nil
+}
+
+// newTaskCSVRows ボードのタスクをカラム順・セル内の順序でCSVの行に変換します
+func newTaskCSVRows(board *domain.Board) []domain.TaskCSVRow {
+	rows := []domain.TaskCSVRow{}
+	for _, column := range board.Columns {
+		for _, task := range column.Tasks {
+			row := domain.TaskCSVRow{
+				Column:        column.Title,
+				Title:         task.Title,
+				Description:   task.Description,
+				DueDate:       task.DueDate,
+				EstimatedTime: task.EstimatedTime,
+				ActualTime:    task.ActualTime,
+				IsCompleted:   task.IsCompleted,
+			}
+			if task.Assignee != nil {
+				row.AssigneeEmail = task.Assignee.Email
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// countTaskCSVErrorLines エラーのある行数を数えます
+func countTaskCSVErrorLines(rowErrors []domain.TaskCSVRowError) int {
+	lines := make(map[int]bool, len(rowErrors))
+	for _, rowError := range rowErrors {
+		lines[rowError.Line] = true
+	}
+	return len(lines)
+}
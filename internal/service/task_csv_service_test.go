@@ -0,0 +1,98 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBoardService テスト用のBoardService（使用するメソッドのみ実装）
+type memoryBoardService struct {
+	BoardService
+	board *domain.Board
+}
+
+func (s *memoryBoardService) GetBoardWithColumns(boardID uint, userID uuid.UUID) (*domain.Board, error) {
+	return s.board, nil
+}
+
+// WIP制限（hard）を超える行と、CSVの形式が不正な行がドライランでも行ごとのエラーになることのテスト
+func TestTaskCSVService_ImportRowErrors(t *testing.T) {
+	limit := 2
+	board := &domain.Board{ID: 1, Columns: []domain.Column{
+		{ID: 1, BoardID: 1, Title: "To Do"},
+		{ID: 2, BoardID: 1, Title: "Doing", WIPLimit: &limit, WIPLimitMode: domain.WIPLimitModeHard, Tasks: []domain.Task{{ID: 1, ColumnID: 2}}},
+	}}
+	alice := &domain.User{ID: uuid.New(), Email: "alice@example.com"}
+	users := &memoryUserRepository{users: map[uuid.UUID]*domain.User{alice.ID: alice}}
+	boards := &memoryBoardRepository{boards: map[uint]*domain.Board{1: {ID: 1, OwnerID: alice.ID}}}
+	webhooks := &recordingWebhookPublisher{}
+	svc := NewTaskCSVService(nil, &memoryBoardService{board: board}, boards, nil, users, webhooks, nil)
+
+	input := strings.Join([]string{
+		"column,title,assignee_email",
+		"Doing,設計,alice@example.com",
+		"Doing,実装,",
+		"To Do,\"引用符\"の誤り,",
+		"Doing,テスト,",
+		"Review,調査,",
+	}, "\n")
+	rows, rowErrors, err := domain.ParseTaskCSV(strings.NewReader(input))
+	require.NoError(t, err)
+
+	for _, dryRun := range []bool{true, false} {
+		result, err := svc.ImportTasks(board.ID, alice.ID, rows, rowErrors, dryRun)
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.Rows)
+		assert.Equal(t, 0, result.CreatedTasks)
+		assert.Empty(t, result.CreatedColumns)
+		require.Len(t, result.Errors, 3)
+		assert.Equal(t, 3, result.Errors[0].Line)
+		assert.Contains(t, result.Errors[0].Message, "WIP制限")
+		assert.Equal(t, 4, result.Errors[1].Line)
+		assert.Contains(t, result.Errors[1].Message, "ダブルクォート")
+		assert.Equal(t, 5, result.Errors[2].Line)
+		assert.Contains(t, result.Errors[2].Message, "WIP制限")
+	}
+	assert.Empty(t, webhooks.events, "エラーがある場合は何も作成せず通知もしない")
+
+	// 上限内の行だけであれば、ドライランでは作成するカラム・タスクを返す
+	rows, rowErrors, err = domain.ParseTaskCSV(strings.NewReader("column,title\nDoing,設計\nReview,調査\n"))
+	require.NoError(t, err)
+	result, err := svc.ImportTasks(board.ID, alice.ID, rows, rowErrors, true)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 2, result.CreatedTasks)
+	assert.Equal(t, []string{"Review"}, result.CreatedColumns)
+}
+
+// 担当者にはボードのメンバーのみを指定でき、未登録のメールアドレスと同じエラーになることのテスト
+func TestTaskCSVService_ImportAssigneeMembers(t *testing.T) {
+	board := &domain.Board{ID: 1, Columns: []domain.Column{{ID: 1, BoardID: 1, Title: "To Do"}}}
+	alice := &domain.User{ID: uuid.New(), Email: "alice@example.com"}
+	bob := &domain.User{ID: uuid.New(), Email: "bob@example.com"}
+	users := &memoryUserRepository{users: map[uuid.UUID]*domain.User{alice.ID: alice, bob.ID: bob}}
+	boards := &memoryBoardRepository{boards: map[uint]*domain.Board{1: {ID: 1, OwnerID: alice.ID}}}
+	svc := NewTaskCSVService(nil, &memoryBoardService{board: board}, boards, nil, users, &recordingWebhookPublisher{}, nil)
+
+	rows, rowErrors, err := domain.ParseTaskCSV(strings.NewReader(strings.Join([]string{
+		"column,title,assignee_email",
+		"To Do,設計,alice@example.com",
+		"To Do,実装,bob@example.com",
+		"To Do,テスト,nobody@example.com",
+	}, "\n")))
+	require.NoError(t, err)
+
+	result, err := svc.ImportTasks(board.ID, alice.ID, rows, rowErrors, true)
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, 3, result.Errors[0].Line)
+	assert.Equal(t, 4, result.Errors[1].Line)
+	assert.Equal(t, strings.Replace(result.Errors[0].Message, "bob@example.com", "nobody@example.com", 1), result.Errors[1].Message,
+		"メンバーでないユーザーと未登録のメールアドレスは区別しない")
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"simple-kanban/internal/repository"
+)
+
+// ランクキーの長さの上限（これを超えたセルは再配置ジョブで振り直す）
+const MaxTaskRankLength = 16
+
+// TaskRankService タスクの並び順（ランクキー）の保守を管理するインターフェース
+type TaskRankService interface {
+	Rebalance() (int, error)
+	StartRebalanceJob(interval time.Duration) func()
+}
+
+// taskRankService TaskRankServiceの実装
+type taskRankService struct {
+	taskRepo repository.TaskRepository
+}
+
+// NewTaskRankService TaskRankServiceの新しいインスタンスを作成
+func NewTaskRankService(taskRepo repository.TaskRepository) TaskRankService {
+	return &taskRankService{taskRepo: taskRepo}
+}
+
+// Rebalance ランクキーが長くなりすぎた、または重複しているセルのランクを振り直します
+// 同じ位置への挿入を繰り返すとランクキーが伸びていくため、定期的に実行します
+func (s *taskRankService) Rebalance() (int, error) {
+	cells, err := s.taskRepo.RebalanceRanks(MaxTaskRankLength)
+	if err != nil {
+		return 0, fmt.Errorf("ランク再配置エラー: %w", err)
+	}
+	return cells, nil
+}
+
+// StartRebalanceJob ランクの再配置を定期的に実行するジョブを開始します
+// 戻り値の関数を呼び出すとジョブを停止します
+func (s *taskRankService) StartRebalanceJob(interval time.Duration) func() {
+	done := make(chan struct{})
+	if interval <= 0 {
+		return func() {}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			cells, err := s.Rebalance()
+			if err != nil {
+				log.Printf("タスクのランク再配置に失敗しました: %v", err)
+			} else if cells > 0 {
+				log.Printf("タスクのランクを再配置しました: %dセル", cells)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -13,59 +15,111 @@ import (
 
 // TaskService タスク関連のビジネスロジックを管理するインターフェース
 type TaskService interface {
-	CreateTask(columnID uint, userID uuid.UUID, title, description string, order int, assigneeID *uuid.UUID, dueDate *time.Time) (*domain.Task, error)
+	CreateTask(columnID uint, laneID *uint, userID uuid.UUID, title, description string, order int, assigneeID *uuid.UUID, dueDate *time.Time, priority domain.TaskPriority) (*domain.Task, *domain.WIPLimitError, error)
 	GetTask(taskID uint, userID uuid.UUID) (*domain.Task, error)
-	UpdateTask(taskID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Task, error)
-	DeleteTask(taskID uint, userID uuid.UUID) error
-	MoveTask(taskID uint, newColumnID uint, newOrder int, userID uuid.UUID) error
-	ReorderTasks(columnID uint, taskIDs []uint, userID uuid.UUID) error
+	UpdateTask(taskID uint, userID uuid.UUID, version int, updates map[string]interface{}) (*domain.Task, error)
+	DeleteTask(taskID uint, userID uuid.UUID, version int) error
+	MoveTask(taskID uint, newColumnID uint, newLaneID *uint, newOrder int, userID uuid.UUID) (*domain.WIPLimitError, error)
+	ReorderTasks(columnID uint, laneID *uint, taskIDs []uint, userID uuid.UUID) error
+	ListBoardTasks(boardID uint, userID uuid.UUID, query repository.TaskQuery) ([]domain.Task, error)
+	SearchTasks(userID uuid.UUID, query repository.TaskQuery, cursor string) ([]domain.Task, string, error)
 }
 
+// タスク検索の取得件数
+const (
+	DefaultTaskSearchLimit = 50
+	MaxTaskSearchLimit     = 100
+)
+
 // taskService TaskServiceの実装
 type taskService struct {
-	taskRepo   repository.TaskRepository
-	boardRepo  repository.BoardRepository
-	columnRepo repository.ColumnRepository
+	taskRepo        repository.TaskRepository
+	boardRepo       repository.BoardRepository
+	columnRepo      repository.ColumnRepository
+	customFieldRepo repository.CustomFieldRepository
+	laneRepo        repository.LaneRepository
+	webhooks        WebhookPublisher
+	automation      AutomationRunner
 }
 
 // NewTaskService TaskServiceの新しいインスタンスを作成
-func NewTaskService(taskRepo repository.TaskRepository, boardRepo repository.BoardRepository, columnRepo repository.ColumnRepository) TaskService {
+func NewTaskService(taskRepo repository.TaskRepository, boardRepo repository.BoardRepository, columnRepo repository.ColumnRepository, customFieldRepo repository.CustomFieldRepository, laneRepo repository.LaneRepository, webhooks WebhookPublisher, automation AutomationRunner) TaskService {
 	return &taskService{
-		taskRepo:   taskRepo,
-		boardRepo:  boardRepo,
-		columnRepo: columnRepo,
+		taskRepo:        taskRepo,
+		boardRepo:       boardRepo,
+		columnRepo:      columnRepo,
+		customFieldRepo: customFieldRepo,
+		laneRepo:        laneRepo,
+		webhooks:        webhooks,
+		automation:      automation,
 	}
 }
 
 // CreateTask 新しいタスクを作成します
-func (s *taskService) CreateTask(columnID uint, userID uuid.UUID, title, description string, order int, assigneeID *uuid.UUID, dueDate *time.Time) (*domain.Task, error) {
+// カラムのWIP制限を超える場合、hardモードではWIPLimitErrorを返し、softモードでは警告として返します
+func (s *taskService) CreateTask(columnID uint, laneID *uint, userID uuid.UUID, title, description string, order int, assigneeID *uuid.UUID, dueDate *time.Time, priority domain.TaskPriority) (*domain.Task, *domain.WIPLimitError, error) {
 	// カラムの存在確認とボードの所有権チェック
 	if err := s.checkColumnAccess(columnID, userID); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// レーンが指定された場合は同じボードのレーンかをチェック
+	if laneID != nil {
+		if err := s.checkLane(*laneID, columnID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// WIP制限をチェック（同時に作成された場合の超過は作成時にリポジトリでもチェックされる）
+	warning, err := s.checkWIPLimit(columnID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 優先度が未指定の場合は「なし」とする
+	if priority == "" {
+		priority = domain.TaskPriorityNone
+	}
+	if !priority.IsValid() {
+		return nil, nil, fmt.Errorf("不正な優先度です: %s", priority)
 	}
 
 	// 新しいタスクを作成
 	task := &domain.Task{
 		ColumnID:    columnID,
+		LaneID:      laneID,
 		Title:       title,
 		Description: description,
 		Order:       order,
 		AssigneeID:  assigneeID,
 		DueDate:     dueDate,
+		Priority:    priority,
 	}
 
-	// データベースに保存
-	if err := s.taskRepo.Create(task); err != nil {
-		return nil, fmt.Errorf("タスク作成エラー: %w", err)
+	// データベースに保存（カラムをロックしてWIP制限を数え直す）
+	if err := s.taskRepo.CreateWithinWIPLimit(task); err != nil {
+		var wipErr *domain.WIPLimitError
+		if errors.As(err, &wipErr) {
+			return nil, nil, wipErr
+		}
+		return nil, nil, fmt.Errorf("タスク作成エラー: %w", err)
 	}
 
 	// 作成されたタスクを関連データと共に取得
 	createdTask, err := s.taskRepo.GetByID(task.ID)
 	if err != nil {
-		return nil, fmt.Errorf("作成されたタスク取得エラー: %w", err)
+		return nil, nil, fmt.Errorf("作成されたタスク取得エラー: %w", err)
 	}
 
-	return createdTask, nil
+	s.webhooks.Publish(createdTask.Column.BoardID, domain.WebhookEventTaskCreated, createdTask)
+
+	// 自動化ルールでタスクが変更された場合は変更後のタスクを返す
+	if s.automation.TaskCreated(createdTask) {
+		if updated, err := s.taskRepo.GetByID(task.ID); err == nil && updated != nil {
+			createdTask = updated
+		}
+	}
+	return createdTask, warning, nil
 }
 
 // GetTask タスクを取得します
@@ -88,7 +142,8 @@ func (s *taskService) GetTask(taskID uint, userID uuid.UUID) (*domain.Task, erro
 }
 
 // UpdateTask タスク情報を更新します
-func (s *taskService) UpdateTask(taskID uint, userID uuid.UUID, updates map[string]interface{}) (*domain.Task, error) {
+// versionがdomain.AnyVersion以外の場合は、現在のバージョンと一致するときのみ更新します
+func (s *taskService) UpdateTask(taskID uint, userID uuid.UUID, version int, updates map[string]interface{}) (*domain.Task, error) {
 	// タスクを取得
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
@@ -102,6 +157,9 @@ func (s *taskService) UpdateTask(taskID uint, userID uuid.UUID, updates map[stri
 	if err := s.checkColumnAccess(task.ColumnID, userID); err != nil {
 		return nil, err
 	}
+	if err := domain.CheckVersion(version, task.Version); err != nil {
+		return nil, err
+	}
 
 	// 更新可能なフィールドのみ処理
 	if title, ok := updates["title"].(string); ok && title != "" {
@@ -149,6 +207,13 @@ func (s *taskService) UpdateTask(taskID uint, userID uuid.UUID, updates map[stri
 			task.EstimatedTime = &val
 		}
 	}
+	if priority, ok := updates["priority"].(string); ok {
+		p := domain.TaskPriority(priority)
+		if !p.IsValid() {
+			return nil, fmt.Errorf("不正な優先度です: %s", priority)
+		}
+		task.Priority = p
+	}
 	if comp, ok := updates["is_completed"]; ok {
 		if v, ok := comp.(bool); ok {
 			task.IsCompleted = v
@@ -195,11 +260,13 @@ func (s *taskService) UpdateTask(taskID uint, userID uuid.UUID, updates map[stri
 		return nil, fmt.Errorf("タスク更新エラー: %w", err)
 	}
 
+	s.webhooks.Publish(task.Column.BoardID, domain.WebhookEventTaskUpdated, task)
 	return task, nil
 }
 
 // DeleteTask タスクを削除します
-func (s *taskService) DeleteTask(taskID uint, userID uuid.UUID) error {
+// versionがdomain.AnyVersion以外の場合は、現在のバージョンと一致するときのみ削除します
+func (s *taskService) DeleteTask(taskID uint, userID uuid.UUID, version int) error {
 	// タスクを取得
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
@@ -215,55 +282,345 @@ func (s *taskService) DeleteTask(taskID uint, userID uuid.UUID) error {
 	}
 
 	// タスクを削除
-	if err := s.taskRepo.Delete(taskID); err != nil {
+	if err := s.taskRepo.Delete(taskID, version); err != nil {
 		return fmt.Errorf("タスク削除エラー: %w", err)
 	}
 
+	s.webhooks.Publish(task.Column.BoardID, domain.WebhookEventTaskDeleted, task)
 	return nil
 }
 
-// MoveTask タスクを別のカラムに移動します
-func (s *taskService) MoveTask(taskID uint, newColumnID uint, newOrder int, userID uuid.UUID) error {
+// MoveTask タスクを別のセル（カラムとレーンの組）に移動します
+// newLaneIDがnilの場合は現在のレーンのまま、0の場合はレーンなしのセルに移動します
+// 移動先カラムのWIP制限を超える場合、hardモードではWIPLimitErrorを返し、softモードでは警告として返します
+func (s *taskService) MoveTask(taskID uint, newColumnID uint, newLaneID *uint, newOrder int, userID uuid.UUID) (*domain.WIPLimitError, error) {
 	// タスクを取得
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
-		return fmt.Errorf("タスク取得エラー: %w", err)
+		return nil, fmt.Errorf("タスク取得エラー: %w", err)
 	}
 	if task == nil {
-		return errors.New("タスクが見つかりません")
+		return nil, errors.New("タスクが見つかりません")
 	}
 
 	// 元のカラムと新しいカラムの両方のアクセス権をチェック
 	if err := s.checkColumnAccess(task.ColumnID, userID); err != nil {
-		return err
+		return nil, err
 	}
 	if err := s.checkColumnAccess(newColumnID, userID); err != nil {
-		return err
+		return nil, err
 	}
 
-	// タスクを移動
-	if err := s.taskRepo.MoveToColumn(taskID, newColumnID, newOrder); err != nil {
-		return fmt.Errorf("タスク移動エラー: %w", err)
+	// 別のボードへの移動はラベル等の付け替えが必要なため、ボード間移動を使用させる
+	newColumn, err := s.columnRepo.GetByID(newColumnID)
+	if err != nil {
+		return nil, fmt.Errorf("カラム取得エラー: %w", err)
+	}
+	if newColumn == nil {
+		return nil, errors.New("カラムが見つかりません")
+	}
+	if newColumn.BoardID != task.Column.BoardID {
+		return nil, errors.New("別のボードへの移動にはボード間移動を使用してください")
 	}
 
-	return nil
+	// 移動先のレーンを決定
+	laneID := task.LaneID
+	if newLaneID != nil {
+		laneID = nil
+		if *newLaneID != 0 {
+			laneID = newLaneID
+		}
+	}
+	if laneID != nil {
+		if err := s.checkLane(*laneID, newColumnID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 別のカラムへ移動する場合はWIP制限をチェック
+	var warning *domain.WIPLimitError
+	if newColumnID != task.ColumnID {
+		if warning, err = s.checkWIPLimit(newColumnID); err != nil {
+			return nil, err
+		}
+	}
+
+	// タスクを移動（同時実行時の超過はリポジトリでもチェックされる）
+	if err := s.taskRepo.MoveToColumn(taskID, newColumnID, laneID, newOrder); err != nil {
+		var wipErr *domain.WIPLimitError
+		if errors.As(err, &wipErr) {
+			return nil, wipErr
+		}
+		return nil, fmt.Errorf("タスク移動エラー: %w", err)
+	}
+
+	moved, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("移動したタスク取得エラー: %w", err)
+	}
+	if moved != nil {
+		s.webhooks.Publish(moved.Column.BoardID, domain.WebhookEventTaskMoved, newTaskMovedData(moved, task.ColumnID, task.LaneID))
+		s.automation.TaskMoved(moved, task.ColumnID)
+	}
+	return warning, nil
 }
 
-// ReorderTasks カラム内のタスクの順序を変更します
-func (s *taskService) ReorderTasks(columnID uint, taskIDs []uint, userID uuid.UUID) error {
+// ReorderTasks セル（カラムとレーンの組）内のタスクの順序を変更します
+// laneIDがnilの場合はレーンなしのセルが対象です
+func (s *taskService) ReorderTasks(columnID uint, laneID *uint, taskIDs []uint, userID uuid.UUID) error {
 	// カラムのアクセス権をチェック
 	if err := s.checkColumnAccess(columnID, userID); err != nil {
 		return err
 	}
+	if laneID != nil {
+		if err := s.checkLane(*laneID, columnID); err != nil {
+			return err
+		}
+	}
 
 	// タスクの順序を更新
-	if err := s.taskRepo.ReorderTasksInColumn(columnID, taskIDs); err != nil {
+	if err := s.taskRepo.ReorderTasksInCell(columnID, laneID, taskIDs); err != nil {
 		return fmt.Errorf("タスク順序更新エラー: %w", err)
 	}
 
 	return nil
 }
 
+// ListBoardTasks ボード内のタスクを条件で絞り込み・並び替えて取得します
+// queryのカスタムフィールド条件は文字列の値で受け取り、フィールド型に従って変換します
+func (s *taskService) ListBoardTasks(boardID uint, userID uuid.UUID, query repository.TaskQuery) ([]domain.Task, error) {
+	// ボードの所有権をチェック
+	if err := s.checkBoardAccess(boardID, userID); err != nil {
+		return nil, err
+	}
+	query.BoardIDs = []uint{boardID}
+
+	for _, p := range query.Priorities {
+		if !p.IsValid() {
+			return nil, fmt.Errorf("不正な優先度です: %s", p)
+		}
+	}
+
+	// カスタムフィールドの条件をフィールド型に従って変換
+	for i, filter := range query.CustomFields {
+		field, err := s.getBoardCustomField(boardID, filter.FieldID)
+		if err != nil {
+			return nil, err
+		}
+		raw, _ := filter.Value.(string)
+		parsed, err := parseCustomFieldFilter(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		query.CustomFields[i] = parsed
+	}
+
+	if query.SortBy == repository.TaskSortCustomField {
+		field, err := s.getBoardCustomField(boardID, query.SortFieldID)
+		if err != nil {
+			return nil, err
+		}
+		query.SortFieldType = field.Type
+	}
+
+	tasks, err := s.taskRepo.Search(query)
+	if err != nil {
+		return nil, fmt.Errorf("タスク検索エラー: %w", err)
+	}
+	return tasks, nil
+}
+
+// SearchTasks アクセス可能な全ボードを対象にタスクを検索します
+// cursorには前回の結果で返された次ページのカーソルを指定し、次ページがない場合は空文字を返します
+func (s *taskService) SearchTasks(userID uuid.UUID, query repository.TaskQuery, cursor string) ([]domain.Task, string, error) {
+	// ユーザーがアクセスできるボードに検索対象を限定
+	boards, err := s.boardRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	accessible := make(map[uint]bool, len(boards))
+	for _, board := range boards {
+		accessible[board.ID] = true
+	}
+
+	if len(query.BoardIDs) > 0 {
+		for _, boardID := range query.BoardIDs {
+			if !accessible[boardID] {
+				return nil, "", errors.New("このボードにアクセスする権限がありません")
+			}
+		}
+	} else {
+		for _, board := range boards {
+			query.BoardIDs = append(query.BoardIDs, board.ID)
+		}
+	}
+	if len(query.BoardIDs) == 0 {
+		return []domain.Task{}, "", nil
+	}
+
+	for _, p := range query.Priorities {
+		if !p.IsValid() {
+			return nil, "", fmt.Errorf("不正な優先度です: %s", p)
+		}
+	}
+
+	// カスタムフィールドの条件をフィールド型に従って変換
+	for i, filter := range query.CustomFields {
+		field, err := s.customFieldRepo.GetDefinitionByID(filter.FieldID)
+		if err != nil {
+			return nil, "", fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+		}
+		if field == nil || !accessible[field.BoardID] {
+			return nil, "", errors.New("カスタムフィールドが見つかりません")
+		}
+		raw, _ := filter.Value.(string)
+		parsed, err := parseCustomFieldFilter(field, raw)
+		if err != nil {
+			return nil, "", err
+		}
+		query.CustomFields[i] = parsed
+	}
+
+	// キーセットページネーションで扱える並び替えキーのみ許可
+	switch query.SortBy {
+	case "":
+		query.SortBy = repository.TaskSortCreatedAt
+	case repository.TaskSortCreatedAt, repository.TaskSortUpdatedAt, repository.TaskSortDueDate, repository.TaskSortPriority:
+	default:
+		return nil, "", fmt.Errorf("検索では並び替えキー %s は使用できません", query.SortBy)
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = DefaultTaskSearchLimit
+	}
+	if query.Limit > MaxTaskSearchLimit {
+		query.Limit = MaxTaskSearchLimit
+	}
+	limit := query.Limit
+
+	if cursor != "" {
+		after, err := decodeTaskCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		query.After = after
+	}
+
+	// 次ページの有無を判定するため1件多く取得する
+	query.Limit = limit + 1
+	tasks, err := s.taskRepo.Search(query)
+	if err != nil {
+		return nil, "", fmt.Errorf("タスク検索エラー: %w", err)
+	}
+
+	nextCursor := ""
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		nextCursor, err = encodeTaskCursor(repository.NewTaskCursor(&tasks[limit-1], query.SortBy))
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return tasks, nextCursor, nil
+}
+
+// encodeTaskCursor カーソルをURLで扱える文字列に変換します
+func encodeTaskCursor(cursor *repository.TaskCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("カーソル生成エラー: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeTaskCursor 文字列からカーソルを復元します
+func decodeTaskCursor(encoded string) (*repository.TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("不正なカーソルです")
+	}
+	var cursor repository.TaskCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, errors.New("不正なカーソルです")
+	}
+	return &cursor, nil
+}
+
+// getBoardCustomField ボードに定義されたカスタムフィールドを取得します
+func (s *taskService) getBoardCustomField(boardID, fieldID uint) (*domain.CustomFieldDefinition, error) {
+	field, err := s.customFieldRepo.GetDefinitionByID(fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+	}
+	if field == nil || field.BoardID != boardID {
+		return nil, errors.New("カスタムフィールドが見つかりません")
+	}
+	return field, nil
+}
+
+// checkLane レーンが存在し、カラムと同じボードに属することをチェックします
+func (s *taskService) checkLane(laneID, columnID uint) error {
+	lane, err := s.laneRepo.GetByID(laneID)
+	if err != nil {
+		return fmt.Errorf("レーン取得エラー: %w", err)
+	}
+	column, err := s.columnRepo.GetByID(columnID)
+	if err != nil {
+		return fmt.Errorf("カラム取得エラー: %w", err)
+	}
+	if lane == nil || column == nil || lane.BoardID != column.BoardID {
+		return errors.New("レーンが見つかりません")
+	}
+	return nil
+}
+
+// checkWIPLimit カラムにタスクを1件追加した場合のWIP制限をチェックします
+// hardモードで超える場合はWIPLimitErrorをerrorとして、softモードで超える場合は警告として返します
+func (s *taskService) checkWIPLimit(columnID uint) (*domain.WIPLimitError, error) {
+	return checkWIPLimit(s.columnRepo, columnID)
+}
+
+// checkWIPLimit カラムにタスクを1件追加した場合のWIP制限をチェックします（自動化ルール等と共通）
+// hardモードで超える場合はWIPLimitErrorをerrorとして、softモードで超える場合は警告として返します
+func checkWIPLimit(columnRepo repository.ColumnRepository, columnID uint) (*domain.WIPLimitError, error) {
+	column, err := columnRepo.GetByID(columnID)
+	if err != nil {
+		return nil, fmt.Errorf("カラム取得エラー: %w", err)
+	}
+	if column == nil {
+		return nil, errors.New("カラムが見つかりません")
+	}
+
+	wipErr := column.CheckWIPLimit(len(column.Tasks) + 1)
+	if wipErr != nil && wipErr.Mode == domain.WIPLimitModeHard {
+		return nil, wipErr
+	}
+	return wipErr, nil
+}
+
+// checkBoardAccess ボードへのアクセス権限をチェックします
+func (s *taskService) checkBoardAccess(boardID uint, userID uuid.UUID) error {
+	board, err := s.boardRepo.GetByID(boardID)
+	if err != nil {
+		return fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		return errors.New("ボードが見つかりません")
+	}
+
+	// ボードのワークスペースのメンバーかをチェック
+	member, err := isBoardMember(s.boardRepo, board.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return errors.New("このボードにアクセスする権限がありません")
+	}
+
+	return nil
+}
+
 // checkColumnAccess カラムへのアクセス権限をチェックします
 func (s *taskService) checkColumnAccess(columnID uint, userID uuid.UUID) error {
 	// カラムを取得
@@ -284,8 +641,12 @@ func (s *taskService) checkColumnAccess(columnID uint, userID uuid.UUID) error {
 		return errors.New("ボードが見つかりません")
 	}
 
-	// ボードの所有権をチェック
-	if board.OwnerID != userID {
+	// ボードのワークスペースのメンバーかをチェック
+	member, err := isBoardMember(s.boardRepo, board.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
 		return errors.New("このタスクにアクセスする権限がありません")
 	}
 
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskTransferPolicy ボードに属するデータ（ラベル・カスタムフィールド）の移動方針
+type TaskTransferPolicy string
+
+const (
+	TaskTransferMap  TaskTransferPolicy = "map"  // 移動先ボードの同名の定義に付け替え、見つからないものは外す
+	TaskTransferDrop TaskTransferPolicy = "drop" // すべて外す
+)
+
+// IsValid 定義済みの移動方針かどうかを判定します
+func (p TaskTransferPolicy) IsValid() bool {
+	return p == TaskTransferMap || p == TaskTransferDrop
+}
+
+// TaskTransferOptions 別のボードへ移動する際のオプション
+type TaskTransferOptions struct {
+	LabelPolicy       TaskTransferPolicy
+	CustomFieldPolicy TaskTransferPolicy
+}
+
+// TaskTransferResult 別のボードへの移動結果
+type TaskTransferResult struct {
+	Task                *domain.Task
+	Warning             *domain.WIPLimitError // WIP制限（soft）を超えた場合の警告
+	MappedLabels        []string              // 付け替えたラベル名
+	DroppedLabels       []string              // 外したラベル名
+	MappedCustomFields  []string              // 付け替えたカスタムフィールド名
+	DroppedCustomFields []string              // 外したカスタムフィールド名
+}
+
+// TaskTransferService タスクのボード間移動を管理するインターフェース
+type TaskTransferService interface {
+	MoveToBoard(taskID, targetColumnID uint, userID uuid.UUID, opts TaskTransferOptions) (*TaskTransferResult, error)
+}
+
+// taskTransferService TaskTransferServiceの実装
+type taskTransferService struct {
+	db       *gorm.DB // トランザクション用のデータベース接続
+	webhooks WebhookPublisher
+}
+
+// NewTaskTransferService TaskTransferServiceの新しいインスタンスを作成
+func NewTaskTransferService(db *gorm.DB, webhooks WebhookPublisher) TaskTransferService {
+	return &taskTransferService{db: db, webhooks: webhooks}
+}
+
+// MoveToBoard タスクを別のボードのカラムへ移動します
+// 移動元・移動先の両方のボードの所有権をチェックし、ラベルとカスタムフィールドは方針に従って付け替えるか外します
+// スイムレーンはボードごとの定義のため、移動先ではレーンなしのセルの末尾に追加します
+// コミット後に、移動元・移動先の両方のボードへtask.movedを通知します
+func (s *taskTransferService) MoveToBoard(taskID, targetColumnID uint, userID uuid.UUID, opts TaskTransferOptions) (*TaskTransferResult, error) {
+	if opts.LabelPolicy == "" {
+		opts.LabelPolicy = TaskTransferMap
+	}
+	if opts.CustomFieldPolicy == "" {
+		opts.CustomFieldPolicy = TaskTransferMap
+	}
+	if !opts.LabelPolicy.IsValid() || !opts.CustomFieldPolicy.IsValid() {
+		return nil, errors.New("移動方針は map または drop を指定してください")
+	}
+
+	var result *TaskTransferResult
+	var event map[string]interface{}
+	var sourceBoardID, targetBoardID uint
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		taskRepo := repository.NewTaskRepository(tx)
+		boardRepo := repository.NewBoardRepository(tx)
+		columnRepo := repository.NewColumnRepository(tx)
+		labelRepo := repository.NewLabelRepository(tx)
+		customFieldRepo := repository.NewCustomFieldRepository(tx)
+		activityRepo := repository.NewTaskActivityRepository(tx)
+
+		task, err := taskRepo.GetByID(taskID)
+		if err != nil {
+			return fmt.Errorf("タスク取得エラー: %w", err)
+		}
+		if task == nil {
+			return errors.New("タスクが見つかりません")
+		}
+		column, err := columnRepo.GetByID(targetColumnID)
+		if err != nil {
+			return fmt.Errorf("カラム取得エラー: %w", err)
+		}
+		if column == nil {
+			return errors.New("移動先のカラムが見つかりません")
+		}
+
+		sourceBoardID = task.Column.BoardID
+		targetBoardID = column.BoardID
+		if sourceBoardID == targetBoardID {
+			return errors.New("同じボード内の移動には通常の移動を使用してください")
+		}
+
+		// 移動元と移動先の両方のボードへのアクセス権をチェック
+		for _, boardID := range []uint{sourceBoardID, targetBoardID} {
+			board, err := boardRepo.GetByID(boardID)
+			if err != nil {
+				return fmt.Errorf("ボード取得エラー: %w", err)
+			}
+			if board == nil {
+				return errors.New("ボードが見つかりません")
+			}
+			member, err := isBoardMember(boardRepo, board.ID, userID)
+			if err != nil {
+				return err
+			}
+			if !member {
+				return errors.New("このボードにアクセスする権限がありません")
+			}
+		}
+
+		// WIP制限をチェック（hardはエラー、softは警告）
+		warning := column.CheckWIPLimit(len(column.Tasks) + 1)
+		if warning != nil && warning.Mode == domain.WIPLimitModeHard {
+			return warning
+		}
+
+		result = &TaskTransferResult{Warning: warning}
+
+		// ラベルを付け替え
+		var targetLabels []domain.Label
+		if opts.LabelPolicy == TaskTransferMap {
+			if targetLabels, err = labelRepo.GetByBoardID(targetBoardID); err != nil {
+				return fmt.Errorf("ラベル取得エラー: %w", err)
+			}
+		}
+		mappedLabels, droppedLabels := mapTransferLabels(task.Labels, targetLabels)
+		for _, label := range task.Labels {
+			if err := labelRepo.RemoveFromTask(task.ID, label.ID); err != nil {
+				return fmt.Errorf("ラベル解除エラー: %w", err)
+			}
+		}
+		for _, label := range mappedLabels {
+			if err := labelRepo.AddToTask(task.ID, label.ID); err != nil {
+				return fmt.Errorf("ラベル付与エラー: %w", err)
+			}
+			result.MappedLabels = append(result.MappedLabels, label.Name)
+		}
+		result.DroppedLabels = droppedLabels
+
+		// カスタムフィールドの値を付け替え
+		sourceFields, err := customFieldRepo.GetDefinitionsByBoardID(sourceBoardID)
+		if err != nil {
+			return fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+		}
+		var targetFields []domain.CustomFieldDefinition
+		if opts.CustomFieldPolicy == TaskTransferMap {
+			if targetFields, err = customFieldRepo.GetDefinitionsByBoardID(targetBoardID); err != nil {
+				return fmt.Errorf("カスタムフィールド取得エラー: %w", err)
+			}
+		}
+		mappedValues, mappedFields, droppedFields := mapTransferCustomFields(task.CustomFieldValues, sourceFields, targetFields)
+		for _, value := range task.CustomFieldValues {
+			if err := customFieldRepo.DeleteValue(task.ID, value.FieldID); err != nil {
+				return fmt.Errorf("カスタムフィールド値削除エラー: %w", err)
+			}
+		}
+		for i := range mappedValues {
+			mappedValues[i].TaskID = task.ID
+			if err := customFieldRepo.SaveValue(&mappedValues[i]); err != nil {
+				return fmt.Errorf("カスタムフィールド値保存エラー: %w", err)
+			}
+		}
+		result.MappedCustomFields = mappedFields
+		result.DroppedCustomFields = droppedFields
+
+		// 移動先カラムのレーンなしのセルの末尾に移動
+		order := 1
+		for _, t := range column.Tasks {
+			if t.LaneID == nil {
+				order++
+			}
+		}
+		if err := taskRepo.MoveToColumn(task.ID, column.ID, nil, order); err != nil {
+			var wipErr *domain.WIPLimitError
+			if errors.As(err, &wipErr) {
+				return wipErr
+			}
+			return fmt.Errorf("タスク移動エラー: %w", err)
+		}
+
+		// 履歴を記録
+		activity := &domain.TaskActivity{
+			TaskID: task.ID,
+			UserID: userID,
+			Action: domain.TaskActivityMovedBoard,
+			Details: domain.JSONMap{
+				"from_board_id":         sourceBoardID,
+				"to_board_id":           targetBoardID,
+				"from_column_id":        task.ColumnID,
+				"to_column_id":          column.ID,
+				"label_policy":          opts.LabelPolicy,
+				"custom_field_policy":   opts.CustomFieldPolicy,
+				"dropped_labels":        nonNilStrings(droppedLabels),
+				"dropped_custom_fields": nonNilStrings(droppedFields),
+			},
+		}
+		if err := activityRepo.Create(activity); err != nil {
+			return fmt.Errorf("履歴記録エラー: %w", err)
+		}
+
+		if result.Task, err = taskRepo.GetByID(task.ID); err != nil {
+			return fmt.Errorf("タスク取得エラー: %w", err)
+		}
+		if result.Task != nil {
+			event = newTaskMovedData(result.Task, task.ColumnID, task.LaneID)
+			event["from_board_id"] = sourceBoardID
+			event["to_board_id"] = targetBoardID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if event != nil {
+		s.webhooks.Publish(sourceBoardID, domain.WebhookEventTaskMoved, event)
+		s.webhooks.Publish(targetBoardID, domain.WebhookEventTaskMoved, event)
+	}
+	return result, nil
+}
+
+// mapTransferLabels タスクのラベルを移動先ボードの同名のラベル（大文字小文字は区別しない）に対応付けます
+// 対応するラベルがないものは外すラベルとして名前を返します
+func mapTransferLabels(labels, targetLabels []domain.Label) ([]domain.Label, []string) {
+	byName := make(map[string]domain.Label, len(targetLabels))
+	for _, label := range targetLabels {
+		byName[transferKey(label.Name)] = label
+	}
+
+	var mapped []domain.Label
+	var dropped []string
+	for _, label := range labels {
+		if target, ok := byName[transferKey(label.Name)]; ok {
+			mapped = append(mapped, target)
+		} else {
+			dropped = append(dropped, label.Name)
+		}
+	}
+	return mapped, dropped
+}
+
+// mapTransferCustomFields タスクのカスタムフィールド値を移動先ボードの同名・同じ型のフィールドに対応付けます
+// 選択肢型は移動先に存在する選択肢だけを残し、1つも残らない場合は外します
+// 付け替えた値と、付け替えた・外したフィールド名を返します
+func mapTransferCustomFields(values []domain.TaskCustomFieldValue, sourceFields, targetFields []domain.CustomFieldDefinition) ([]domain.TaskCustomFieldValue, []string, []string) {
+	sources := make(map[uint]domain.CustomFieldDefinition, len(sourceFields))
+	for _, field := range sourceFields {
+		sources[field.ID] = field
+	}
+	targets := make(map[string]domain.CustomFieldDefinition, len(targetFields))
+	for _, field := range targetFields {
+		targets[string(field.Type)+":"+transferKey(field.Name)] = field
+	}
+
+	var mappedValues []domain.TaskCustomFieldValue
+	var mapped, dropped []string
+	for _, value := range values {
+		source, ok := sources[value.FieldID]
+		if !ok {
+			// 定義が削除済みの値はそのまま外す
+			continue
+		}
+		target, ok := targets[string(source.Type)+":"+transferKey(source.Name)]
+		if !ok {
+			dropped = append(dropped, source.Name)
+			continue
+		}
+
+		newValue := domain.TaskCustomFieldValue{
+			FieldID:     target.ID,
+			TextValue:   value.TextValue,
+			NumberValue: value.NumberValue,
+			DateValue:   value.DateValue,
+		}
+		if target.Type.IsSelect() {
+			for _, option := range value.OptionValues {
+				if target.Options.Contains(option) {
+					newValue.OptionValues = append(newValue.OptionValues, option)
+				}
+			}
+			if len(newValue.OptionValues) == 0 {
+				dropped = append(dropped, source.Name)
+				continue
+			}
+		}
+
+		mappedValues = append(mappedValues, newValue)
+		mapped = append(mapped, source.Name)
+	}
+	return mappedValues, mapped, dropped
+}
+
+// transferKey ボード間で名前を対応付けるためのキーを返します
+func transferKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// nonNilStrings JSONで null ではなく空配列として保存するために nil を空スライスに置き換えます
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
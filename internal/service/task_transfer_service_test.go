@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ボード間移動時のカスタムフィールド値の対応付けテスト
+func TestMapTransferCustomFields(t *testing.T) {
+	text := "メモ"
+	points := 3.0
+
+	sourceFields := []domain.CustomFieldDefinition{
+		{ID: 1, Name: "メモ", Type: domain.CustomFieldTypeText},
+		{ID: 2, Name: "Points", Type: domain.CustomFieldTypeNumber},
+		{ID: 3, Name: "環境", Type: domain.CustomFieldTypeMultiSelect, Options: domain.StringList{"dev", "stg", "prod"}},
+		{ID: 4, Name: "チーム", Type: domain.CustomFieldTypeSingleSelect, Options: domain.StringList{"A", "B"}},
+	}
+	targetFields := []domain.CustomFieldDefinition{
+		{ID: 11, Name: "メモ", Type: domain.CustomFieldTypeText},
+		{ID: 12, Name: " points ", Type: domain.CustomFieldTypeNumber},
+		{ID: 13, Name: "環境", Type: domain.CustomFieldTypeMultiSelect, Options: domain.StringList{"stg", "prod"}},
+		{ID: 14, Name: "チーム", Type: domain.CustomFieldTypeSingleSelect, Options: domain.StringList{"C"}},
+	}
+	values := []domain.TaskCustomFieldValue{
+		{FieldID: 1, TextValue: &text},
+		{FieldID: 2, NumberValue: &points},
+		{FieldID: 3, OptionValues: domain.StringList{"dev", "prod"}},
+		{FieldID: 4, OptionValues: domain.StringList{"A"}},
+	}
+
+	mappedValues, mapped, dropped := mapTransferCustomFields(values, sourceFields, targetFields)
+
+	assert.Equal(t, []string{"メモ", "Points", "環境"}, mapped)
+	assert.Equal(t, []string{"チーム"}, dropped)
+	assert.Len(t, mappedValues, 3)
+	assert.Equal(t, uint(11), mappedValues[0].FieldID)
+	assert.Equal(t, &text, mappedValues[0].TextValue)
+	assert.Equal(t, uint(12), mappedValues[1].FieldID)
+	assert.Equal(t, uint(13), mappedValues[2].FieldID)
+	assert.Equal(t, domain.StringList{"prod"}, mappedValues[2].OptionValues)
+
+	// drop方針では移動先の定義を渡さないため、すべて外れる
+	mappedValues, mapped, dropped = mapTransferCustomFields(values, sourceFields, nil)
+	assert.Empty(t, mappedValues)
+	assert.Empty(t, mapped)
+	assert.Len(t, dropped, 4)
+}
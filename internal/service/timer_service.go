@@ -24,16 +24,19 @@ type TimerService interface {
 type timerService struct {
 	timerSessionRepo repository.TimerSessionRepository
 	taskRepo         repository.TaskRepository
+	automation       AutomationRunner
 }
 
 // NewTimerService タイマーサービスのコンストラクタ
 func NewTimerService(
 	timerSessionRepo repository.TimerSessionRepository,
 	taskRepo repository.TaskRepository,
+	automation AutomationRunner,
 ) TimerService {
 	return &timerService{
 		timerSessionRepo: timerSessionRepo,
 		taskRepo:         taskRepo,
+		automation:       automation,
 	}
 }
 
@@ -110,6 +113,11 @@ func (s *timerService) StopTimer(userID uuid.UUID, sessionID uint) (*domain.Time
 		// TODO: ログ機能実装時にログ出力を追加
 	}
 
+	// タイマー停止の自動化ルールを実行
+	if task, err := s.taskRepo.GetByID(session.TaskID); err == nil && task != nil {
+		s.automation.TimerStopped(task)
+	}
+
 	return session, nil
 }
 
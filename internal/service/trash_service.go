@@ -0,0 +1,287 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Trash ゴミ箱の内容
+type Trash struct {
+	Boards  []domain.Board
+	Columns []domain.Column
+	Tasks   []domain.Task
+}
+
+// TrashService ゴミ箱（削除済みデータの一覧・復元・完全削除）を管理するインターフェース
+type TrashService interface {
+	GetUserTrash(userID uuid.UUID) (*Trash, error)
+	GetBoardTrash(boardID uint, userID uuid.UUID) (*Trash, error)
+	RestoreBoard(boardID uint, userID uuid.UUID) (*domain.Board, error)
+	RestoreColumn(columnID uint, userID uuid.UUID) (*domain.Column, error)
+	RestoreTask(taskID uint, userID uuid.UUID) (*domain.Task, error)
+	PurgeExpired(now time.Time) (*repository.TrashPurgeResult, error)
+	StartRetentionJob(interval time.Duration) func()
+}
+
+// trashService TrashServiceの実装
+type trashService struct {
+	trashRepo     repository.TrashRepository
+	boardRepo     repository.BoardRepository
+	workspaces    repository.WorkspaceRepository
+	columnRepo    repository.ColumnRepository
+	taskRepo      repository.TaskRepository
+	webhooks      WebhookPublisher
+	retentionDays int // 0以下の場合は完全削除しない
+}
+
+// NewTrashService TrashServiceの新しいインスタンスを作成
+func NewTrashService(
+	trashRepo repository.TrashRepository,
+	boardRepo repository.BoardRepository,
+	workspaces repository.WorkspaceRepository,
+	columnRepo repository.ColumnRepository,
+	taskRepo repository.TaskRepository,
+	webhooks WebhookPublisher,
+	retentionDays int,
+) TrashService {
+	return &trashService{
+		trashRepo:     trashRepo,
+		boardRepo:     boardRepo,
+		workspaces:    workspaces,
+		columnRepo:    columnRepo,
+		taskRepo:      taskRepo,
+		webhooks:      webhooks,
+		retentionDays: retentionDays,
+	}
+}
+
+// GetUserTrash ユーザーのワークスペースの削除済みボードと、アクセスできる全ボードの削除済みカラム・タスクを取得します
+func (s *trashService) GetUserTrash(userID uuid.UUID) (*Trash, error) {
+	deletedBoards, err := s.trashRepo.GetDeletedBoards(userID)
+	if err != nil {
+		return nil, fmt.Errorf("削除済みボード取得エラー: %w", err)
+	}
+
+	boards, err := s.boardRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	boardIDs := make([]uint, 0, len(boards))
+	for _, board := range boards {
+		boardIDs = append(boardIDs, board.ID)
+	}
+
+	trash, err := s.getTrash(boardIDs)
+	if err != nil {
+		return nil, err
+	}
+	trash.Boards = deletedBoards
+	return trash, nil
+}
+
+// GetBoardTrash ボードの削除済みカラム・タスクを取得します
+func (s *trashService) GetBoardTrash(boardID uint, userID uuid.UUID) (*Trash, error) {
+	if _, err := s.getOwnedBoard(boardID, userID); err != nil {
+		return nil, err
+	}
+	return s.getTrash([]uint{boardID})
+}
+
+// RestoreBoard 削除済みのボードを復元します（削除と同じく、ボードのワークスペースの管理者のみ）
+func (s *trashService) RestoreBoard(boardID uint, userID uuid.UUID) (*domain.Board, error) {
+	board, err := s.trashRepo.GetDeletedBoard(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		return nil, errors.New("ゴミ箱にボードが見つかりません")
+	}
+	member, err := isBoardMember(s.boardRepo, board.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, errors.New("このボードにアクセスする権限がありません")
+	}
+	if err := checkWorkspaceAdmin(s.workspaces, board.WorkspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.trashRepo.RestoreBoard(boardID); err != nil {
+		return nil, fmt.Errorf("ボード復元エラー: %w", err)
+	}
+
+	restored, err := s.boardRepo.GetByID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if restored != nil {
+		s.webhooks.Publish(boardID, domain.WebhookEventBoardRestored, restored)
+	}
+	return restored, nil
+}
+
+// RestoreColumn 削除済みのカラムを復元します（ボードが削除されている場合は先にボードの復元が必要です）
+func (s *trashService) RestoreColumn(columnID uint, userID uuid.UUID) (*domain.Column, error) {
+	column, err := s.trashRepo.GetDeletedColumn(columnID)
+	if err != nil {
+		return nil, fmt.Errorf("カラム取得エラー: %w", err)
+	}
+	if column == nil {
+		return nil, errors.New("ゴミ箱にカラムが見つかりません")
+	}
+	if _, err := s.getOwnedBoard(column.BoardID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.trashRepo.RestoreColumn(columnID); err != nil {
+		return nil, fmt.Errorf("カラム復元エラー: %w", err)
+	}
+
+	restored, err := s.columnRepo.GetByID(columnID)
+	if err != nil {
+		return nil, fmt.Errorf("カラム取得エラー: %w", err)
+	}
+	if restored != nil {
+		s.webhooks.Publish(restored.BoardID, domain.WebhookEventColumnRestored, restored)
+	}
+	return restored, nil
+}
+
+// RestoreTask 削除済みのタスクを復元します
+// 元のカラムが削除されている場合は、ボードの先頭のカラムに復元します
+func (s *trashService) RestoreTask(taskID uint, userID uuid.UUID) (*domain.Task, error) {
+	task, err := s.trashRepo.GetDeletedTask(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("タスク取得エラー: %w", err)
+	}
+	if task == nil || task.Column.ID == 0 {
+		return nil, errors.New("ゴミ箱にタスクが見つかりません")
+	}
+	if _, err := s.getOwnedBoard(task.Column.BoardID, userID); err != nil {
+		return nil, err
+	}
+
+	columnID := task.ColumnID
+	if task.Column.DeletedAt.Valid {
+		columns, err := s.columnRepo.GetByBoardID(task.Column.BoardID)
+		if err != nil {
+			return nil, fmt.Errorf("カラム取得エラー: %w", err)
+		}
+		if len(columns) == 0 {
+			return nil, errors.New("復元先のカラムがありません。先にカラムを復元してください")
+		}
+		columnID = columns[0].ID
+	}
+
+	if err := s.trashRepo.RestoreTask(taskID, columnID); err != nil {
+		return nil, fmt.Errorf("タスク復元エラー: %w", err)
+	}
+
+	restored, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("タスク取得エラー: %w", err)
+	}
+	if restored != nil {
+		s.webhooks.Publish(restored.Column.BoardID, domain.WebhookEventTaskRestored, restored)
+	}
+	return restored, nil
+}
+
+// PurgeExpired 保持期間を過ぎた削除済みデータを完全に削除します
+func (s *trashService) PurgeExpired(now time.Time) (*repository.TrashPurgeResult, error) {
+	if s.retentionDays <= 0 {
+		return &repository.TrashPurgeResult{}, nil
+	}
+
+	cutoff := now.AddDate(0, 0, -s.retentionDays)
+	result, err := s.trashRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("ゴミ箱の完全削除エラー: %w", err)
+	}
+	return result, nil
+}
+
+// StartRetentionJob 保持期間を過ぎたデータを定期的に完全削除するジョブを開始します
+// 戻り値の関数を呼び出すとジョブを停止します
+func (s *trashService) StartRetentionJob(interval time.Duration) func() {
+	done := make(chan struct{})
+	if s.retentionDays <= 0 || interval <= 0 {
+		return func() {}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			result, err := s.PurgeExpired(time.Now())
+			if err != nil {
+				log.Printf("ゴミ箱の完全削除に失敗しました: %v", err)
+			} else if result.Boards+result.Columns+result.Tasks > 0 {
+				log.Printf("ゴミ箱を完全削除しました: ボード%d件, カラム%d件, タスク%d件", result.Boards, result.Columns, result.Tasks)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// getTrash ボードの削除済みカラム・タスクを取得します
+func (s *trashService) getTrash(boardIDs []uint) (*Trash, error) {
+	columns, err := s.trashRepo.GetDeletedColumns(boardIDs)
+	if err != nil {
+		return nil, fmt.Errorf("削除済みカラム取得エラー: %w", err)
+	}
+	tasks, err := s.trashRepo.GetDeletedTasks(boardIDs)
+	if err != nil {
+		return nil, fmt.Errorf("削除済みタスク取得エラー: %w", err)
+	}
+	return &Trash{Columns: columns, Tasks: tasks}, nil
+}
+
+// getOwnedBoard 削除されていない、アクセスできるボードを取得します
+func (s *trashService) getOwnedBoard(boardID uint, userID uuid.UUID) (*domain.Board, error) {
+	board, err := s.boardRepo.GetByID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		// ボード自体がゴミ箱にある場合は、先にボードの復元が必要
+		deleted, err := s.trashRepo.GetDeletedBoard(boardID)
+		if err != nil {
+			return nil, fmt.Errorf("ボード取得エラー: %w", err)
+		}
+		if deleted != nil {
+			member, err := isBoardMember(s.boardRepo, deleted.ID, userID)
+			if err != nil {
+				return nil, err
+			}
+			if member {
+				return nil, errors.New("ボードがゴミ箱にあります。先にボードを復元してください")
+			}
+		}
+		return nil, errors.New("ボードが見つかりません")
+	}
+	member, err := isBoardMember(s.boardRepo, board.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, errors.New("このボードにアクセスする権限がありません")
+	}
+	return board, nil
+}
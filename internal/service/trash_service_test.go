@@ -0,0 +1,156 @@
+package service
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryTrashRepository テスト用のメモリ上のTrashRepository（使用するメソッドのみ実装）
+type memoryTrashRepository struct {
+	repository.TrashRepository
+	deletedBoards map[uint]*domain.Board
+	deletedTasks  map[uint]*domain.Task // カラム（削除済みを含む）を読み込んだ削除済みのタスク
+	tasks         *memoryTaskRepository // タスクの復元先
+	purgedBefore  []time.Time
+}
+
+func (r *memoryTrashRepository) GetDeletedTask(id uint) (*domain.Task, error) {
+	task, ok := r.deletedTasks[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *task
+	return &copied, nil
+}
+
+func (r *memoryTrashRepository) GetDeletedBoard(id uint) (*domain.Board, error) {
+	board, ok := r.deletedBoards[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *board
+	return &copied, nil
+}
+
+func (r *memoryTrashRepository) RestoreBoard(id uint) error {
+	delete(r.deletedBoards, id)
+	return nil
+}
+
+// RestoreTask 削除済みのタスクを指定したカラムに戻します
+func (r *memoryTrashRepository) RestoreTask(id uint, columnID uint) error {
+	task := *r.deletedTasks[id]
+	delete(r.deletedTasks, id)
+	task.ColumnID, task.Column = columnID, domain.Column{}
+	r.tasks.tasks[id] = &task
+	return nil
+}
+
+func (r *memoryTrashRepository) PurgeDeletedBefore(cutoff time.Time) (*repository.TrashPurgeResult, error) {
+	r.purgedBefore = append(r.purgedBefore, cutoff)
+	return &repository.TrashPurgeResult{}, nil
+}
+
+// GetByBoardID 削除されていないボードのカラムを表示順に返します
+func (r *memoryColumnRepository) GetByBoardID(boardID uint) ([]domain.Column, error) {
+	var columns []domain.Column
+	for _, column := range r.columns {
+		if column.BoardID == boardID {
+			columns = append(columns, *column)
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Order < columns[j].Order })
+	return columns, nil
+}
+
+// 元のカラムへの復元、元のカラムが削除されている場合の先頭のカラムへの復元、権限のチェックのテスト
+func TestTrashService_RestoreTask(t *testing.T) {
+	owner := uuid.New()
+	boards, columns, tasks := newTestKanban(owner,
+		domain.Column{ID: 1, BoardID: 1, Title: "Doing", Order: 2},
+		domain.Column{ID: 2, BoardID: 1, Title: "To Do", Order: 1},
+	)
+	deletedColumn := domain.Column{ID: 3, BoardID: 1, Title: "削除したカラム", DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	trash := &memoryTrashRepository{
+		deletedTasks: map[uint]*domain.Task{
+			10: {ID: 10, ColumnID: 1, Title: "元のカラムがある", Column: *columns.columns[1]},
+			11: {ID: 11, ColumnID: 3, Title: "元のカラムが削除済み", Column: deletedColumn},
+		},
+		tasks: tasks,
+	}
+	webhooks := &recordingWebhookPublisher{}
+	svc := NewTrashService(trash, boards, nil, columns, tasks, webhooks, 30)
+
+	// 他のユーザーは復元できない
+	_, err := svc.RestoreTask(10, uuid.New())
+	assert.Error(t, err)
+	assert.Contains(t, trash.deletedTasks, uint(10))
+
+	restored, err := svc.RestoreTask(10, owner)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), restored.ColumnID)
+
+	// 元のカラムが削除されている場合は、表示順で先頭のカラムに復元する
+	restored, err = svc.RestoreTask(11, owner)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), restored.ColumnID)
+
+	assert.Equal(t, []domain.WebhookEvent{domain.WebhookEventTaskRestored, domain.WebhookEventTaskRestored}, webhooks.events)
+
+	// ゴミ箱にないタスクは復元できない
+	_, err = svc.RestoreTask(10, owner)
+	assert.Error(t, err)
+}
+
+// ボードの復元は、削除と同じくワークスペースの管理者のみができることのテスト
+func TestTrashService_RestoreBoard(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	workspaces := &memoryWorkspaceRepository{
+		workspaces: make(map[uint]*domain.Workspace),
+		members:    make(map[uint]map[uuid.UUID]*domain.WorkspaceMember),
+	}
+	team := &domain.Workspace{Name: "開発チーム", Members: []domain.WorkspaceMember{{UserID: alice, Role: domain.WorkspaceRoleAdmin}}}
+	require.NoError(t, workspaces.Create(team))
+	require.NoError(t, workspaces.AddMember(&domain.WorkspaceMember{WorkspaceID: team.ID, UserID: bob, Role: domain.WorkspaceRoleMember}))
+
+	// BoardRepositoryのアクセス権の判定は削除済みのボードも対象にする
+	board := &domain.Board{ID: 1, Name: "チームのボード", WorkspaceID: team.ID, OwnerID: bob}
+	boards := &workspaceBoardRepository{workspaces: workspaces, boards: map[uint]*domain.Board{1: board}}
+	trash := &memoryTrashRepository{deletedBoards: map[uint]*domain.Board{1: board}}
+	webhooks := &recordingWebhookPublisher{}
+	svc := NewTrashService(trash, boards, workspaces, nil, nil, webhooks, 30)
+
+	_, err := svc.RestoreBoard(1, bob)
+	assert.Error(t, err, "メンバーは復元できない")
+	assert.Contains(t, trash.deletedBoards, uint(1))
+
+	restored, err := svc.RestoreBoard(1, alice)
+	require.NoError(t, err)
+	assert.Equal(t, "チームのボード", restored.Name)
+	assert.NotContains(t, trash.deletedBoards, uint(1))
+	assert.Equal(t, []domain.WebhookEvent{domain.WebhookEventBoardRestored}, webhooks.events)
+}
+
+// 保持期間を過ぎたデータのみを完全削除し、保持期間が0以下の場合は削除しないことのテスト
+func TestTrashService_PurgeExpired(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	trash := &memoryTrashRepository{}
+	_, err := NewTrashService(trash, nil, nil, nil, nil, &recordingWebhookPublisher{}, 30).PurgeExpired(now)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{now.AddDate(0, 0, -30)}, trash.purgedBefore)
+
+	trash = &memoryTrashRepository{}
+	_, err = NewTrashService(trash, nil, nil, nil, nil, &recordingWebhookPublisher{}, 0).PurgeExpired(now)
+	require.NoError(t, err)
+	assert.Empty(t, trash.purgedBefore)
+}
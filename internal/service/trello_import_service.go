@@ -0,0 +1,282 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TrelloImportIssue Trelloからのインポートで、そのまま取り込めなかった項目
+type TrelloImportIssue struct {
+	Item   string `json:"item"` // 項目の種類（list / card / label / checklist / comment / attachment / member / custom_field）
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// TrelloImportReport Trelloからのインポート結果
+type TrelloImportReport struct {
+	Board           *domain.Board
+	Columns         int                 // 作成したカラム数（アーカイブ済みを含む）
+	Tasks           int                 // 作成したタスク数（アーカイブ済みを含む）
+	Labels          int                 // 作成したラベル数
+	Checklists      int                 // タスクの説明に追記したチェックリスト数
+	ArchivedColumns int                 // アーカイブ済みのためゴミ箱に入れたカラム数
+	ArchivedTasks   int                 // アーカイブ済みのためゴミ箱に入れたタスク数
+	Unmapped        []TrelloImportIssue // 取り込めなかった項目
+}
+
+// TrelloImportService Trelloのボードのインポートを管理するインターフェース
+type TrelloImportService interface {
+	ImportTrelloBoard(userID uuid.UUID, board *domain.TrelloBoard) (*TrelloImportReport, error)
+}
+
+// trelloImportService TrelloImportServiceの実装
+type trelloImportService struct {
+	db *gorm.DB // インポート用のデータベース接続
+}
+
+// NewTrelloImportService TrelloImportServiceの新しいインスタンスを作成
+func NewTrelloImportService(db *gorm.DB) TrelloImportService {
+	return &trelloImportService{db: db}
+}
+
+// ImportTrelloBoard TrelloのボードのJSONエクスポートから新しいボードを作成します
+// リストはカラム、カードはタスクとして作成し、アーカイブ済みのリスト・カードはゴミ箱に入れます
+func (s *trelloImportService) ImportTrelloBoard(userID uuid.UUID, board *domain.TrelloBoard) (*TrelloImportReport, error) {
+	conversion := convertTrelloBoard(board)
+	if err := conversion.export.Validate(); err != nil {
+		return nil, err
+	}
+
+	report := &TrelloImportReport{
+		Columns:         len(conversion.export.Board.Columns),
+		Tasks:           len(conversion.export.Board.Tasks),
+		Labels:          len(conversion.export.Board.Labels),
+		Checklists:      conversion.checklists,
+		ArchivedColumns: len(conversion.archivedColumns),
+		ArchivedTasks:   len(conversion.archivedTasks),
+		Unmapped:        conversion.issues,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		imported, err := importBoard(tx, userID, &conversion.export.Board)
+		if err != nil {
+			return err
+		}
+		report.Board = imported.result.Board
+
+		taskRepo := repository.NewTaskRepository(tx)
+		for _, id := range conversion.archivedTasks {
+			if err := taskRepo.Delete(imported.taskIDs[id], domain.AnyVersion); err != nil {
+				return fmt.Errorf("タスク削除エラー: %w", err)
+			}
+		}
+		columnRepo := repository.NewColumnRepository(tx)
+		for _, id := range conversion.archivedColumns {
+			if err := columnRepo.Delete(imported.columnIDs[id]); err != nil {
+				return fmt.Errorf("カラム削除エラー: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// trelloConversion TrelloのボードをエクスポートファイルのBoardExportに変換した結果
+type trelloConversion struct {
+	export          *domain.BoardExport
+	archivedColumns []uint // アーカイブ済みのリストから作成するカラム（ファイル内のID）
+	archivedTasks   []uint // アーカイブ済みのカードから作成するタスク（ファイル内のID）
+	checklists      int
+	issues          []TrelloImportIssue
+}
+
+// convertTrelloBoard TrelloのボードをエクスポートファイルのBoardExportに変換します
+// チェックリストはタスクの説明にMarkdownのチェックリストとして追記し、取り込めない項目はissuesに記録します
+func convertTrelloBoard(board *domain.TrelloBoard) *trelloConversion {
+	conversion := &trelloConversion{issues: []TrelloImportIssue{}}
+	addIssue := func(item, name, reason string) {
+		conversion.issues = append(conversion.issues, TrelloImportIssue{Item: item, Name: name, Reason: reason})
+	}
+
+	name := board.Name
+	if name == "" {
+		name = "Trello からのインポート"
+	}
+	exported := domain.ExportedBoard{
+		Name:         name,
+		Columns:      []domain.ExportedColumn{},
+		Lanes:        []domain.ExportedLane{},
+		Labels:       []domain.ExportedLabel{},
+		CustomFields: []domain.ExportedCustomField{},
+		Tasks:        []domain.ExportedTask{},
+	}
+
+	// リスト → カラム（表示位置順）
+	lists := append([]domain.TrelloList(nil), board.Lists...)
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+	columnIDs := make(map[string]uint, len(lists))
+	for i, list := range lists {
+		id := uint(i + 1)
+		exported.Columns = append(exported.Columns, domain.ExportedColumn{
+			ID:    id,
+			Title: untitled(list.Name),
+			Order: i + 1,
+		})
+		columnIDs[list.ID] = id
+		if list.Closed {
+			conversion.archivedColumns = append(conversion.archivedColumns, id)
+		}
+	}
+
+	// ラベル（名前のないラベルは色名を名前にする）
+	labelIDs := make(map[string]uint, len(board.Labels))
+	for i, label := range board.Labels {
+		id := uint(i + 1)
+		labelName := label.Name
+		if labelName == "" {
+			labelName = untitled(label.Color)
+		}
+		color, ok := trelloLabelColor(label.Color)
+		if !ok {
+			addIssue("label", labelName, fmt.Sprintf("色「%s」に対応する色がないため、既定の色にしました", label.Color))
+		}
+		exported.Labels = append(exported.Labels, domain.ExportedLabel{ID: id, Name: labelName, Color: color})
+		labelIDs[label.ID] = id
+	}
+
+	// チェックリスト（カードごと、表示位置順）
+	checklistsByCard := make(map[string][]domain.TrelloChecklist)
+	for _, checklist := range board.Checklists {
+		checklistsByCard[checklist.IDCard] = append(checklistsByCard[checklist.IDCard], checklist)
+	}
+
+	// カードごとのコメント数
+	comments := make(map[string]int)
+	for _, action := range board.Actions {
+		if action.Type == "commentCard" {
+			comments[action.Data.Card.ID]++
+		}
+	}
+
+	// カード → タスク（リストの表示位置順、リスト内の表示位置順）
+	listOrder := make(map[string]int, len(lists))
+	for i, list := range lists {
+		listOrder[list.ID] = i
+	}
+	cards := append([]domain.TrelloCard(nil), board.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool {
+		if listOrder[cards[i].IDList] != listOrder[cards[j].IDList] {
+			return listOrder[cards[i].IDList] < listOrder[cards[j].IDList]
+		}
+		return cards[i].Pos < cards[j].Pos
+	})
+	for _, card := range cards {
+		title := untitled(card.Name)
+		columnID, ok := columnIDs[card.IDList]
+		if !ok {
+			addIssue("card", title, "カードのリストが見つからないため、インポートしませんでした")
+			continue
+		}
+
+		id := uint(len(exported.Tasks) + 1)
+		task := domain.ExportedTask{
+			ID:          id,
+			ColumnID:    columnID,
+			Title:       title,
+			Description: card.Desc,
+			DueDate:     card.Due,
+			IsCompleted: card.DueComplete,
+			LabelIDs:    []uint{},
+		}
+		for _, idLabel := range card.IDLabels {
+			if labelID, ok := labelIDs[idLabel]; ok {
+				task.LabelIDs = append(task.LabelIDs, labelID)
+			}
+		}
+
+		checklists := checklistsByCard[card.ID]
+		sort.SliceStable(checklists, func(i, j int) bool { return checklists[i].Pos < checklists[j].Pos })
+		for _, checklist := range checklists {
+			task.Description = appendTrelloChecklist(task.Description, checklist)
+			conversion.checklists++
+			addIssue("checklist", fmt.Sprintf("%s / %s", title, checklist.Name), "チェックリスト機能がないため、タスクの説明にMarkdownのチェックリストとして追記しました")
+		}
+
+		if len(card.IDMembers) > 0 {
+			addIssue("member", title, "Trelloのエクスポートにはメンバーのメールアドレスが含まれないため、担当者を割り当てていません")
+		}
+		if count := comments[card.ID]; count > 0 {
+			addIssue("comment", title, fmt.Sprintf("コメント機能がないため、%d件のコメントをインポートしませんでした", count))
+		}
+		if card.Badges.Attachments > 0 {
+			addIssue("attachment", title, fmt.Sprintf("添付ファイル機能がないため、%d件の添付ファイルをインポートしませんでした", card.Badges.Attachments))
+		}
+
+		exported.Tasks = append(exported.Tasks, task)
+		if card.Closed {
+			conversion.archivedTasks = append(conversion.archivedTasks, id)
+		}
+	}
+
+	for _, field := range board.CustomFields {
+		addIssue("custom_field", field.Name, "Trelloのカスタムフィールド（Power-Up）はインポートしませんでした")
+	}
+
+	conversion.export = &domain.BoardExport{
+		Format:  domain.BoardExportFormat,
+		Version: domain.BoardExportVersion,
+		Board:   exported,
+	}
+	return conversion
+}
+
+// appendTrelloChecklist タスクの説明の末尾にチェックリストをMarkdownで追記します
+func appendTrelloChecklist(description string, checklist domain.TrelloChecklist) string {
+	items := append([]domain.TrelloChecklistItem(nil), checklist.CheckItems...)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(description, "\n"))
+	if b.Len() > 0 {
+		b.WriteString("\n\n")
+	}
+	b.WriteString("### " + untitled(checklist.Name) + "\n")
+	for _, item := range items {
+		mark := " "
+		if item.State == "complete" {
+			mark = "x"
+		}
+		b.WriteString(fmt.Sprintf("- [%s] %s\n", mark, item.Name))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// trelloLabelColor Trelloのラベルの色名に対応する色コードを返します
+// green_dark などの濃淡のある色は元の色として扱い、色がない場合や不明な色の場合は空文字列（既定の色）を返します
+func trelloLabelColor(color string) (string, bool) {
+	if color == "" {
+		return "", true
+	}
+	base := strings.SplitN(color, "_", 2)[0]
+	hex, ok := domain.TrelloLabelColors[base]
+	return hex, ok
+}
+
+// untitled 名前が空の場合に「（無題）」を返します
+func untitled(name string) string {
+	if strings.TrimSpace(name) == "" {
+		return "（無題）"
+	}
+	return name
+}
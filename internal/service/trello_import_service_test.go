@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertTrelloBoard(t *testing.T) {
+	due := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	board := &domain.TrelloBoard{
+		Name: "ロードマップ",
+		Lists: []domain.TrelloList{
+			{ID: "l-done", Name: "Done", Pos: 300},
+			{ID: "l-todo", Name: "To Do", Pos: 100},
+			{ID: "l-old", Name: "Old", Pos: 200, Closed: true},
+		},
+		Labels: []domain.TrelloLabel{
+			{ID: "lb-1", Name: "Bug", Color: "red_dark"},
+			{ID: "lb-2", Color: "green"},
+		},
+		Cards: []domain.TrelloCard{
+			{ID: "c-2", Name: "後", IDList: "l-todo", Pos: 2, Closed: true},
+			{ID: "c-1", Name: "先", Desc: "説明", IDList: "l-todo", Pos: 1, Due: &due, DueComplete: true,
+				IDLabels: []string{"lb-1", "lb-2"}, IDMembers: []string{"m-1"}},
+			{ID: "c-3", Name: "迷子", IDList: "l-missing"},
+		},
+		Checklists: []domain.TrelloChecklist{
+			{IDCard: "c-1", Name: "手順", CheckItems: []domain.TrelloChecklistItem{
+				{Name: "二", State: "incomplete", Pos: 2},
+				{Name: "一", State: "complete", Pos: 1},
+			}},
+		},
+		Actions: []domain.TrelloAction{{Type: "commentCard"}, {Type: "updateCard"}},
+	}
+	board.Actions[0].Data.Card.ID = "c-1"
+
+	conversion := convertTrelloBoard(board)
+	require.NoError(t, conversion.export.Validate())
+
+	exported := conversion.export.Board
+	require.Len(t, exported.Columns, 3)
+	assert.Equal(t, "To Do", exported.Columns[0].Title, "リストは表示位置順に並ぶ")
+	assert.Equal(t, []uint{2}, conversion.archivedColumns)
+
+	assert.Equal(t, "#EB5A46", exported.Labels[0].Color, "濃淡のある色は元の色になる")
+	assert.Equal(t, "green", exported.Labels[1].Name, "名前のないラベルは色名になる")
+
+	require.Len(t, exported.Tasks, 2, "リストが見つからないカードはインポートしない")
+	first := exported.Tasks[0]
+	assert.Equal(t, "先", first.Title, "カードは表示位置順に並ぶ")
+	assert.Equal(t, &due, first.DueDate)
+	assert.True(t, first.IsCompleted)
+	assert.Equal(t, []uint{1, 2}, first.LabelIDs)
+	assert.Equal(t, "説明\n\n### 手順\n- [x] 一\n- [ ] 二", first.Description)
+	assert.Equal(t, []uint{exported.Tasks[1].ID}, conversion.archivedTasks)
+	assert.Equal(t, 1, conversion.checklists)
+
+	items := make(map[string]int)
+	for _, issue := range conversion.issues {
+		items[issue.Item]++
+	}
+	assert.Equal(t, map[string]int{"card": 1, "checklist": 1, "member": 1, "comment": 1}, items)
+}
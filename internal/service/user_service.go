@@ -3,10 +3,17 @@ package service
 import (
 	"errors"
 	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"simple-kanban/config"
 	"simple-kanban/internal/domain"
 	"simple-kanban/internal/repository"
+	"simple-kanban/pkg/mailer"
 	"simple-kanban/pkg/middleware"
 
 	"github.com/google/uuid"
@@ -19,19 +26,38 @@ type UserService interface {
 	Login(email, password string) (*domain.User, string, error)
 	GetProfile(userID uuid.UUID) (*domain.User, error)
 	UpdateProfile(userID uuid.UUID, updates map[string]interface{}) (*domain.User, error)
+	ChangePassword(userID uuid.UUID, currentPassword, newPassword string) (string, error)
+	ChangeEmail(userID uuid.UUID, newEmail, password string) error
+	RequestPasswordReset(email string) error
+	ResetPassword(token, password string) error
+	VerifyEmail(token string) (*domain.User, error)
+	ResendVerificationEmail(userID uuid.UUID) error
+	SetupTwoFactor(userID uuid.UUID) (string, string, error)
+	EnableTwoFactor(userID uuid.UUID, code string) ([]string, error)
+	DisableTwoFactor(userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(userID uuid.UUID, code string) ([]string, error)
+	VerifyTwoFactor(challengeToken, code string) (*domain.User, string, error)
+	ValidateSession(userID uuid.UUID, tokenVersion int) error
 }
 
+// ErrInvalidAccountToken パスワードの再設定・メールアドレスの確認のトークンが無効な場合のエラー
+var ErrInvalidAccountToken = errors.New("トークンが無効か、有効期限が切れています")
+
 // userService UserServiceの実装
 type userService struct {
-	userRepo repository.UserRepository
-	cfg      *config.Config
+	userRepo  repository.UserRepository
+	tokenRepo repository.AccountTokenRepository
+	mailer    mailer.Mailer
+	cfg       *config.Config
 }
 
 // NewUserService UserServiceの新しいインスタンスを作成
-func NewUserService(userRepo repository.UserRepository, cfg *config.Config) UserService {
+func NewUserService(userRepo repository.UserRepository, tokenRepo repository.AccountTokenRepository, mailer mailer.Mailer, cfg *config.Config) UserService {
 	return &userService{
-		userRepo: userRepo,
-		cfg:      cfg,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		mailer:    mailer,
+		cfg:       cfg,
 	}
 }
 
@@ -57,6 +83,8 @@ func (s *userService) Register(email, password string) (*domain.User, string, er
 		ID:           uuid.New(),
 		Email:        email,
 		PasswordHash: hashedPassword,
+		TimeZone:     domain.DefaultUserTimeZone,
+		Locale:       domain.DefaultUserLocale,
 	}
 
 	// データベースに保存
@@ -64,8 +92,13 @@ func (s *userService) Register(email, password string) (*domain.User, string, er
 		return nil, "", fmt.Errorf("ユーザー作成エラー: %w", err)
 	}
 
+	// 確認メールの送信に失敗しても登録は完了させる（再送できる）
+	if err := s.sendVerificationEmail(user); err != nil {
+		log.Printf("確認メールの送信に失敗しました: %v", err)
+	}
+
 	// JWTトークンを生成
-	token, err := middleware.GenerateToken(user.ID, user.Email, s.cfg)
+	token, err := middleware.GenerateToken(user.ID, user.Email, user.TokenVersion, s.cfg)
 	if err != nil {
 		return nil, "", fmt.Errorf("トークン生成エラー: %w", err)
 	}
@@ -84,13 +117,30 @@ func (s *userService) Login(email, password string) (*domain.User, string, error
 		return nil, "", errors.New("メールアドレスまたはパスワードが正しくありません")
 	}
 
+	// 連続して失敗した場合はパスワードが正しくてもロックの期限まではログインできない
+	now := time.Now()
+	if user.IsLocked(now) {
+		return nil, "", &AccountLockedError{RetryAfter: user.LockedUntil.Sub(now)}
+	}
+
 	// パスワードを検証
 	if !s.checkPasswordHash(password, user.PasswordHash) {
+		s.recordLoginFailure(user, now)
 		return nil, "", errors.New("メールアドレスまたはパスワードが正しくありません")
 	}
 
+	// 二要素認証が有効な場合は、コードの入力を待つトークンを返す
+	if user.TwoFactorEnabled() {
+		challengeToken, err := middleware.GenerateChallengeToken(user.ID, user.Email, TwoFactorChallengeTTL, s.cfg)
+		if err != nil {
+			return nil, "", fmt.Errorf("トークン生成エラー: %w", err)
+		}
+		return user, "", &TwoFactorRequiredError{ChallengeToken: challengeToken}
+	}
+	s.resetLoginFailures(user)
+
 	// JWTトークンを生成
-	token, err := middleware.GenerateToken(user.ID, user.Email, s.cfg)
+	token, err := middleware.GenerateToken(user.ID, user.Email, user.TokenVersion, s.cfg)
 	if err != nil {
 		return nil, "", fmt.Errorf("トークン生成エラー: %w", err)
 	}
@@ -98,6 +148,58 @@ func (s *userService) Login(email, password string) (*domain.User, string, error
 	return user, token, nil
 }
 
+// AccountLockedError ログインの失敗が続いたためにアカウントがロックされている場合のエラー
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("ログインの失敗が続いたため、アカウントがロックされています。%d秒後に再度お試しください", int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// recordLoginFailure ログインの失敗（パスワード・二要素認証のコードの誤り）を記録し、
+// 連続した失敗が閾値に達した場合はアカウントをロックします（閾値を超えた失敗ごとにロックの時間を倍増）
+func (s *userService) recordLoginFailure(user *domain.User, now time.Time) {
+	cfg := s.cfg.RateLimit
+	if cfg.LockoutThreshold <= 0 {
+		return
+	}
+
+	failures, err := s.userRepo.IncrementFailedLogins(user.ID)
+	if err != nil {
+		log.Printf("ログイン失敗の記録に失敗しました: %v", err)
+		return
+	}
+	if failures < cfg.LockoutThreshold {
+		return
+	}
+
+	lockout := time.Duration(cfg.LockoutBaseSeconds) * time.Second
+	maxLockout := time.Duration(cfg.LockoutMaxMinutes) * time.Minute
+	for i := cfg.LockoutThreshold; i < failures && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > maxLockout {
+		lockout = maxLockout
+	}
+	if err := s.userRepo.LockUntil(user.ID, now.Add(lockout)); err != nil {
+		log.Printf("アカウントのロックに失敗しました: %v", err)
+	}
+}
+
+// resetLoginFailures ログインに成功したため、失敗の回数とロックを解除します
+func (s *userService) resetLoginFailures(user *domain.User) {
+	if user.FailedLogins == 0 && user.LockedUntil == nil {
+		return
+	}
+	if err := s.userRepo.ResetFailedLogins(user.ID); err != nil {
+		log.Printf("ログイン失敗の回数のリセットに失敗しました: %v", err)
+		return
+	}
+	user.FailedLogins = 0
+	user.LockedUntil = nil
+}
+
 // GetProfile ユーザーのプロフィール情報を取得します
 func (s *userService) GetProfile(userID uuid.UUID) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(userID)
@@ -111,6 +213,7 @@ func (s *userService) GetProfile(userID uuid.UUID) (*domain.User, error) {
 }
 
 // UpdateProfile ユーザーのプロフィール情報を更新します
+// 更新できる項目は display_name・avatar_url・time_zone・locale です（メールアドレス・パスワードはChangeEmail・ChangePasswordで変更）
 func (s *userService) UpdateProfile(userID uuid.UUID, updates map[string]interface{}) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
@@ -121,25 +224,33 @@ func (s *userService) UpdateProfile(userID uuid.UUID, updates map[string]interfa
 	}
 
 	// 更新可能なフィールドのみ処理
-	if email, ok := updates["email"].(string); ok && email != "" {
-		// メールアドレスの重複チェック
-		existingUser, err := s.userRepo.GetByEmail(email)
-		if err != nil {
-			return nil, fmt.Errorf("メール重複確認エラー: %w", err)
+	if displayName, ok := updates["display_name"].(string); ok {
+		displayName = strings.TrimSpace(displayName)
+		if utf8.RuneCountInString(displayName) > 50 {
+			return nil, errors.New("表示名は50文字以内で指定してください")
 		}
-		if existingUser != nil && existingUser.ID != userID {
-			return nil, errors.New("このメールアドレスは既に使用されています")
+		user.DisplayName = displayName
+	}
+	if avatarURL, ok := updates["avatar_url"].(string); ok {
+		// 空文字の場合はアバターを削除
+		if avatarURL != "" {
+			if err := domain.ValidateAvatarURL(avatarURL); err != nil {
+				return nil, err
+			}
 		}
-		user.Email = email
+		user.AvatarURL = avatarURL
 	}
-
-	// パスワード更新の場合はハッシュ化
-	if password, ok := updates["password"].(string); ok && password != "" {
-		hashedPassword, err := s.hashPassword(password)
-		if err != nil {
-			return nil, fmt.Errorf("パスワードハッシュ化エラー: %w", err)
+	if timeZone, ok := updates["time_zone"].(string); ok {
+		if err := domain.ValidateTimeZone(timeZone); err != nil {
+			return nil, err
+		}
+		user.TimeZone = timeZone
+	}
+	if locale, ok := updates["locale"].(string); ok {
+		if locale == "" {
+			return nil, errors.New("言語を指定してください")
 		}
-		user.PasswordHash = hashedPassword
+		user.Locale = locale
 	}
 
 	// データベースに保存
@@ -150,6 +261,330 @@ func (s *userService) UpdateProfile(userID uuid.UUID, updates map[string]interfa
 	return user, nil
 }
 
+// ValidateSession JWTのユーザーが存在するか（アカウントが削除されていないか）と、
+// JWTがパスワードの変更・再設定より後に発行されたものか（世代が一致するか）をチェックします
+func (s *userService) ValidateSession(userID uuid.UUID, tokenVersion int) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil {
+		return errors.New("ユーザーが見つかりません")
+	}
+	if user.TokenVersion != tokenVersion {
+		return errors.New("パスワードの変更前に発行されたトークンです")
+	}
+	return nil
+}
+
+// ChangePassword 現在のパスワードを確認してパスワードを変更します
+// 変更前に発行したJWTはすべて使用できなくなるため、変更したセッションで使用する新しいJWTを返します
+func (s *userService) ChangePassword(userID uuid.UUID, currentPassword, newPassword string) (string, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return "", err
+	}
+	if err := confirmPassword(user, currentPassword); err != nil {
+		return "", err
+	}
+
+	hashedPassword, err := s.hashPassword(newPassword)
+	if err != nil {
+		return "", fmt.Errorf("パスワードハッシュ化エラー: %w", err)
+	}
+	user.PasswordHash = hashedPassword
+	user.TokenVersion++
+	if err := s.userRepo.Update(user); err != nil {
+		return "", fmt.Errorf("ユーザー更新エラー: %w", err)
+	}
+
+	// 変更前に申請されたパスワードの再設定は使用できないようにする
+	if err := s.tokenRepo.InvalidateByUser(user.ID, domain.AccountTokenPasswordReset, time.Now()); err != nil {
+		return "", fmt.Errorf("トークン無効化エラー: %w", err)
+	}
+	s.notify(user.Email, "【Simple Kanban】パスワードが変更されました",
+		"アカウントのパスワードが変更されました。\n"+
+			"心当たりがない場合は、パスワードの再設定を行ってください。\n")
+
+	token, err := middleware.GenerateToken(user.ID, user.Email, user.TokenVersion, s.cfg)
+	if err != nil {
+		return "", fmt.Errorf("トークン生成エラー: %w", err)
+	}
+	return token, nil
+}
+
+// ChangeEmail パスワードを確認してメールアドレスの変更を申請します
+// 新しいメールアドレスに確認のメールを送信し、確認されるまでは現在のメールアドレスのままです
+func (s *userService) ChangeEmail(userID uuid.UUID, newEmail, password string) error {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return err
+	}
+	if err := confirmPassword(user, password); err != nil {
+		return err
+	}
+	if strings.EqualFold(newEmail, user.Email) {
+		return errors.New("現在と同じメールアドレスです")
+	}
+
+	// メールアドレスの重複チェック（確認時にもう一度チェックする）
+	existingUser, err := s.userRepo.GetByEmail(newEmail)
+	if err != nil {
+		return fmt.Errorf("メール重複確認エラー: %w", err)
+	}
+	if existingUser != nil {
+		return errors.New("このメールアドレスは既に使用されています")
+	}
+
+	user.PendingEmail = newEmail
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("ユーザー更新エラー: %w", err)
+	}
+	if err := s.sendEmailChangeVerification(user); err != nil {
+		return err
+	}
+	s.notify(user.Email, "【Simple Kanban】メールアドレスの変更",
+		"アカウントのメールアドレスを "+newEmail+" に変更する申請がありました。\n"+
+			"新しいメールアドレスで確認されると変更が完了します。\n"+
+			"心当たりがない場合は、パスワードを変更してください。\n")
+	return nil
+}
+
+// confirmPassword 重要な操作の前に現在のパスワードを確認します
+func confirmPassword(user *domain.User, password string) error {
+	if user.PasswordHash == "" {
+		// IDプロバイダーで作成したユーザーはパスワードを持たない
+		return errors.New("パスワードが設定されていません。パスワードの再設定から設定してください")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return errors.New("現在のパスワードが正しくありません")
+	}
+	return nil
+}
+
+// notify お知らせのメールを送信します（送信に失敗しても操作は完了させる）
+func (s *userService) notify(to, subject, body string) {
+	if err := s.mailer.Send(mailer.Message{To: to, Subject: subject, Body: body}); err != nil {
+		log.Printf("お知らせのメールの送信に失敗しました: %v", err)
+	}
+}
+
+// RequestPasswordReset パスワードの再設定のメールを送信します
+// 登録されているメールアドレスかどうかを知られないように、未登録の場合もエラーにしません
+func (s *userService) RequestPasswordReset(email string) error {
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return fmt.Errorf("ユーザー検索エラー: %w", err)
+	}
+	if user == nil {
+		return nil
+	}
+
+	token, err := s.issueAccountToken(user, domain.AccountTokenPasswordReset, user.Email, domain.PasswordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "【Simple Kanban】パスワードの再設定",
+		Body: "パスワードの再設定が申請されました。\n" +
+			"以下のリンクから1時間以内に新しいパスワードを設定してください。\n\n" +
+			s.appURL("/reset-password", token) + "\n\n" +
+			"心当たりがない場合は、このメールを破棄してください。パスワードは変更されません。\n",
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		log.Printf("パスワード再設定メールの送信に失敗しました: %v", err)
+	}
+	return nil
+}
+
+// ResetPassword パスワードの再設定のトークンを使用して新しいパスワードを設定します
+// 再設定前に発行したJWTは使用できなくなり、ログイン失敗によるロックも解除します
+func (s *userService) ResetPassword(token, password string) error {
+	now := time.Now()
+	accountToken, err := s.tokenRepo.Consume(domain.AccountTokenPasswordReset, domain.HashAccountToken(token), now)
+	if err != nil {
+		return fmt.Errorf("トークン取得エラー: %w", err)
+	}
+	if accountToken == nil {
+		return ErrInvalidAccountToken
+	}
+	user, err := s.userRepo.GetByID(accountToken.UserID)
+	if err != nil {
+		return fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil {
+		return ErrInvalidAccountToken
+	}
+
+	hashedPassword, err := s.hashPassword(password)
+	if err != nil {
+		return fmt.Errorf("パスワードハッシュ化エラー: %w", err)
+	}
+	user.PasswordHash = hashedPassword
+	user.TokenVersion++
+	user.FailedLogins = 0
+	user.LockedUntil = nil
+	// 再設定のメールを受け取れたため、メールアドレスも確認済みとする
+	if user.EmailVerifiedAt == nil && strings.EqualFold(accountToken.Email, user.Email) {
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("ユーザー更新エラー: %w", err)
+	}
+
+	// ほかに発行済みの再設定のトークンは使用できないようにする
+	if err := s.tokenRepo.InvalidateByUser(user.ID, domain.AccountTokenPasswordReset, now); err != nil {
+		return fmt.Errorf("トークン無効化エラー: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail メールアドレスの確認のトークンを使用してメールアドレスを確認済みにします
+// メールアドレスの変更の確認の場合は、新しいメールアドレスに変更します
+func (s *userService) VerifyEmail(token string) (*domain.User, error) {
+	now := time.Now()
+	hash := domain.HashAccountToken(token)
+	accountToken, err := s.tokenRepo.Consume(domain.AccountTokenEmailVerification, hash, now)
+	if err == nil && accountToken == nil {
+		accountToken, err = s.tokenRepo.Consume(domain.AccountTokenEmailChange, hash, now)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("トークン取得エラー: %w", err)
+	}
+	if accountToken == nil {
+		return nil, ErrInvalidAccountToken
+	}
+	user, err := s.userRepo.GetByID(accountToken.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil {
+		return nil, ErrInvalidAccountToken
+	}
+
+	if accountToken.Purpose == domain.AccountTokenEmailChange {
+		// 送信後に別のメールアドレスへの変更が申請された場合は使用できない
+		if !strings.EqualFold(accountToken.Email, user.PendingEmail) {
+			return nil, ErrInvalidAccountToken
+		}
+		existingUser, err := s.userRepo.GetByEmail(user.PendingEmail)
+		if err != nil {
+			return nil, fmt.Errorf("メール重複確認エラー: %w", err)
+		}
+		if existingUser != nil && existingUser.ID != user.ID {
+			return nil, errors.New("このメールアドレスは既に使用されています")
+		}
+		user.Email = user.PendingEmail
+		user.PendingEmail = ""
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, fmt.Errorf("ユーザー更新エラー: %w", err)
+		}
+		return user, nil
+	}
+
+	// 送信後にメールアドレスが変更された場合は、古いメールアドレスの確認として扱わない
+	if !strings.EqualFold(accountToken.Email, user.Email) {
+		return nil, ErrInvalidAccountToken
+	}
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, fmt.Errorf("ユーザー更新エラー: %w", err)
+		}
+	}
+	return user, nil
+}
+
+// ResendVerificationEmail メールアドレスの確認のメールを再送します（以前のトークンは使用できなくなる）
+// メールアドレスの変更を申請中の場合は、新しいメールアドレスに再送します
+func (s *userService) ResendVerificationEmail(userID uuid.UUID) error {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return err
+	}
+	if user.PendingEmail != "" {
+		return s.sendEmailChangeVerification(user)
+	}
+	if user.EmailVerifiedAt != nil {
+		return errors.New("メールアドレスは既に確認済みです")
+	}
+	return s.sendVerificationEmail(user)
+}
+
+// sendEmailChangeVerification 変更後のメールアドレスに確認のメールを送信します
+func (s *userService) sendEmailChangeVerification(user *domain.User) error {
+	token, err := s.issueAccountToken(user, domain.AccountTokenEmailChange, user.PendingEmail, domain.EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+	msg := mailer.Message{
+		To:      user.PendingEmail,
+		Subject: "【Simple Kanban】新しいメールアドレスの確認",
+		Body: "Simple Kanban のメールアドレスの変更が申請されました。\n" +
+			"以下のリンクから24時間以内に新しいメールアドレスを確認すると、変更が完了します。\n\n" +
+			s.appURL("/verify-email", token) + "\n\n" +
+			"心当たりがない場合は、このメールを破棄してください。\n",
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		return fmt.Errorf("確認メール送信エラー: %w", err)
+	}
+	return nil
+}
+
+// sendVerificationEmail メールアドレスの確認のメールを送信します
+func (s *userService) sendVerificationEmail(user *domain.User) error {
+	token, err := s.issueAccountToken(user, domain.AccountTokenEmailVerification, user.Email, domain.EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "【Simple Kanban】メールアドレスの確認",
+		Body: "Simple Kanban へのご登録ありがとうございます。\n" +
+			"以下のリンクから24時間以内にメールアドレスを確認してください。\n\n" +
+			s.appURL("/verify-email", token) + "\n\n" +
+			"心当たりがない場合は、このメールを破棄してください。\n",
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		return fmt.Errorf("確認メール送信エラー: %w", err)
+	}
+	return nil
+}
+
+// issueAccountToken メールアドレスに送信するトークンを発行します
+// 同じ用途の未使用のトークンは使用できなくなり、最後に送信したメールのリンクのみ有効になります
+func (s *userService) issueAccountToken(user *domain.User, purpose domain.AccountTokenPurpose, email string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	if err := s.tokenRepo.DeleteExpired(now); err != nil {
+		log.Printf("期限切れのトークンの削除に失敗しました: %v", err)
+	}
+	if err := s.tokenRepo.InvalidateByUser(user.ID, purpose, now); err != nil {
+		return "", fmt.Errorf("トークン無効化エラー: %w", err)
+	}
+
+	token, err := randomURLToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.tokenRepo.Create(&domain.AccountToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: domain.HashAccountToken(token),
+		Email:     email,
+		ExpiresAt: now.Add(ttl),
+	}); err != nil {
+		return "", fmt.Errorf("トークン作成エラー: %w", err)
+	}
+	return token, nil
+}
+
+// appURL メール内のリンク（フロントエンドのページ + トークン）を返します
+func (s *userService) appURL(path, token string) string {
+	return strings.TrimSuffix(s.cfg.Mail.AppBaseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
 // hashPassword パスワードをハッシュ化します
 func (s *userService) hashPassword(password string) (string, error) {
 	// bcryptでパスワードをハッシュ化（コスト10）
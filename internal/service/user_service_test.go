@@ -0,0 +1,364 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-kanban/config"
+	"simple-kanban/internal/domain"
+	"simple-kanban/pkg/mailer"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAccountTokenRepository テスト用のメモリ上のAccountTokenRepository
+type memoryAccountTokenRepository struct {
+	tokens []domain.AccountToken
+}
+
+func (r *memoryAccountTokenRepository) Create(token *domain.AccountToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, *token)
+	return nil
+}
+
+func (r *memoryAccountTokenRepository) Consume(purpose domain.AccountTokenPurpose, tokenHash string, now time.Time) (*domain.AccountToken, error) {
+	for i := range r.tokens {
+		t := &r.tokens[i]
+		if t.TokenHash == tokenHash && t.Purpose == purpose && t.UsedAt == nil && t.ExpiresAt.After(now) {
+			t.UsedAt = &now
+			consumed := *t
+			return &consumed, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAccountTokenRepository) InvalidateByUser(userID uuid.UUID, purpose domain.AccountTokenPurpose, now time.Time) error {
+	for i := range r.tokens {
+		if r.tokens[i].UserID == userID && r.tokens[i].Purpose == purpose && r.tokens[i].UsedAt == nil {
+			r.tokens[i].UsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *memoryAccountTokenRepository) DeleteExpired(now time.Time) error {
+	return nil
+}
+
+// mailToken 送信したメールのリンクからトークンを取り出します
+func mailToken(t *testing.T, msg mailer.Message) string {
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if strings.HasPrefix(line, "http") {
+			u, err := url.Parse(line)
+			require.NoError(t, err)
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("メールにリンクがありません: %s", msg.Body)
+	return ""
+}
+
+func newTestUserService() (UserService, *memoryUserRepository, *memoryAccountTokenRepository, *mailer.MemoryMailer) {
+	cfg := &config.Config{
+		JWT:  config.JWTConfig{SecretKey: "test-secret", ExpireHours: 1},
+		Mail: config.MailConfig{AppBaseURL: "http://app.example.com/"},
+	}
+	userRepo := &memoryUserRepository{users: make(map[uuid.UUID]*domain.User)}
+	tokenRepo := &memoryAccountTokenRepository{}
+	mail := mailer.NewMemoryMailer()
+	return NewUserService(userRepo, tokenRepo, mail, cfg), userRepo, tokenRepo, mail
+}
+
+// assertSession JWTがセッションとして有効かどうかを確認します
+func assertSession(t *testing.T, svc UserService, token string, valid bool) {
+	t.Helper()
+	claims, err := middleware.ValidateToken(token, &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret"}})
+	require.NoError(t, err)
+	if valid {
+		assert.NoError(t, svc.ValidateSession(claims.UserID, claims.Version))
+	} else {
+		assert.Error(t, svc.ValidateSession(claims.UserID, claims.Version))
+	}
+}
+
+// 登録時の確認メールと、メールアドレスの確認のテスト
+func TestUserService_VerifyEmail(t *testing.T) {
+	svc, _, _, mail := newTestUserService()
+
+	user, _, err := svc.Register("alice@example.com", "password123")
+	require.NoError(t, err)
+	assert.Nil(t, user.EmailVerifiedAt)
+
+	require.Len(t, mail.Messages(), 1)
+	msg := mail.Messages()[0]
+	assert.Equal(t, "alice@example.com", msg.To)
+	assert.Contains(t, msg.Body, "http://app.example.com/verify-email?token=")
+
+	// 再送すると以前のリンクは使用できなくなる
+	require.NoError(t, svc.ResendVerificationEmail(user.ID))
+	_, err = svc.VerifyEmail(mailToken(t, msg))
+	assert.ErrorIs(t, err, ErrInvalidAccountToken)
+
+	token := mailToken(t, mail.Messages()[1])
+	verified, err := svc.VerifyEmail(token)
+	require.NoError(t, err)
+	assert.NotNil(t, verified.EmailVerifiedAt)
+
+	// トークンは1回のみ使用できる
+	_, err = svc.VerifyEmail(token)
+	assert.ErrorIs(t, err, ErrInvalidAccountToken)
+	assert.Error(t, svc.ResendVerificationEmail(user.ID))
+}
+
+// パスワードの再設定のテスト
+func TestUserService_ResetPassword(t *testing.T) {
+	svc, userRepo, tokenRepo, mail := newTestUserService()
+
+	alice, _, err := svc.Register("alice@example.com", "password123")
+	require.NoError(t, err)
+
+	// 未登録のメールアドレスはエラーにせず、メールも送信しない
+	require.NoError(t, svc.RequestPasswordReset("nobody@example.com"))
+	assert.Len(t, mail.Messages(), 1)
+
+	require.NoError(t, svc.RequestPasswordReset("alice@example.com"))
+	require.Len(t, mail.Messages(), 2)
+	token := mailToken(t, mail.Messages()[1])
+
+	// メールアドレスの確認のトークンとしては使用できない
+	_, err = svc.VerifyEmail(token)
+	assert.ErrorIs(t, err, ErrInvalidAccountToken)
+
+	// ログインの失敗でロックされていても、再設定するとロックが解除される
+	_, before, err := svc.Login("alice@example.com", "password123")
+	require.NoError(t, err)
+	locked := time.Now().Add(time.Hour)
+	userRepo.users[alice.ID].FailedLogins = 10
+	userRepo.users[alice.ID].LockedUntil = &locked
+
+	require.NoError(t, svc.ResetPassword(token, "new-password"))
+	user, after, err := svc.Login("alice@example.com", "new-password")
+	require.NoError(t, err)
+	assert.NotNil(t, user.EmailVerifiedAt)
+	_, _, err = svc.Login("alice@example.com", "password123")
+	assert.Error(t, err)
+
+	// 再設定前に発行したJWTは使用できない
+	assertSession(t, svc, before, false)
+	assertSession(t, svc, after, true)
+
+	// 使用済み・期限切れのトークンは使用できない
+	assert.ErrorIs(t, svc.ResetPassword(token, "another-password"), ErrInvalidAccountToken)
+
+	require.NoError(t, svc.RequestPasswordReset("alice@example.com"))
+	expired := mailToken(t, mail.Messages()[2])
+	tokenRepo.tokens[len(tokenRepo.tokens)-1].ExpiresAt = time.Now().Add(-time.Minute)
+	assert.ErrorIs(t, svc.ResetPassword(expired, "another-password"), ErrInvalidAccountToken)
+}
+
+// 二要素認証の有効化・ログイン・リカバリーコード・無効化のテスト
+func TestUserService_TwoFactor(t *testing.T) {
+	svc, _, _, _ := newTestUserService()
+	user, _, err := svc.Register("alice@example.com", "password123")
+	require.NoError(t, err)
+
+	secret, uri, err := svc.SetupTwoFactor(user.ID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Simple%20Kanban:alice@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+
+	codeAt := func(t *testing.T, offset time.Duration) string {
+		code, err := domain.TOTPCode(secret, domain.TOTPStep(time.Now().Add(offset)))
+		require.NoError(t, err)
+		return code
+	}
+
+	// 有効化するまではパスワードのみでログインできる
+	_, token, err := svc.Login("alice@example.com", "password123")
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+
+	_, err = svc.EnableTwoFactor(user.ID, "000000x")
+	assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+	recoveryCodes, err := svc.EnableTwoFactor(user.ID, codeAt(t, -domain.TOTPPeriod*time.Second))
+	require.NoError(t, err)
+	assert.Len(t, recoveryCodes, domain.RecoveryCodeCount)
+
+	// パスワードが正しい場合はコードの入力を待つトークンを返す（APIには使用できない）
+	_, token, err = svc.Login("alice@example.com", "password123")
+	var challenge *TwoFactorRequiredError
+	require.ErrorAs(t, err, &challenge)
+	assert.Empty(t, token)
+	_, err = middleware.ValidateToken(challenge.ChallengeToken, &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret"}})
+	assert.Error(t, err)
+
+	_, _, err = svc.VerifyTwoFactor(challenge.ChallengeToken, "123")
+	assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+	_, _, err = svc.VerifyTwoFactor("invalid", codeAt(t, 0))
+	assert.Error(t, err)
+
+	code := codeAt(t, 0)
+	loggedIn, token, err := svc.VerifyTwoFactor(challenge.ChallengeToken, code)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, loggedIn.ID)
+	assert.NotEmpty(t, token)
+
+	// 使用済みのコード（以前の時間ステップを含む）は再利用できない
+	_, _, err = svc.VerifyTwoFactor(challenge.ChallengeToken, code)
+	assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+	// リカバリーコードは1回のみ使用できる（区切りや大文字・小文字は問わない）
+	recovery := strings.ToUpper(strings.ReplaceAll(recoveryCodes[0], "-", ""))
+	_, _, err = svc.VerifyTwoFactor(challenge.ChallengeToken, recovery)
+	require.NoError(t, err)
+	_, _, err = svc.VerifyTwoFactor(challenge.ChallengeToken, recovery)
+	assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+	// 再発行すると以前のリカバリーコードは使用できない
+	regenerated, err := svc.RegenerateRecoveryCodes(user.ID, recoveryCodes[1])
+	require.NoError(t, err)
+	assert.ErrorIs(t, svc.DisableTwoFactor(user.ID, recoveryCodes[2]), ErrInvalidTwoFactorCode)
+
+	require.NoError(t, svc.DisableTwoFactor(user.ID, regenerated[0]))
+	_, token, err = svc.Login("alice@example.com", "password123")
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+// 連続したログイン失敗によるアカウントのロックのテスト
+func TestUserService_LoginLockout(t *testing.T) {
+	svc, userRepo, _, _ := newTestUserService()
+	svc.(*userService).cfg.RateLimit = config.RateLimitConfig{LockoutThreshold: 3, LockoutBaseSeconds: 60, LockoutMaxMinutes: 3}
+
+	user, _, err := svc.Register("alice@example.com", "password123")
+	require.NoError(t, err)
+
+	// 閾値未満の失敗は成功でリセットされる
+	_, _, err = svc.Login("alice@example.com", "wrong-password")
+	assert.Error(t, err)
+	_, _, err = svc.Login("alice@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, 0, userRepo.users[user.ID].FailedLogins)
+
+	for i := 0; i < 3; i++ {
+		_, _, err = svc.Login("alice@example.com", "wrong-password")
+		assert.Error(t, err)
+	}
+
+	// ロック中は正しいパスワードでもログインできない
+	_, _, err = svc.Login("alice@example.com", "password123")
+	var locked *AccountLockedError
+	require.ErrorAs(t, err, &locked)
+	assert.InDelta(t, time.Minute.Seconds(), locked.RetryAfter.Seconds(), 1)
+
+	// 閾値を超えた失敗ごとにロックの時間を倍増し、上限で止める
+	lockedFor := func(failures int) time.Duration {
+		userRepo.users[user.ID].FailedLogins = failures - 1
+		userRepo.users[user.ID].LockedUntil = nil
+		_, _, err := svc.Login("alice@example.com", "wrong-password")
+		require.Error(t, err)
+		return time.Until(*userRepo.users[user.ID].LockedUntil).Round(time.Second)
+	}
+	assert.Equal(t, 2*time.Minute, lockedFor(4))
+	assert.Equal(t, 3*time.Minute, lockedFor(5))
+	assert.Equal(t, 3*time.Minute, lockedFor(30))
+
+	// ロックの期限を過ぎるとログインでき、回数もリセットされる
+	past := time.Now().Add(-time.Second)
+	userRepo.users[user.ID].LockedUntil = &past
+	_, _, err = svc.Login("alice@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, 0, userRepo.users[user.ID].FailedLogins)
+	assert.Nil(t, userRepo.users[user.ID].LockedUntil)
+}
+
+// プロフィール・パスワードの更新のテスト
+func TestUserService_UpdateProfileAndPassword(t *testing.T) {
+	svc, _, _, mail := newTestUserService()
+	user, _, err := svc.Register("alice@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Name())
+	assert.Equal(t, domain.DefaultUserTimeZone, user.TimeZone)
+
+	updated, err := svc.UpdateProfile(user.ID, map[string]interface{}{
+		"display_name": "  Alice  ",
+		"avatar_url":   "https://example.com/alice.png",
+		"time_zone":    "Asia/Tokyo",
+		"locale":       "en-US",
+		"email":        "ignored@example.com", // メールアドレスはChangeEmailでのみ変更できる
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", updated.Name())
+	assert.Equal(t, "Asia/Tokyo", updated.TimeZone)
+	assert.Equal(t, "en-US", updated.Locale)
+	assert.Equal(t, "alice@example.com", updated.Email)
+
+	_, err = svc.UpdateProfile(user.ID, map[string]interface{}{"time_zone": "Mars/Olympus"})
+	assert.Error(t, err)
+	_, err = svc.UpdateProfile(user.ID, map[string]interface{}{"avatar_url": "javascript:alert(1)"})
+	assert.Error(t, err)
+	updated, err = svc.UpdateProfile(user.ID, map[string]interface{}{"avatar_url": ""})
+	require.NoError(t, err)
+	assert.Empty(t, updated.AvatarURL)
+
+	// 現在のパスワードが正しくない場合は変更できない
+	_, err = svc.ChangePassword(user.ID, "wrong-password", "new-password")
+	assert.Error(t, err)
+	_, before, err := svc.Login("alice@example.com", "password123")
+	require.NoError(t, err)
+	token, err := svc.ChangePassword(user.ID, "password123", "new-password")
+	require.NoError(t, err)
+	_, _, err = svc.Login("alice@example.com", "new-password")
+	require.NoError(t, err)
+
+	// 変更前に発行したJWTは使用できず、変更時に返したJWTは使用できる
+	assertSession(t, svc, before, false)
+	assertSession(t, svc, token, true)
+	assert.Equal(t, "alice@example.com", mail.Messages()[len(mail.Messages())-1].To)
+}
+
+// メールアドレスの変更（新しいメールアドレスの確認後に変更）のテスト
+func TestUserService_ChangeEmail(t *testing.T) {
+	svc, userRepo, _, mail := newTestUserService()
+	user, _, err := svc.Register("alice@example.com", "password123")
+	require.NoError(t, err)
+	_, _, err = svc.Register("bob@example.com", "password123")
+	require.NoError(t, err)
+
+	assert.Error(t, svc.ChangeEmail(user.ID, "alice@new.example.com", "wrong-password"))
+	assert.Error(t, svc.ChangeEmail(user.ID, "bob@example.com", "password123"))
+
+	sent := len(mail.Messages())
+	require.NoError(t, svc.ChangeEmail(user.ID, "alice@new.example.com", "password123"))
+	messages := mail.Messages()[sent:]
+	require.Len(t, messages, 2)
+	assert.Equal(t, "alice@new.example.com", messages[0].To)
+	assert.Equal(t, "alice@example.com", messages[1].To) // 現在のメールアドレスへのお知らせ
+
+	// 確認されるまでは現在のメールアドレスのまま
+	assert.Equal(t, "alice@example.com", userRepo.users[user.ID].Email)
+	assert.Equal(t, "alice@new.example.com", userRepo.users[user.ID].PendingEmail)
+
+	// 再送すると新しいメールアドレスに送信し、以前のリンクは使用できなくなる
+	require.NoError(t, svc.ResendVerificationEmail(user.ID))
+	_, err = svc.VerifyEmail(mailToken(t, messages[0]))
+	assert.ErrorIs(t, err, ErrInvalidAccountToken)
+
+	resent := mail.Messages()[len(mail.Messages())-1]
+	assert.Equal(t, "alice@new.example.com", resent.To)
+	verified, err := svc.VerifyEmail(mailToken(t, resent))
+	require.NoError(t, err)
+	assert.Equal(t, "alice@new.example.com", verified.Email)
+	assert.Empty(t, verified.PendingEmail)
+	assert.NotNil(t, verified.EmailVerifiedAt)
+
+	_, _, err = svc.Login("alice@new.example.com", "password123")
+	assert.NoError(t, err)
+}
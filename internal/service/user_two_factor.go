@@ -0,0 +1,223 @@
+package service
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/pkg/middleware"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactorChallengeTTL パスワードの確認後に二要素認証のコードを入力するまでの有効期限
+const TwoFactorChallengeTTL = 5 * time.Minute
+
+// totpIssuer 認証アプリに表示するサービス名
+const totpIssuer = "Simple Kanban"
+
+// ErrInvalidTwoFactorCode 二要素認証のコードが正しくない場合のエラー
+var ErrInvalidTwoFactorCode = errors.New("認証コードが正しくありません")
+
+// TwoFactorRequiredError パスワードは正しいが、二要素認証のコードの入力が必要な場合のエラー
+// ChallengeTokenとコードをVerifyTwoFactorに渡すとログインが完了します
+type TwoFactorRequiredError struct {
+	ChallengeToken string
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	return "二要素認証のコードを入力してください"
+}
+
+// SetupTwoFactor 二要素認証のシークレットを発行します（EnableTwoFactorでコードを確認するまでは無効）
+// 戻り値はシークレットと認証アプリに登録するためのURIです
+func (s *userService) SetupTwoFactor(userID uuid.UUID) (string, string, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user.TwoFactorEnabled() {
+		return "", "", errors.New("二要素認証は既に有効です")
+	}
+
+	secret, err := domain.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	if err := s.userRepo.Update(user); err != nil {
+		return "", "", fmt.Errorf("ユーザー更新エラー: %w", err)
+	}
+	return secret, domain.TOTPURI(totpIssuer, user.Email, secret), nil
+}
+
+// EnableTwoFactor 認証アプリのコードを確認して二要素認証を有効にし、リカバリーコードを発行します
+// リカバリーコードはハッシュのみを保存するため、この時点でのみ取得できます
+func (s *userService) EnableTwoFactor(userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled() {
+		return nil, errors.New("二要素認証は既に有効です")
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("先に二要素認証の設定を開始してください")
+	}
+	if !s.verifyTOTP(user, code, time.Now()) {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, err := s.resetRecoveryCodes(user)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	user.TOTPEnabledAt = &now
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("ユーザー更新エラー: %w", err)
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor 認証アプリのコードまたはリカバリーコードを確認して二要素認証を無効にします
+func (s *userService) DisableTwoFactor(userID uuid.UUID, code string) error {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled() {
+		return errors.New("二要素認証は有効になっていません")
+	}
+	if !s.verifySecondFactor(user, code) {
+		return ErrInvalidTwoFactorCode
+	}
+
+	user.TOTPSecret = ""
+	user.TOTPEnabledAt = nil
+	user.TOTPLastStep = 0
+	user.RecoveryCodes = nil
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("ユーザー更新エラー: %w", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes リカバリーコードを発行し直します（以前のコードは使用できなくなる）
+func (s *userService) RegenerateRecoveryCodes(userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled() {
+		return nil, errors.New("二要素認証は有効になっていません")
+	}
+	if !s.verifySecondFactor(user, code) {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, err := s.resetRecoveryCodes(user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("ユーザー更新エラー: %w", err)
+	}
+	return codes, nil
+}
+
+// VerifyTwoFactor ログイン時のトークンと、認証アプリのコードまたはリカバリーコードを確認してログインを完了します
+func (s *userService) VerifyTwoFactor(challengeToken, code string) (*domain.User, string, error) {
+	claims, err := middleware.ValidateChallengeToken(challengeToken, s.cfg)
+	if err != nil {
+		return nil, "", errors.New("ログインの有効期限が切れています。もう一度ログインしてください")
+	}
+	user, err := s.userRepo.GetByID(claims.UserID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil || !user.TwoFactorEnabled() {
+		return nil, "", errors.New("ログインの有効期限が切れています。もう一度ログインしてください")
+	}
+
+	// コードの総当たりを防ぐため、パスワードと同じくロックの対象にする
+	now := time.Now()
+	if user.IsLocked(now) {
+		return nil, "", &AccountLockedError{RetryAfter: user.LockedUntil.Sub(now)}
+	}
+	if !s.verifySecondFactor(user, code) {
+		s.recordLoginFailure(user, now)
+		return nil, "", ErrInvalidTwoFactorCode
+	}
+
+	// 使用したコード（時間ステップ・リカバリーコード）を保存する
+	user.FailedLogins = 0
+	user.LockedUntil = nil
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, "", fmt.Errorf("ユーザー更新エラー: %w", err)
+	}
+
+	token, err := middleware.GenerateToken(user.ID, user.Email, user.TokenVersion, s.cfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("トークン生成エラー: %w", err)
+	}
+	return user, token, nil
+}
+
+// verifySecondFactor 認証アプリのコードまたはリカバリーコードを確認します
+// 使用したリカバリーコードはuserから取り除きます（保存は呼び出し側で行う）
+func (s *userService) verifySecondFactor(user *domain.User, code string) bool {
+	if s.verifyTOTP(user, code, time.Now()) {
+		return true
+	}
+
+	hash := domain.HashRecoveryCode(code)
+	for i, stored := range user.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			user.RecoveryCodes = append(user.RecoveryCodes[:i:i], user.RecoveryCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// verifyTOTP 認証アプリのコードを確認します（前後の時間ステップも許容する）
+// 同じコードを再利用できないよう、一致した時間ステップをuserに記録します（保存は呼び出し側で行う）
+func (s *userService) verifyTOTP(user *domain.User, code string, now time.Time) bool {
+	if user.TOTPSecret == "" || len(code) != domain.TOTPDigits {
+		return false
+	}
+
+	current := domain.TOTPStep(now)
+	for step := current - domain.TOTPSkew; step <= current+domain.TOTPSkew; step++ {
+		if step <= user.TOTPLastStep {
+			continue
+		}
+		expected, err := domain.TOTPCode(user.TOTPSecret, step)
+		if err != nil {
+			return false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			user.TOTPLastStep = step
+			return true
+		}
+	}
+	return false
+}
+
+// resetRecoveryCodes リカバリーコードを生成し、ハッシュをuserに設定します（保存は呼び出し側で行う）
+func (s *userService) resetRecoveryCodes(user *domain.User) ([]string, error) {
+	codes, err := domain.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(domain.StringList, len(codes))
+	for i, code := range codes {
+		hashes[i] = domain.HashRecoveryCode(code)
+	}
+	user.RecoveryCodes = hashes
+	return codes, nil
+}
@@ -0,0 +1,488 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Webhookの配信の設定
+const (
+	webhookDeliveryBatchSize = 50               // 1回の配信ジョブで処理する配信の最大数
+	webhookDeliveryTimeout   = 10 * time.Second // 1回の配信のタイムアウト
+	WebhookDeliveryLogLimit  = 100              // 配信ログとして返す最大件数
+
+	// 配信ジョブが確保した配信をほかのインスタンスが処理しない時間（1回分の配信をすべてタイムアウトしても余裕のある長さ）
+	webhookDeliveryLease = webhookDeliveryBatchSize*webhookDeliveryTimeout + time.Minute
+)
+
+// WebhookPublisher ボードのイベントをWebhookの配信キューに追加するインターフェース
+// イベントの通知に失敗しても元の操作は失敗させないため、エラーはログに出力するのみです
+type WebhookPublisher interface {
+	Publish(boardID uint, event domain.WebhookEvent, data interface{})
+}
+
+// WebhookUpdate Webhookの更新内容（nilの項目は変更しない）
+type WebhookUpdate struct {
+	URL    *string
+	Secret *string
+	Events *[]string
+	Active *bool
+}
+
+// WebhookService Webhookの購読と配信を管理するインターフェース
+type WebhookService interface {
+	WebhookPublisher
+	CreateWebhook(boardID uint, userID uuid.UUID, url, secret string, events []string) (*domain.Webhook, error)
+	GetBoardWebhooks(boardID uint, userID uuid.UUID) ([]domain.Webhook, error)
+	UpdateWebhook(webhookID uint, userID uuid.UUID, update WebhookUpdate) (*domain.Webhook, error)
+	DeleteWebhook(webhookID uint, userID uuid.UUID) error
+	PingWebhook(webhookID uint, userID uuid.UUID) error
+	GetDeliveries(webhookID uint, userID uuid.UUID) ([]domain.WebhookDelivery, error)
+	DeliverDue(now time.Time) (int, error)
+	StartDeliveryJob(interval time.Duration) func()
+}
+
+// webhookService WebhookServiceの実装
+type webhookService struct {
+	webhookRepo repository.WebhookRepository
+	boardRepo   repository.BoardRepository
+	client      *http.Client // 配信に使用するHTTPクライアント
+}
+
+// NewWebhookService WebhookServiceの新しいインスタンスを作成
+// clientがnilの場合は内部ネットワークに接続しない既定のクライアント（newWebhookHTTPClient）を使用します
+func NewWebhookService(webhookRepo repository.WebhookRepository, boardRepo repository.BoardRepository, client *http.Client) WebhookService {
+	if client == nil {
+		client = newWebhookHTTPClient()
+	}
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		boardRepo:   boardRepo,
+		client:      client,
+	}
+}
+
+// CreateWebhook ボードにWebhookを作成します
+// secretが空の場合はランダムなシークレットを生成します
+func (s *webhookService) CreateWebhook(boardID uint, userID uuid.UUID, url, secret string, events []string) (*domain.Webhook, error) {
+	if err := s.checkBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateWebhookURL(url); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateWebhookEvents(events); err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	webhook := &domain.Webhook{
+		BoardID: boardID,
+		URL:     url,
+		Secret:  secret,
+		Events:  domain.StringList(events),
+		Active:  true,
+	}
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, fmt.Errorf("Webhook作成エラー: %w", err)
+	}
+	return webhook, nil
+}
+
+// GetBoardWebhooks ボードのWebhook一覧を取得します
+func (s *webhookService) GetBoardWebhooks(boardID uint, userID uuid.UUID) ([]domain.Webhook, error) {
+	if err := s.checkBoardOwnership(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhookRepo.GetByBoardID(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook取得エラー: %w", err)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook Webhookの通知先・シークレット・イベント・有効/無効を更新します
+func (s *webhookService) UpdateWebhook(webhookID uint, userID uuid.UUID, update WebhookUpdate) (*domain.Webhook, error) {
+	webhook, err := s.getWebhook(webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.URL != nil {
+		if err := domain.ValidateWebhookURL(*update.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *update.URL
+	}
+	if update.Secret != nil {
+		if *update.Secret == "" {
+			return nil, errors.New("シークレットを指定してください")
+		}
+		webhook.Secret = *update.Secret
+	}
+	if update.Events != nil {
+		if err := domain.ValidateWebhookEvents(*update.Events); err != nil {
+			return nil, err
+		}
+		webhook.Events = domain.StringList(*update.Events)
+	}
+	if update.Active != nil {
+		webhook.Active = *update.Active
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, fmt.Errorf("Webhook更新エラー: %w", err)
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook Webhookと配信ログを削除します
+func (s *webhookService) DeleteWebhook(webhookID uint, userID uuid.UUID) error {
+	if _, err := s.getWebhook(webhookID, userID); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(webhookID); err != nil {
+		return fmt.Errorf("Webhook削除エラー: %w", err)
+	}
+	return nil
+}
+
+// PingWebhook 疎通確認用のpingイベントを配信キューに追加します
+func (s *webhookService) PingWebhook(webhookID uint, userID uuid.UUID) error {
+	webhook, err := s.getWebhook(webhookID, userID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{"webhook_id": webhook.ID}
+	if err := s.enqueue([]domain.Webhook{*webhook}, webhook.BoardID, domain.WebhookEventPing, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDeliveries Webhookの配信ログを新しい順に取得します
+func (s *webhookService) GetDeliveries(webhookID uint, userID uuid.UUID) ([]domain.WebhookDelivery, error) {
+	if _, err := s.getWebhook(webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepo.GetDeliveriesByWebhookID(webhookID, WebhookDeliveryLogLimit)
+	if err != nil {
+		return nil, fmt.Errorf("配信ログ取得エラー: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Publish ボードのイベントを購読しているWebhookごとに配信キューへ追加します
+func (s *webhookService) Publish(boardID uint, event domain.WebhookEvent, data interface{}) {
+	webhooks, err := s.webhookRepo.GetByBoardID(boardID)
+	if err != nil {
+		log.Printf("Webhook取得エラー: %v", err)
+		return
+	}
+
+	var targets []domain.Webhook
+	for _, webhook := range webhooks {
+		if webhook.Active && webhook.Subscribes(event) {
+			targets = append(targets, webhook)
+		}
+	}
+	if err := s.enqueue(targets, boardID, event, data); err != nil {
+		log.Printf("Webhookの配信キューへの追加に失敗しました: %v", err)
+	}
+}
+
+// DeliverDue 配信予定時刻を過ぎた配信を送信し、処理した配信の数を返します
+// 失敗した配信は指数バックオフで再試行を予定し、上限に達したものは失敗として記録します
+// 配信は取得時に確保するため、複数のインスタンスでジョブを実行しても同じ配信を重複して送信しません
+func (s *webhookService) DeliverDue(now time.Time) (int, error) {
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(now, webhookDeliveryLease, webhookDeliveryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("配信待ち取得エラー: %w", err)
+	}
+
+	webhooks := make(map[uint]*domain.Webhook)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			if webhook, err = s.webhookRepo.GetByID(delivery.WebhookID); err != nil {
+				return i, fmt.Errorf("Webhook取得エラー: %w", err)
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if webhook == nil || !webhook.Active {
+			// 無効化されたWebhookへの配信は再試行しない
+			delivery.Status = domain.WebhookDeliveryFailed
+			delivery.LastError = "Webhookが無効化されているため配信しませんでした"
+		} else {
+			responseStatus, errorMessage := s.send(webhook, delivery)
+			delivery.RecordAttempt(now, responseStatus, errorMessage)
+		}
+
+		if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+			return i, fmt.Errorf("配信結果更新エラー: %w", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// StartDeliveryJob 配信キューを定期的に処理するジョブを開始します
+// 戻り値の関数を呼び出すとジョブを停止します
+func (s *webhookService) StartDeliveryJob(interval time.Duration) func() {
+	done := make(chan struct{})
+	if interval <= 0 {
+		return func() {}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			// 1回で処理しきれなかった場合は続けて処理する
+			for {
+				count, err := s.DeliverDue(time.Now())
+				if err != nil {
+					log.Printf("Webhookの配信に失敗しました: %v", err)
+				}
+				if err != nil || count < webhookDeliveryBatchSize {
+					break
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// send 配信を1回送信し、HTTPステータスと失敗時のエラーメッセージを返します
+// 2xx以外のステータスとタイムアウトは失敗として扱います
+func (s *webhookService) send(webhook *domain.Webhook, delivery *domain.WebhookDelivery) (*int, string) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Sprintf("リクエスト作成エラー: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "simple-kanban-webhook/1.0")
+	req.Header.Set("X-Kanban-Event", string(delivery.Event))
+	req.Header.Set("X-Kanban-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Kanban-Signature", domain.SignWebhookPayload(webhook.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Sprintf("送信エラー: %v", err)
+	}
+	defer resp.Body.Close()
+	// コネクションを再利用できるよう、レスポンスを読み捨てる
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		return &status, fmt.Sprintf("HTTPステータス %d が返されました", status)
+	}
+	return &status, ""
+}
+
+// newWebhookHTTPClient 配信用の既定のHTTPクライアントを作成します
+// 通知先を使って内部ネットワークを探索されないよう、名前解決後のIPアドレスがループバック・プライベート・
+// リンクローカル等の場合は接続せず、リダイレクトにも従いません
+func newWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookDeliveryTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isInternalIP(ip) {
+				return fmt.Errorf("内部ネットワークのアドレス %s には送信できません", host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: webhookDeliveryTimeout,
+		Transport: &http.Transport{
+			// プロキシを経由すると接続先のチェックが意味をなさないため、環境変数のプロキシは使用しない
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookDeliveryTimeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookInternalNetworks net.IPの判定メソッドで扱われない、内部ネットワークとして扱うアドレス範囲
+var webhookInternalNetworks = func() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",     // 「このネットワーク」（0.0.0.0以外も自ホストとして扱われる場合がある）
+		"100.64.0.0/10", // キャリアグレードNATの共有アドレス（クラウドの内部ネットワークで使われる）
+		"192.0.0.0/24",  // IETFプロトコル割り当て
+	} {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}()
+
+// isInternalIP 外部から到達できない（内部ネットワークの）IPアドレスかどうかを判定します
+func isInternalIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, network := range webhookInternalNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue イベントをwebhooksへの配信として配信キューに追加します
+func (s *webhookService) enqueue(webhooks []domain.Webhook, boardID uint, event domain.WebhookEvent, data interface{}) error {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(domain.WebhookPayload{
+		Event:      event,
+		BoardID:    boardID,
+		OccurredAt: now,
+		Data:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("Webhookのペイロード作成エラー: %w", err)
+	}
+
+	deliveries := make([]domain.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, domain.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		})
+	}
+	if err := s.webhookRepo.CreateDeliveries(deliveries); err != nil {
+		return fmt.Errorf("配信キュー追加エラー: %w", err)
+	}
+	return nil
+}
+
+// getWebhook Webhookを取得し、ボードの所有権をチェックします
+func (s *webhookService) getWebhook(webhookID uint, userID uuid.UUID) (*domain.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook取得エラー: %w", err)
+	}
+	if webhook == nil {
+		return nil, errors.New("Webhookが見つかりません")
+	}
+	if err := s.checkBoardOwnership(webhook.BoardID, userID); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// checkBoardOwnership ボードへのアクセス権をチェックします
+func (s *webhookService) checkBoardOwnership(boardID uint, userID uuid.UUID) error {
+	board, err := s.boardRepo.GetByID(boardID)
+	if err != nil {
+		return fmt.Errorf("ボード取得エラー: %w", err)
+	}
+	if board == nil {
+		return errors.New("ボードが見つかりません")
+	}
+	member, err := isBoardMember(s.boardRepo, board.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return errors.New("このボードにアクセスする権限がありません")
+	}
+	return nil
+}
+
+// generateWebhookSecret ランダムなシークレット（32バイトの16進数）を生成します
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("シークレット生成エラー: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// webhookEvent トランザクションのコミット後に配信キューへ追加するイベント
+type webhookEvent struct {
+	boardID uint
+	event   domain.WebhookEvent
+	data    interface{}
+}
+
+// publishTaskEvent タスクを関連データと共に取得し直し、タスクのボードにイベントを通知します
+// 通知に失敗しても元の操作は失敗させないため、取得エラーはログに出力するのみです
+func publishTaskEvent(webhooks WebhookPublisher, taskRepo repository.TaskRepository, taskID uint, event domain.WebhookEvent) {
+	task, err := taskRepo.GetByID(taskID)
+	if err != nil || task == nil {
+		log.Printf("Webhook通知用のタスク取得エラー: task=%d err=%v", taskID, err)
+		return
+	}
+	webhooks.Publish(task.Column.BoardID, event, task)
+}
+
+// newTaskMovedData task.movedイベントのデータを作成します
+func newTaskMovedData(task *domain.Task, fromColumnID uint, fromLaneID *uint) map[string]interface{} {
+	return map[string]interface{}{
+		"task":           task,
+		"from_column_id": fromColumnID,
+		"to_column_id":   task.ColumnID,
+		"from_lane_id":   fromLaneID,
+		"to_lane_id":     task.LaneID,
+	}
+}
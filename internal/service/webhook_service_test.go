@@ -0,0 +1,267 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"simple-kanban/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWebhookRepository テスト用のメモリ上のWebhookRepository
+type memoryWebhookRepository struct {
+	webhooks   map[uint]*domain.Webhook
+	deliveries []domain.WebhookDelivery
+}
+
+func newMemoryWebhookRepository(webhooks ...domain.Webhook) *memoryWebhookRepository {
+	repo := &memoryWebhookRepository{webhooks: make(map[uint]*domain.Webhook)}
+	for i := range webhooks {
+		repo.webhooks[webhooks[i].ID] = &webhooks[i]
+	}
+	return repo
+}
+
+func (r *memoryWebhookRepository) Create(webhook *domain.Webhook) error {
+	webhook.ID = uint(len(r.webhooks) + 1)
+	r.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetByID(id uint) (*domain.Webhook, error) {
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *webhook
+	return &copied, nil
+}
+
+func (r *memoryWebhookRepository) GetByBoardID(boardID uint) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	for id := uint(1); id <= uint(len(r.webhooks)); id++ {
+		if webhook, ok := r.webhooks[id]; ok && webhook.BoardID == boardID {
+			webhooks = append(webhooks, *webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *memoryWebhookRepository) Update(webhook *domain.Webhook) error {
+	r.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (r *memoryWebhookRepository) Delete(id uint) error {
+	delete(r.webhooks, id)
+	return nil
+}
+
+func (r *memoryWebhookRepository) CreateDeliveries(deliveries []domain.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		delivery.ID = uint(len(r.deliveries) + 1)
+		r.deliveries = append(r.deliveries, delivery)
+	}
+	return nil
+}
+
+func (r *memoryWebhookRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	var due []domain.WebhookDelivery
+	for i := range r.deliveries {
+		delivery := &r.deliveries[i]
+		if delivery.Status == domain.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) && len(due) < limit {
+			delivery.NextAttemptAt = now.Add(lease)
+			due = append(due, *delivery)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryWebhookRepository) GetDeliveriesByWebhookID(webhookID uint, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	for i := len(r.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if r.deliveries[i].WebhookID == webhookID {
+			deliveries = append(deliveries, r.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *memoryWebhookRepository) UpdateDelivery(delivery *domain.WebhookDelivery) error {
+	r.deliveries[delivery.ID-1] = *delivery
+	return nil
+}
+
+// webhookReceiver テスト用のWebhookの受信サーバー
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int // 返すHTTPステータス
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+// イベントの絞り込み、署名付きの配信、失敗時の再試行（指数バックオフ）のテスト
+func TestWebhookService_PublishAndDeliver(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusInternalServerError}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := newMemoryWebhookRepository(
+		domain.Webhook{ID: 1, BoardID: 10, URL: server.URL, Secret: "s3cret", Events: domain.StringList{"task.moved"}, Active: true},
+		domain.Webhook{ID: 2, BoardID: 10, URL: server.URL, Secret: "other", Events: domain.StringList{"task.deleted"}, Active: true},
+		domain.Webhook{ID: 3, BoardID: 10, URL: server.URL, Secret: "off", Active: false},
+	)
+	svc := NewWebhookService(repo, nil, server.Client())
+
+	task := &domain.Task{ID: 5, ColumnID: 2, Title: "設計"}
+	svc.Publish(10, domain.WebhookEventTaskMoved, newTaskMovedData(task, 1, nil))
+	require.Len(t, repo.deliveries, 1, "購読しているイベントで、有効なWebhookのみ配信キューに追加する")
+
+	// 1回目は受信側のエラーで失敗し、バックオフ後に再試行を予定する
+	now := time.Now()
+	count, err := svc.DeliverDue(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	delivery := repo.deliveries[0]
+	assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, *delivery.ResponseStatus)
+	assert.NotEmpty(t, delivery.LastError)
+	assert.Equal(t, now.Add(domain.WebhookInitialRetryWait), delivery.NextAttemptAt)
+
+	// 再試行の予定時刻より前には送信しない
+	count, err = svc.DeliverDue(now.Add(domain.WebhookInitialRetryWait - time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// 2回目で成功する
+	receiver.setStatus(http.StatusNoContent)
+	_, err = svc.DeliverDue(now.Add(domain.WebhookInitialRetryWait))
+	require.NoError(t, err)
+	delivery = repo.deliveries[0]
+	assert.Equal(t, domain.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Empty(t, delivery.LastError)
+
+	// 受信した内容と署名を検証
+	require.Len(t, receiver.requests, 2)
+	req, body := receiver.requests[1], receiver.bodies[1]
+	assert.Equal(t, "task.moved", req.Header.Get("X-Kanban-Event"))
+	assert.Equal(t, "1", req.Header.Get("X-Kanban-Delivery"))
+	assert.Equal(t, domain.SignWebhookPayload("s3cret", body), req.Header.Get("X-Kanban-Signature"))
+
+	var payload struct {
+		Event   string `json:"event"`
+		BoardID uint   `json:"board_id"`
+		Data    struct {
+			Task         domain.Task `json:"task"`
+			FromColumnID uint        `json:"from_column_id"`
+			ToColumnID   uint        `json:"to_column_id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "task.moved", payload.Event)
+	assert.Equal(t, uint(10), payload.BoardID)
+	assert.Equal(t, "設計", payload.Data.Task.Title)
+	assert.Equal(t, uint(1), payload.Data.FromColumnID)
+	assert.Equal(t, uint(2), payload.Data.ToColumnID)
+}
+
+// 再試行の上限に達した配信は失敗として記録し、それ以上送信しないことのテスト
+func TestWebhookService_GivesUpAfterMaxAttempts(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusBadGateway}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := newMemoryWebhookRepository(domain.Webhook{ID: 1, BoardID: 10, URL: server.URL, Secret: "s3cret", Active: true})
+	svc := NewWebhookService(repo, nil, server.Client())
+	svc.Publish(10, domain.WebhookEventTaskCreated, &domain.Task{ID: 5})
+
+	now := time.Now()
+	for i := 0; i < domain.WebhookMaxAttempts+2; i++ {
+		_, err := svc.DeliverDue(now)
+		require.NoError(t, err)
+		now = now.Add(domain.WebhookMaxRetryWait)
+	}
+
+	assert.Equal(t, domain.WebhookDeliveryFailed, repo.deliveries[0].Status)
+	assert.Equal(t, domain.WebhookMaxAttempts, repo.deliveries[0].Attempts)
+	assert.Len(t, receiver.requests, domain.WebhookMaxAttempts)
+}
+
+// 既定のクライアントは内部ネットワーク（ループバック等）のアドレスに送信しないことのテスト
+func TestWebhookService_RejectsInternalAddresses(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := newMemoryWebhookRepository(domain.Webhook{ID: 1, BoardID: 10, URL: server.URL, Secret: "s3cret", Active: true})
+	svc := NewWebhookService(repo, nil, nil)
+	svc.Publish(10, domain.WebhookEventTaskCreated, &domain.Task{ID: 5})
+
+	_, err := svc.DeliverDue(time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, receiver.requests)
+	assert.Nil(t, repo.deliveries[0].ResponseStatus)
+	assert.Contains(t, repo.deliveries[0].LastError, "内部ネットワーク")
+}
+
+func TestIsInternalIP(t *testing.T) {
+	tests := []struct {
+		ip       string
+		internal bool
+	}{
+		{"127.0.0.1", true},
+		{"10.0.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"0.0.0.0", true},
+		{"0.1.2.3", true},
+		{"100.64.0.1", true},
+		{"100.127.255.254", true},
+		{"::ffff:100.64.0.1", true},
+		{"192.0.0.8", true},
+		{"::1", true},
+		{"fd00::1", true},
+		{"93.184.216.34", false},
+		{"100.63.255.255", false},
+		{"100.128.0.1", false},
+		{"192.0.1.1", false},
+		{"2606:2800:220:1::1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.internal, isInternalIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestWebhookRetryWait(t *testing.T) {
+	assert.Equal(t, 30*time.Second, domain.WebhookRetryWait(1))
+	assert.Equal(t, 60*time.Second, domain.WebhookRetryWait(2))
+	assert.Equal(t, 4*time.Minute, domain.WebhookRetryWait(4))
+	assert.Equal(t, domain.WebhookMaxRetryWait, domain.WebhookRetryWait(20))
+}
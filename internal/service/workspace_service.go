@@ -0,0 +1,323 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceService ワークスペースとメンバーの管理を行うインターフェース
+type WorkspaceService interface {
+	CreateWorkspace(userID uuid.UUID, name string) (*domain.Workspace, error)
+	GetUserWorkspaces(userID uuid.UUID) ([]domain.Workspace, error)
+	UpdateWorkspace(workspaceID uint, userID uuid.UUID, name string) (*domain.Workspace, error)
+	DeleteWorkspace(workspaceID uint, userID uuid.UUID) error
+	GetMembers(workspaceID uint, userID uuid.UUID) ([]domain.WorkspaceMember, error)
+	AddMember(workspaceID uint, userID uuid.UUID, email string, role domain.WorkspaceRole) (*domain.WorkspaceMember, error)
+	UpdateMemberRole(workspaceID uint, userID, memberID uuid.UUID, role domain.WorkspaceRole) (*domain.WorkspaceMember, error)
+	RemoveMember(workspaceID uint, userID, memberID uuid.UUID) error
+}
+
+// workspaceService WorkspaceServiceの実装
+type workspaceService struct {
+	workspaceRepo repository.WorkspaceRepository
+	userRepo      repository.UserRepository
+}
+
+// NewWorkspaceService WorkspaceServiceの新しいインスタンスを作成
+func NewWorkspaceService(workspaceRepo repository.WorkspaceRepository, userRepo repository.UserRepository) WorkspaceService {
+	return &workspaceService{
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// CreateWorkspace 共有用のワークスペースを作成します（作成したユーザーは管理者）
+func (s *workspaceService) CreateWorkspace(userID uuid.UUID, name string) (*domain.Workspace, error) {
+	name, err := normalizeWorkspaceName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := &domain.Workspace{
+		Name:    name,
+		Members: []domain.WorkspaceMember{{UserID: userID, Role: domain.WorkspaceRoleAdmin}},
+	}
+	if err := s.workspaceRepo.Create(workspace); err != nil {
+		return nil, fmt.Errorf("ワークスペース作成エラー: %w", err)
+	}
+	return workspace, nil
+}
+
+// GetUserWorkspaces ユーザーがメンバーのワークスペース一覧を取得します
+// 各ワークスペースのMembersにはユーザー自身のメンバー情報（役割）のみを含みます
+func (s *workspaceService) GetUserWorkspaces(userID uuid.UUID) ([]domain.Workspace, error) {
+	workspaces, err := s.workspaceRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ワークスペース取得エラー: %w", err)
+	}
+	return workspaces, nil
+}
+
+// UpdateWorkspace ワークスペースの名前を変更します（管理者のみ）
+func (s *workspaceService) UpdateWorkspace(workspaceID uint, userID uuid.UUID, name string) (*domain.Workspace, error) {
+	workspace, err := s.getAdminWorkspace(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.Name, err = normalizeWorkspaceName(name); err != nil {
+		return nil, err
+	}
+
+	if err := s.workspaceRepo.Update(workspace); err != nil {
+		return nil, fmt.Errorf("ワークスペース更新エラー: %w", err)
+	}
+	return workspace, nil
+}
+
+// DeleteWorkspace ワークスペースを削除します（管理者のみ）
+// ボード（ゴミ箱のボードを含む）がある場合は、先に別のワークスペースへの移動か削除が必要です
+func (s *workspaceService) DeleteWorkspace(workspaceID uint, userID uuid.UUID) error {
+	workspace, err := s.getAdminWorkspace(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if workspace.Personal {
+		return errors.New("個人用ワークスペースは削除できません")
+	}
+
+	count, err := s.workspaceRepo.CountBoards(workspace.ID)
+	if err != nil {
+		return fmt.Errorf("ボード数取得エラー: %w", err)
+	}
+	if count > 0 {
+		return errors.New("ボードがあるワークスペースは削除できません。先にボードを移動するか削除してください（ゴミ箱のボードも含みます）")
+	}
+
+	if err := s.workspaceRepo.Delete(workspace.ID); err != nil {
+		return fmt.Errorf("ワークスペース削除エラー: %w", err)
+	}
+	return nil
+}
+
+// GetMembers ワークスペースのメンバー一覧を取得します（メンバーのみ）
+func (s *workspaceService) GetMembers(workspaceID uint, userID uuid.UUID) ([]domain.WorkspaceMember, error) {
+	if _, err := getWorkspaceMember(s.workspaceRepo, workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.workspaceRepo.GetMembers(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("メンバー取得エラー: %w", err)
+	}
+	return members, nil
+}
+
+// AddMember メールアドレスのユーザーをワークスペースに追加します（管理者のみ）
+func (s *workspaceService) AddMember(workspaceID uint, userID uuid.UUID, email string, role domain.WorkspaceRole) (*domain.WorkspaceMember, error) {
+	workspace, err := s.getAdminWorkspace(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.Personal {
+		return nil, errors.New("個人用ワークスペースにはメンバーを追加できません")
+	}
+	if role == "" {
+		role = domain.WorkspaceRoleMember
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("不正な役割です: %s", role)
+	}
+
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー取得エラー: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("このメールアドレスのユーザーが見つかりません")
+	}
+	existing, err := s.workspaceRepo.GetMember(workspace.ID, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("メンバー取得エラー: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.New("このユーザーは既にメンバーです")
+	}
+
+	member := &domain.WorkspaceMember{WorkspaceID: workspace.ID, UserID: user.ID, Role: role}
+	if err := s.workspaceRepo.AddMember(member); err != nil {
+		return nil, fmt.Errorf("メンバー追加エラー: %w", err)
+	}
+	member.User = user
+	return member, nil
+}
+
+// UpdateMemberRole メンバーの役割を変更します（管理者のみ）
+// 管理者が1人もいなくなる変更はできません
+func (s *workspaceService) UpdateMemberRole(workspaceID uint, userID, memberID uuid.UUID, role domain.WorkspaceRole) (*domain.WorkspaceMember, error) {
+	workspace, err := s.getAdminWorkspace(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("不正な役割です: %s", role)
+	}
+
+	member, err := s.workspaceRepo.GetMember(workspace.ID, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("メンバー取得エラー: %w", err)
+	}
+	if member == nil {
+		return nil, errors.New("メンバーが見つかりません")
+	}
+	if member.Role == role {
+		return member, nil
+	}
+	if member.Role == domain.WorkspaceRoleAdmin {
+		if err := s.checkOtherAdmins(workspace.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.workspaceRepo.UpdateMemberRole(workspace.ID, memberID, role); err != nil {
+		return nil, fmt.Errorf("メンバー更新エラー: %w", err)
+	}
+	member.Role = role
+	return member, nil
+}
+
+// RemoveMember メンバーをワークスペースから外します（管理者、または本人が退出する場合）
+// 管理者が1人もいなくなる変更はできません
+func (s *workspaceService) RemoveMember(workspaceID uint, userID, memberID uuid.UUID) error {
+	self, err := getWorkspaceMember(s.workspaceRepo, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && self.Role != domain.WorkspaceRoleAdmin {
+		return errors.New("この操作にはワークスペースの管理者の権限が必要です")
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return fmt.Errorf("ワークスペース取得エラー: %w", err)
+	}
+	if workspace == nil {
+		return errors.New("ワークスペースが見つかりません")
+	}
+	if workspace.Personal {
+		return errors.New("個人用ワークスペースからは退出できません")
+	}
+
+	member, err := s.workspaceRepo.GetMember(workspaceID, memberID)
+	if err != nil {
+		return fmt.Errorf("メンバー取得エラー: %w", err)
+	}
+	if member == nil {
+		return errors.New("メンバーが見つかりません")
+	}
+	if member.Role == domain.WorkspaceRoleAdmin {
+		if err := s.checkOtherAdmins(workspaceID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.workspaceRepo.RemoveMember(workspaceID, memberID); err != nil {
+		return fmt.Errorf("メンバー削除エラー: %w", err)
+	}
+	return nil
+}
+
+// getAdminWorkspace ユーザーが管理者のワークスペースを取得します
+func (s *workspaceService) getAdminWorkspace(workspaceID uint, userID uuid.UUID) (*domain.Workspace, error) {
+	if err := checkWorkspaceAdmin(s.workspaceRepo, workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("ワークスペース取得エラー: %w", err)
+	}
+	if workspace == nil {
+		return nil, errors.New("ワークスペースが見つかりません")
+	}
+	return workspace, nil
+}
+
+// checkOtherAdmins 管理者を外す前に、他の管理者がいるかをチェックします
+func (s *workspaceService) checkOtherAdmins(workspaceID uint) error {
+	admins, err := s.workspaceRepo.CountAdmins(workspaceID)
+	if err != nil {
+		return fmt.Errorf("管理者数取得エラー: %w", err)
+	}
+	if admins <= 1 {
+		return errors.New("ワークスペースには管理者が1人以上必要です。先に他のメンバーを管理者にしてください")
+	}
+	return nil
+}
+
+// getWorkspaceMember ユーザーのワークスペースのメンバー情報を取得します（メンバーでない場合はエラー）
+func getWorkspaceMember(workspaceRepo repository.WorkspaceRepository, workspaceID uint, userID uuid.UUID) (*domain.WorkspaceMember, error) {
+	member, err := workspaceRepo.GetMember(workspaceID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("メンバー取得エラー: %w", err)
+	}
+	if member == nil {
+		// 他のユーザーのワークスペースの存在は明かさない
+		return nil, errors.New("ワークスペースが見つかりません")
+	}
+	return member, nil
+}
+
+// checkWorkspaceAdmin ユーザーがワークスペースの管理者であることをチェックします
+func checkWorkspaceAdmin(workspaceRepo repository.WorkspaceRepository, workspaceID uint, userID uuid.UUID) error {
+	member, err := getWorkspaceMember(workspaceRepo, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role != domain.WorkspaceRoleAdmin {
+		return errors.New("この操作にはワークスペースの管理者の権限が必要です")
+	}
+	return nil
+}
+
+// resolveWorkspace ボードを作成するワークスペースを決定します
+// workspaceIDが0の場合はユーザーの個人用ワークスペース、それ以外はメンバーであることをチェックします
+func resolveWorkspace(workspaceRepo repository.WorkspaceRepository, userID uuid.UUID, workspaceID uint) (uint, error) {
+	if workspaceID != 0 {
+		if _, err := getWorkspaceMember(workspaceRepo, workspaceID, userID); err != nil {
+			return 0, err
+		}
+		return workspaceID, nil
+	}
+
+	workspace, err := workspaceRepo.GetPersonal(userID)
+	if err != nil {
+		return 0, fmt.Errorf("ワークスペース取得エラー: %w", err)
+	}
+	if workspace == nil {
+		// ワークスペース導入前に作成され、まだマイグレーションされていないユーザー
+		workspace = domain.NewPersonalWorkspace(userID)
+		if err := workspaceRepo.Create(workspace); err != nil {
+			return 0, fmt.Errorf("ワークスペース作成エラー: %w", err)
+		}
+	}
+	return workspace.ID, nil
+}
+
+// normalizeWorkspaceName ワークスペース名の前後の空白を除き、長さをチェックします
+func normalizeWorkspaceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("ワークスペース名を指定してください")
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return "", errors.New("ワークスペース名は100文字以内で指定してください")
+	}
+	return name, nil
+}
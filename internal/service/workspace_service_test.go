@@ -0,0 +1,143 @@
+package service
+
+import (
+	"testing"
+
+	"simple-kanban/internal/domain"
+	"simple-kanban/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWorkspaceRepository テスト用のメモリ上のWorkspaceRepository（使用するメソッドのみ実装）
+type memoryWorkspaceRepository struct {
+	repository.WorkspaceRepository
+	workspaces map[uint]*domain.Workspace
+	members    map[uint]map[uuid.UUID]*domain.WorkspaceMember
+	boards     map[uint]int64
+}
+
+func (r *memoryWorkspaceRepository) Create(workspace *domain.Workspace) error {
+	workspace.ID = uint(len(r.workspaces) + 1)
+	r.workspaces[workspace.ID] = workspace
+	r.members[workspace.ID] = make(map[uuid.UUID]*domain.WorkspaceMember)
+	for i := range workspace.Members {
+		workspace.Members[i].WorkspaceID = workspace.ID
+		member := workspace.Members[i]
+		r.members[workspace.ID][member.UserID] = &member
+	}
+	return nil
+}
+
+func (r *memoryWorkspaceRepository) GetByID(id uint) (*domain.Workspace, error) {
+	return r.workspaces[id], nil
+}
+
+func (r *memoryWorkspaceRepository) GetPersonal(userID uuid.UUID) (*domain.Workspace, error) {
+	for id, workspace := range r.workspaces {
+		if _, ok := r.members[id][userID]; ok && workspace.Personal {
+			return workspace, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryWorkspaceRepository) Delete(id uint) error {
+	delete(r.workspaces, id)
+	delete(r.members, id)
+	return nil
+}
+
+func (r *memoryWorkspaceRepository) CountBoards(id uint) (int64, error) {
+	return r.boards[id], nil
+}
+
+func (r *memoryWorkspaceRepository) GetMember(workspaceID uint, userID uuid.UUID) (*domain.WorkspaceMember, error) {
+	if member, ok := r.members[workspaceID][userID]; ok {
+		copied := *member
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *memoryWorkspaceRepository) AddMember(member *domain.WorkspaceMember) error {
+	copied := *member
+	r.members[member.WorkspaceID][member.UserID] = &copied
+	return nil
+}
+
+func (r *memoryWorkspaceRepository) UpdateMemberRole(workspaceID uint, userID uuid.UUID, role domain.WorkspaceRole) error {
+	r.members[workspaceID][userID].Role = role
+	return nil
+}
+
+func (r *memoryWorkspaceRepository) RemoveMember(workspaceID uint, userID uuid.UUID) error {
+	delete(r.members[workspaceID], userID)
+	return nil
+}
+
+func (r *memoryWorkspaceRepository) CountAdmins(workspaceID uint) (int64, error) {
+	var count int64
+	for _, member := range r.members[workspaceID] {
+		if member.Role == domain.WorkspaceRoleAdmin {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// 役割による操作の制限と、管理者が1人以上残ることのテスト
+func TestWorkspaceService_Members(t *testing.T) {
+	alice := &domain.User{ID: uuid.New(), Email: "alice@example.com"}
+	bob := &domain.User{ID: uuid.New(), Email: "bob@example.com"}
+	users := &memoryUserRepository{users: map[uuid.UUID]*domain.User{alice.ID: alice, bob.ID: bob}}
+	workspaces := &memoryWorkspaceRepository{
+		workspaces: make(map[uint]*domain.Workspace),
+		members:    make(map[uint]map[uuid.UUID]*domain.WorkspaceMember),
+		boards:     make(map[uint]int64),
+	}
+	svc := NewWorkspaceService(workspaces, users)
+
+	personalID, err := resolveWorkspace(workspaces, alice.ID, 0)
+	require.NoError(t, err)
+	_, err = svc.AddMember(personalID, alice.ID, bob.Email, domain.WorkspaceRoleMember)
+	assert.Error(t, err, "個人用ワークスペースにはメンバーを追加できない")
+
+	team, err := svc.CreateWorkspace(alice.ID, "  開発チーム ")
+	require.NoError(t, err)
+	assert.Equal(t, "開発チーム", team.Name)
+
+	_, err = svc.AddMember(team.ID, alice.ID, "nobody@example.com", "")
+	assert.Error(t, err, "登録されていないユーザーは追加できない")
+	member, err := svc.AddMember(team.ID, alice.ID, bob.Email, "")
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkspaceRoleMember, member.Role, "役割の省略時はメンバー")
+
+	// メンバーはボードを作成できるが、ワークスペースの管理はできない
+	workspaceID, err := resolveWorkspace(workspaces, bob.ID, team.ID)
+	require.NoError(t, err)
+	assert.Equal(t, team.ID, workspaceID)
+	_, err = svc.UpdateWorkspace(team.ID, bob.ID, "名前変更")
+	assert.Error(t, err)
+	assert.Error(t, svc.RemoveMember(team.ID, bob.ID, alice.ID))
+
+	// 唯一の管理者は役割を変更・退出できない
+	_, err = svc.UpdateMemberRole(team.ID, alice.ID, alice.ID, domain.WorkspaceRoleMember)
+	assert.Error(t, err)
+	assert.Error(t, svc.RemoveMember(team.ID, alice.ID, alice.ID))
+
+	_, err = svc.UpdateMemberRole(team.ID, alice.ID, bob.ID, domain.WorkspaceRoleAdmin)
+	require.NoError(t, err)
+	require.NoError(t, svc.RemoveMember(team.ID, alice.ID, alice.ID), "他に管理者がいれば退出できる")
+	_, err = resolveWorkspace(workspaces, alice.ID, team.ID)
+	assert.Error(t, err, "退出したワークスペースにはボードを作成できない")
+
+	// ボードのあるワークスペースは削除できない
+	workspaces.boards[team.ID] = 1
+	assert.Error(t, svc.DeleteWorkspace(team.ID, bob.ID))
+	workspaces.boards[team.ID] = 0
+	require.NoError(t, svc.DeleteWorkspace(team.ID, bob.ID))
+	assert.Error(t, svc.DeleteWorkspace(personalID, alice.ID), "個人用ワークスペースは削除できない")
+}
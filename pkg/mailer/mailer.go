@@ -0,0 +1,180 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"simple-kanban/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Message 送信するメール（本文はプレーンテキスト）
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer メールの送信を行うインターフェース
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New 設定に応じたMailerを作成します
+// SMTPサーバーが設定されていない場合は、送信する代わりにファイルへ保存します
+// ファイルへの保存は開発用のため、Ginのモード（mode）が debug か test の場合のみ許可し、それ以外はエラーを返します
+func New(cfg config.MailConfig, mode string) (Mailer, error) {
+	if cfg.SMTPHost != "" {
+		return NewSMTPMailer(cfg), nil
+	}
+	if mode != gin.DebugMode && mode != gin.TestMode {
+		return nil, errors.New("SMTP_HOST が設定されていません。メールをファイルに保存するのは debug・test モードのみです")
+	}
+	return NewFileMailer(cfg.OutboxDir, cfg.From), nil
+}
+
+// smtpMailer SMTPサーバーでメールを送信するMailerの実装
+type smtpMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer SMTPサーバーでメールを送信するMailerを作成
+// ユーザー名が設定されている場合はPLAIN認証を行います（net/smtpはTLSでない接続での認証を拒否します）
+func NewSMTPMailer(cfg config.MailConfig) Mailer {
+	return &smtpMailer{
+		addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		host:     cfg.SMTPHost,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		from:     cfg.From,
+	}
+}
+
+// Send メールを送信します
+func (m *smtpMailer) Send(msg Message) error {
+	data, err := render(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	if err := smtp.SendMail(m.addr, auth, m.from, []string{msg.To}, data); err != nil {
+		return fmt.Errorf("メール送信エラー: %w", err)
+	}
+	return nil
+}
+
+// fileMailer メールを送信せずに1通ずつ.emlファイルとして保存するMailerの実装
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer メールをディレクトリに保存するMailerを作成
+func NewFileMailer(dir, from string) Mailer {
+	return &fileMailer{dir: dir, from: from}
+}
+
+// Send メールをファイルに保存します
+func (m *fileMailer) Send(msg Message) error {
+	now := time.Now()
+	data, err := render(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("メール保存先の作成エラー: %w", err)
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("乱数生成エラー: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), hex.EncodeToString(suffix))
+	if err := os.WriteFile(filepath.Join(m.dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("メール保存エラー: %w", err)
+	}
+	return nil
+}
+
+// MemoryMailer 送信したメールをメモリ上に保持するMailerの実装（テスト用）
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemoryMailer メールをメモリ上に保持するMailerを作成
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send メールを保持します
+func (m *MemoryMailer) Send(msg Message) error {
+	if _, err := render("", msg, time.Now()); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages これまでに送信したメールを返します
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// render メールをヘッダー付きのメッセージ（本文はUTF-8のBase64）に変換します
+func render(from string, msg Message, now time.Time) ([]byte, error) {
+	// ヘッダーインジェクションを防ぐ
+	for _, v := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("メールのヘッダーに改行は使用できません")
+		}
+	}
+	if msg.To == "" {
+		return nil, errors.New("メールの宛先がありません")
+	}
+
+	var buf bytes.Buffer
+	if from != "" {
+		fmt.Fprintf(&buf, "From: %s\r\n", from)
+	}
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes(), nil
+}
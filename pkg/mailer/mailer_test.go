@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"testing"
+
+	"simple-kanban/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SMTPサーバーが未設定の場合、ファイルへの保存は debug・test モードでのみ許可されることのテスト
+func TestNew_FileMailerOnlyInDevelopment(t *testing.T) {
+	cfg := config.MailConfig{OutboxDir: t.TempDir(), From: "noreply@example.com"}
+
+	for _, mode := range []string{gin.DebugMode, gin.TestMode} {
+		mail, err := New(cfg, mode)
+		require.NoError(t, err)
+		assert.NotNil(t, mail)
+	}
+
+	_, err := New(cfg, gin.ReleaseMode)
+	assert.Error(t, err)
+
+	cfg.SMTPHost = "smtp.example.com"
+	cfg.SMTPPort = 587
+	mail, err := New(cfg, gin.ReleaseMode)
+	require.NoError(t, err)
+	assert.NotNil(t, mail)
+}
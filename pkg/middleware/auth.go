@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -14,13 +17,48 @@ import (
 
 // Claims JWTクレーム構造体
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
+	UserID  uuid.UUID `json:"user_id"`
+	Email   string    `json:"email"`
+	Purpose string    `json:"purpose,omitempty"` // 空の場合はAPIを利用するためのトークン
+	Version int       `json:"ver,omitempty"`     // 発行時のユーザーのJWTの世代（SessionValidatorで照合）
 	jwt.RegisteredClaims
 }
 
-// AuthMiddleware JWT認証ミドルウェア
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+// TokenPurposeTwoFactor 二要素認証のコードの入力を待っているログインのトークンの用途（APIの利用には使用できない）
+const TokenPurposeTwoFactor = "two_factor"
+
+// ErrTokenForbidden パーソナルアクセストークンのスコープで許可されていない操作の場合のエラー
+var ErrTokenForbidden = errors.New("このトークンにはこの操作の権限がありません")
+
+// PersonalAccessTokenPrefix パーソナルアクセストークンの接頭辞（JWTと区別するために使用）
+const PersonalAccessTokenPrefix = "skpat_"
+
+// スコープのチェックのために読み取るリクエストボディの上限
+const tokenAccessBodyLimit = 1 << 20
+
+// TokenAccess パーソナルアクセストークンでのリクエストの内容（スコープのチェックに使用）
+type TokenAccess struct {
+	Method string
+	Route  string            // ルートのパターン（例: /api/v1/tasks/:id）
+	Params map[string]string // パスパラメータ
+	Body   func() []byte     // リクエストボディ（読み取った後も後続のハンドラで読めるよう復元する。上限を超える場合はnil）
+}
+
+// TokenAuthenticator パーソナルアクセストークンを検証するインターフェース
+// トークンが無効な場合はエラーを、スコープで許可されていない場合はErrTokenForbiddenを返します
+type TokenAuthenticator interface {
+	AuthenticateToken(token string, access TokenAccess) (uuid.UUID, string, error)
+}
+
+// SessionValidator JWTのユーザーのセッションが有効か（アカウントが削除されていないか、パスワードの変更前に発行されていないか）を検証するインターフェース
+type SessionValidator interface {
+	ValidateSession(userID uuid.UUID, tokenVersion int) error
+}
+
+// AuthMiddleware 認証ミドルウェア
+// JWTと、tokensがnilでない場合はパーソナルアクセストークンを受け付けます
+// sessionsがnilでない場合は、JWTのユーザーのセッションが有効かも検証します
+func AuthMiddleware(cfg *config.Config, tokens TokenAuthenticator, sessions SessionValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Authorizationヘッダーからトークンを取得
 		authHeader := c.GetHeader("Authorization")
@@ -44,6 +82,29 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 
 		tokenString := tokenParts[1]
 
+		// パーソナルアクセストークンを検証
+		if tokens != nil && strings.HasPrefix(tokenString, PersonalAccessTokenPrefix) {
+			userID, email, err := tokens.AuthenticateToken(tokenString, newTokenAccess(c))
+			if err != nil {
+				if errors.Is(err, ErrTokenForbidden) {
+					c.JSON(http.StatusForbidden, gin.H{
+						"error": err.Error(),
+					})
+				} else {
+					c.JSON(http.StatusUnauthorized, gin.H{
+						"error": "無効な認証トークンです",
+					})
+				}
+				c.Abort()
+				return
+			}
+
+			c.Set("user_id", userID)
+			c.Set("user_email", email)
+			c.Next()
+			return
+		}
+
 		// JWTトークンを検証
 		claims := &Claims{}
 		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -54,7 +115,7 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 			return []byte(cfg.JWT.SecretKey), nil
 		})
 
-		if err != nil || !token.Valid {
+		if err != nil || !token.Valid || claims.Purpose != "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "無効な認証トークンです",
 			})
@@ -62,6 +123,17 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
+		// 削除したアカウントのJWTと、パスワードの変更前に発行したJWTは有効期限内でも使用できないようにする
+		if sessions != nil {
+			if err := sessions.ValidateSession(claims.UserID, claims.Version); err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "無効な認証トークンです",
+				})
+				c.Abort()
+				return
+			}
+		}
+
 		// ユーザー情報をコンテキストに設定
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
@@ -70,13 +142,41 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 	}
 }
 
+// newTokenAccess リクエストからスコープのチェックに使用する内容を取り出します
+func newTokenAccess(c *gin.Context) TokenAccess {
+	params := make(map[string]string, len(c.Params))
+	for _, p := range c.Params {
+		params[p.Key] = p.Value
+	}
+
+	return TokenAccess{
+		Method: c.Request.Method,
+		Route:  c.FullPath(),
+		Params: params,
+		Body: func() []byte {
+			if c.Request.Body == nil {
+				return nil
+			}
+			body, _ := io.ReadAll(io.LimitReader(c.Request.Body, tokenAccessBodyLimit+1))
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+			if len(body) > tokenAccessBodyLimit {
+				// 途中までの内容ではハンドラが読むボディと異なる可能性があるため、チェックに使用させない
+				return nil
+			}
+			return body
+		},
+	}
+}
+
 // GenerateToken JWTトークンを生成します
-func GenerateToken(userID uuid.UUID, email string, cfg *config.Config) (string, error) {
+// tokenVersionにはユーザーの現在のJWTの世代を指定します
+func GenerateToken(userID uuid.UUID, email string, tokenVersion int, cfg *config.Config) (string, error) {
 	expirationTime := time.Now().Add(time.Duration(cfg.JWT.ExpireHours) * time.Hour)
 
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:  userID,
+		Email:   email,
+		Version: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -93,8 +193,49 @@ func GenerateToken(userID uuid.UUID, email string, cfg *config.Config) (string,
 	return tokenString, nil
 }
 
+// GenerateChallengeToken 二要素認証のコードの入力を待っているログインのトークンを生成します
+func GenerateChallengeToken(userID uuid.UUID, email string, ttl time.Duration, cfg *config.Config) (string, error) {
+	claims := &Claims{
+		UserID:  userID,
+		Email:   email,
+		Purpose: TokenPurposeTwoFactor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "simple-kanban",
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(cfg.JWT.SecretKey))
+}
+
+// ValidateChallengeToken 二要素認証のコードの入力を待っているログインのトークンを検証します
+func ValidateChallengeToken(tokenString string, cfg *config.Config) (*Claims, error) {
+	claims, err := parseToken(tokenString, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != TokenPurposeTwoFactor {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
 // ValidateToken JWTトークンを検証します
 func ValidateToken(tokenString string, cfg *config.Config) (*Claims, error) {
+	claims, err := parseToken(tokenString, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+// parseToken JWTトークンの署名と有効期限を検証します
+func parseToken(tokenString string, cfg *config.Config) (*Claims, error) {
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
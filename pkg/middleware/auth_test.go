@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"simple-kanban/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTokenAuthenticator スコープのチェックに渡されたリクエストボディを記録するTokenAuthenticator
+type recordingTokenAuthenticator struct {
+	body []byte
+}
+
+func (a *recordingTokenAuthenticator) AuthenticateToken(token string, access TokenAccess) (uuid.UUID, string, error) {
+	a.body = access.Body()
+	return uuid.New(), "cli@example.com", nil
+}
+
+// 上限を超えるボディは途中までの内容をスコープのチェックに渡さず、ハンドラには全体を渡すことのテスト
+func TestAuthMiddleware_TokenAccessBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokens := &recordingTokenAuthenticator{}
+	var handled string
+	router := gin.New()
+	router.POST("/api/v1/tasks", AuthMiddleware(&config.Config{}, tokens, nil), func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		handled = string(body)
+		c.Status(http.StatusCreated)
+	})
+	request := func(body string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+PersonalAccessTokenPrefix+"secret")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	body := `{"title":"タスク","column_id":100}`
+	request(body)
+	assert.Equal(t, body, string(tokens.body))
+	assert.Equal(t, body, handled)
+
+	// 説明を上限まで埋めてcolumn_idを最後に置いたボディ
+	padded := `{"title":"タスク","description":"` + strings.Repeat("a", tokenAccessBodyLimit) + `","column_id":200}`
+	request(padded)
+	assert.Nil(t, tokens.body)
+	assert.Equal(t, padded, handled)
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// プリフライトリクエストで、楽観的ロックに使うIf-MatchとETagを許可することのテスト
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CORSMiddleware())
+	router.PUT("/api/v1/tasks/1", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/tasks/1", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	req.Header.Set("Access-Control-Request-Headers", "authorization, content-type, if-match")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	allowHeaders := strings.Split(w.Header().Get("Access-Control-Allow-Headers"), ", ")
+	assert.Contains(t, allowHeaders, "If-Match")
+	exposeHeaders := strings.Split(w.Header().Get("Access-Control-Expose-Headers"), ", ")
+	assert.Contains(t, exposeHeaders, "ETag")
+}
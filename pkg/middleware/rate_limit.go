@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 満杯になったバケットを削除する間隔（使われなくなったキーでメモリが増え続けないようにする）
+const rateLimitSweepInterval = time.Minute
+
+// RateLimiter キーごとのトークンバケットでリクエスト数を制限します
+// バケットの容量は1分あたりの上限と同じで、1分かけて満杯まで補充されます（短時間の集中は容量まで許容する）
+type RateLimiter struct {
+	limit int
+	rate  float64 // 1秒あたりに補充するトークン数
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket キーごとの残りのトークン
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimitResult リクエストを許可したかどうかと、レスポンスヘッダーに設定する値
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // 次のリクエストが許可されるまでの時間（許可された場合は0）
+	ResetAfter time.Duration // バケットが満杯に戻るまでの時間
+}
+
+// NewRateLimiter 1分あたりperMinute回までリクエストを許可するRateLimiterを作成
+// perMinuteが0以下の場合はnilを返し、ミドルウェアは制限を行いません
+func NewRateLimiter(perMinute int) *RateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &RateLimiter{
+		limit:   perMinute,
+		rate:    float64(perMinute) / 60,
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Allow キーのバケットからトークンを1つ取り出し、リクエストを許可するかどうかを返します
+func (l *RateLimiter) Allow(key string) RateLimitResult {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(l.limit), updated: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(float64(l.limit), bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rate)
+	bucket.updated = now
+
+	result := RateLimitResult{Limit: l.limit}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.duration(1 - bucket.tokens)
+	}
+	result.Remaining = int(bucket.tokens)
+	result.ResetAfter = l.duration(float64(l.limit) - bucket.tokens)
+	return result
+}
+
+// sweep 満杯に戻ったバケットを削除します（満杯のバケットは新しく作成した場合と同じ状態のため）
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rate >= float64(l.limit) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// duration トークンがn個補充されるまでの時間を返します
+func (l *RateLimiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// RateLimitKeyByIP クライアントのIPアドレスごとに制限するキー
+func RateLimitKeyByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimitKeyByUser 認証済みのユーザーごとに制限するキー（認証前の場合はIPアドレスごと）
+func RateLimitKeyByUser(c *gin.Context) string {
+	if userID, err := GetUserIDFromContext(c); err == nil {
+		return "user:" + userID.String()
+	}
+	return RateLimitKeyByIP(c)
+}
+
+// RateLimitMiddleware リクエスト数を制限するミドルウェア
+// X-RateLimit-Limit・X-RateLimit-Remaining・X-RateLimit-Reset（満杯に戻る時刻のUNIX時間）ヘッダーを付け、
+// 上限を超えた場合はRetry-Afterヘッダーとともに429を返します。limiterがnilの場合は何もしません
+func RateLimitMiddleware(limiter *RateLimiter, key func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		result := limiter.Allow(key(c))
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(limiter.now().Add(result.ResetAfter).Unix(), 10))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "リクエストが多すぎます。しばらくしてから再度お試しください",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// 容量までの集中は許可し、上限を超えた場合は補充されるまで429を返すことのテスト
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Unix(1700000000, 0)
+	limiter := NewRateLimiter(2)
+	limiter.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.GET("/", RateLimitMiddleware(limiter, RateLimitKeyByIP), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	request := func(ip string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = ip + ":12345"
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("192.0.2.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, http.StatusOK, request("192.0.2.1").Code)
+
+	w = request("192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Equal(t, "1700000060", w.Header().Get("X-RateLimit-Reset"))
+
+	// IPアドレスごとに制限する
+	assert.Equal(t, http.StatusOK, request("192.0.2.2").Code)
+
+	// 1分あたり2回のため、30秒で1回分補充される
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, http.StatusOK, request("192.0.2.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, request("192.0.2.1").Code)
+
+	// 満杯に戻ったバケットは削除する
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, http.StatusOK, request("192.0.2.1").Code)
+	assert.Len(t, limiter.buckets, 1)
+
+	// 0以下の場合は制限しない
+	assert.Nil(t, NewRateLimiter(0))
+}